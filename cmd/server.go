@@ -2,7 +2,12 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,15 +16,36 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	gommonlog "github.com/labstack/gommon/log"
 	"github.com/tiagos4ntos/device-manager/internal/config"
 	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand"
+	brandrepository "github.com/tiagos4ntos/device-manager/internal/domain/brand/repository"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal"
+	disposalrepository "github.com/tiagos4ntos/device-manager/internal/domain/disposal/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location"
+	locationrepository "github.com/tiagos4ntos/device-manager/internal/domain/location/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance"
+	maintenancerepository "github.com/tiagos4ntos/device-manager/internal/domain/maintenance/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model"
+	modelrepository "github.com/tiagos4ntos/device-manager/internal/domain/model/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/report"
+	reportrepository "github.com/tiagos4ntos/device-manager/internal/domain/report/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation"
+	reservationrepository "github.com/tiagos4ntos/device-manager/internal/domain/reservation/repository"
+	"github.com/tiagos4ntos/device-manager/internal/lifecycle"
+	"github.com/tiagos4ntos/device-manager/internal/network/apidoc"
+	"github.com/tiagos4ntos/device-manager/internal/network/graphqlapi"
+	"github.com/tiagos4ntos/device-manager/internal/network/grpcapi"
 	"github.com/tiagos4ntos/device-manager/internal/network/handler"
+	appmiddleware "github.com/tiagos4ntos/device-manager/internal/network/middleware"
+	"github.com/tiagos4ntos/device-manager/internal/network/ratelimit"
 	"github.com/tiagos4ntos/device-manager/internal/network/router"
-
-	echoSwagger "github.com/swaggo/echo-swagger"
-	_ "github.com/tiagos4ntos/device-manager/docs"
+	"github.com/tiagos4ntos/device-manager/internal/network/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // @title Device Manager API
@@ -35,79 +61,317 @@ import (
 // @BasePath /
 
 func main() {
-	cfg := config.LoadConfig()
+	args := os.Args[1:]
+	if len(args) >= 2 && args[0] == "config" && args[1] == "print" {
+		os.Exit(printConfig(args[2:]))
+	}
+
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
 
 	log.Printf("%v:starting...", cfg.AppName)
 
 	// validate config
 	if err := cfg.Validate(); err != nil {
-		log.Fatalf("invalid configuration: %v", err)
+		log.Fatalf("invalid configuration:\n%v", err)
 	}
+	configWatcher := config.NewWatcher(cfg, args)
 
-	// initialize database connection
-	psqlConn, err := database.NewPostgresDB(cfg.DatabaseHost, cfg.DatabasePort, cfg.DatabaseUser, cfg.DatabasePass, cfg.DatabaseName)
+	// create a context that cancels on SIGINT/SIGTERM/os.Interrupt, giving up
+	// the startup as well
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	app := lifecycle.NewManager(
+		time.Duration(cfg.ShutdownTimeout)*time.Second,
+		time.Duration(cfg.ShutdownDrainDelay)*time.Second,
+	)
+	requests := &lifecycle.Requests{}
+
+	// initialize database connection, the connector opening new connections with the latest secrets
+	dbConnector, err := database.NewConnector(cfg.DatabaseSettings())
+	if err != nil {
+		log.Fatalf("invalid database settings: %v", err)
+	}
+	psqlConn, err := database.NewPostgresDB(ctx, dbConnector)
 	if err != nil {
 		log.Fatalf("failed to connect to database: (%v) ", err.Error())
 	}
-	defer psqlConn.Close()
 
 	// run database migrations
 	database.MigrateUp(psqlConn)
 
-	// initialize device repository
+	// initialize device, attribute definition, brand, model, location, reservation, maintenance and report repositories
 	deviceRepository := repository.NewDeviceRepository(psqlConn)
+	attributeDefinitionRepository := repository.NewAttributeDefinitionRepository(psqlConn)
+	brandRepository := brandrepository.NewBrandRepository(psqlConn)
+	modelRepository := modelrepository.NewModelRepository(psqlConn)
+	locationRepository := locationrepository.NewLocationRepository(psqlConn)
+	reservationRepository := reservationrepository.NewReservationRepository(psqlConn)
+	maintenanceRepository := maintenancerepository.NewMaintenanceRepository(psqlConn)
+	reportRepository := reportrepository.NewReportRepository(psqlConn)
+	disposalRepository := disposalrepository.NewDisposalRepository(psqlConn)
 
-	// initialize device service
-	deviceService := device.NewDeviceService(deviceRepository)
+	// initialize device, attribute definition, brand, model, location, reservation, maintenance and report services
+	brandService := brand.NewBrandService(brandRepository)
+	modelService := model.NewModelService(modelRepository, brandService)
+	deviceService := device.NewDeviceService(deviceRepository, attributeDefinitionRepository, brandService, modelService)
+	attributeDefinitionService := device.NewAttributeDefinitionService(attributeDefinitionRepository)
+	locationService := location.NewLocationService(locationRepository)
+	reservationService := reservation.NewReservationService(reservationRepository, deviceService)
+	maintenanceService := maintenance.NewMaintenanceService(maintenanceRepository)
+	reportService := report.NewReportService(reportRepository)
+	disposalService := disposal.NewDisposalService(disposalRepository)
 
 	// initialize echo server
 	e := echo.New()
 
 	// echo settings, middlewares and documentation endpoint
-	configureEcho(e, cfg)
+	// count the rate limits of the clients in memory, or in the database to share them among the instances
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimitStore == "postgres" {
+		rateLimitStore = ratelimit.NewPostgresStore(psqlConn)
+	}
+
+	configureEcho(e, configWatcher, rateLimitStore, requests)
 
-	// initialize device handler
+	// initialize device, attribute definition, brand, model, location, reservation, maintenance and report handlers
 	deviceHandler := handler.NewDeviceHandler(deviceService)
+	attributeDefinitionHandler := handler.NewAttributeDefinitionHandler(attributeDefinitionService)
+	brandHandler := handler.NewBrandHandler(brandService)
+	modelHandler := handler.NewModelHandler(modelService)
+	locationHandler := handler.NewLocationHandler(locationService, deviceService)
+	reservationHandler := handler.NewReservationHandler(reservationService)
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceService)
+	reportHandler := handler.NewReportHandler(reportService)
+	disposalHandler := handler.NewDisposalHandler(disposalService)
+	healthHandler := handler.NewHealthHandler(app.Ready)
+	graphQLHandler := handler.NewGraphQLHandler(graphqlapi.NewSchema(deviceService, brandService, modelService, locationService, reservationService))
 
-	router.RegisterRoutes(e, deviceHandler)
+	router.RegisterRoutes(e, deviceHandler, attributeDefinitionHandler, brandHandler, modelHandler, locationHandler, reservationHandler, maintenanceHandler, reportHandler, disposalHandler, graphQLHandler, healthHandler)
 
-	// create a context that cancels on SIGINT/SIGTERM/os.Interrupt
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
-	defer stop()
+	// follow the device changes notified by the database, for the gRPC watchers
+	deviceChangesListener, err := database.NewPostgresListener(dbConnector, repository.DeviceChangesChannel)
+	if err != nil {
+		log.Fatalf("failed to listen to device changes: (%v) ", err.Error())
+	}
 
-	go func() {
-		log.Printf("%v:ready...", cfg.AppName)
-		if err := e.Start(":" + cfg.ServerPort); err != nil && err != http.ErrServerClosed {
-			e.Logger.Fatal("Server error:", err)
+	// reload the configuration on SIGHUP, new database connections and the device changes listener using the rotated secrets
+	configWatcher.OnReload(func(cfg *config.Config) {
+		e.Logger.SetLevel(logLevel(cfg.LogLevel))
+		if err := dbConnector.Update(cfg.DatabaseSettings()); err != nil {
+			log.Printf("failed to update database settings, keeping the previous ones: %v", err)
+			return
+		}
+		deviceChangesListener.Update()
+	})
+
+	deviceChanges := device.NewChangeFeed()
+
+	// serve HTTPS and gRPC over TLS when a certificate is informed, reading it again once renewed
+	operationTimeout := func() time.Duration {
+		return time.Duration(configWatcher.Config().DatabaseOperationTimeout) * time.Second
+	}
+	grpcOptions := []grpc.ServerOption{
+		grpc.UnaryInterceptor(grpcapi.UnaryOperationTimeout(operationTimeout)),
+		grpc.StreamInterceptor(grpcapi.StreamOperationTimeout(operationTimeout)),
+	}
+	if cfg.TLSEnabled() {
+		certificates, err := tlsconfig.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+		if err != nil {
+			log.Fatalf("failed to load tls certificates: %v", err)
 		}
+		e.Server.TLSConfig = certificates.TLSConfig()
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(certificates.TLSConfig())))
+	}
+
+	// initialize grpc server, serving the device service on its own port
+	grpcServer := grpcapi.NewServer(deviceService, deviceChanges, grpcOptions...)
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GrpcPort)
+	if err != nil {
+		log.Fatalf("failed to listen on grpc port: (%v) ", err.Error())
+	}
+
+	// components are started in order and stopped in reverse order, the
+	// servers first and the database pool last
+	app.Add(lifecycle.Component{Name: "database", Stop: func(context.Context) error { return psqlConn.Close() }})
+	app.Add(lifecycle.Component{Name: "device changes listener", Stop: func(context.Context) error { return deviceChangesListener.Close() }})
+	app.Add(lifecycle.Worker("config watcher", configWatcher.Watch))
+	// forget the rate limit buckets of the clients gone
+	app.Add(lifecycle.Worker("rate limit sweeper", func(ctx context.Context) { sweepRateLimits(ctx, rateLimitStore, operationTimeout) }))
+	app.Add(lifecycle.Worker("device changes", func(ctx context.Context) {
+		repository.ListenDeviceChanges(ctx, deviceChangesListener.NotificationChannel(), deviceChanges.Publish)
+	}))
+	app.Add(lifecycle.Component{
+		Name: "grpc server",
+		Run: func(started func()) error {
+			log.Printf("%v:grpc ready on port %v...", cfg.AppName, cfg.GrpcPort)
+			// the port is bound already, the connections wait for Serve
+			started()
+			return grpcServer.Serve(grpcListener)
+		},
+		Stop: func(ctx context.Context) error {
+			// watch streams never end on their own, closing the feed ends them
+			deviceChanges.Close()
+			return stopGrpcServer(ctx, grpcServer)
+		},
+	})
+	app.Add(lifecycle.Component{
+		Name: "http server",
+		Run: func(started func()) error {
+			// bind the port first so the server is only reported started once
+			// it accepts connections, StartServer serving on the listener set
+			l, err := net.Listen("tcp", ":"+cfg.ServerPort)
+			if err != nil {
+				return err
+			}
+			if e.Server.TLSConfig != nil {
+				e.TLSListener = tls.NewListener(l, e.Server.TLSConfig)
+			} else {
+				e.Listener = l
+			}
+			log.Printf("%v:ready...", cfg.AppName)
+			started()
+			if err := e.StartServer(e.Server); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			if err := e.Shutdown(ctx); err != nil {
+				log.Printf("%d requests still in flight, closing their connections", requests.InFlight())
+				return errors.Join(err, e.Close())
+			}
+			return nil
+		},
+	})
+
+	if err := app.Run(ctx); err != nil {
+		log.Fatalf("%v:shutdown failed:\n%v", cfg.AppName, err)
+	}
+	log.Printf("%v:stopped", cfg.AppName)
+}
+
+// stopGrpcServer stops server once the calls in flight are over, cancelling
+// them once ctx is done.
+func stopGrpcServer(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
 	}()
-	<-ctx.Done()
 
-	e.Logger.Infof("Gracefully shuting down %v...", cfg.AppName)
-	if err := e.Shutdown(context.Background()); err != nil {
-		e.Logger.Fatal("Shutdown failed:", err)
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
 	}
 }
 
-func configureEcho(e *echo.Echo, cfg *config.Config) {
+// configureEcho sets up the server, the middlewares following the configuration
+// reloaded by watcher, but for the server read and write timeouts.
+func configureEcho(e *echo.Echo, watcher *config.Watcher, rateLimitStore ratelimit.Store, requests *lifecycle.Requests) {
+	cfg := watcher.Config()
+
 	e.Debug = false
-	e.DisableHTTP2 = true
+	// HTTP/2 is negotiated over TLS only
+	e.DisableHTTP2 = !cfg.TLSEnabled()
 	e.HideBanner = true
 	e.HidePort = true
 	e.Server.ReadTimeout = time.Duration(cfg.HttpTimeout) * time.Second
 	e.Server.WriteTimeout = time.Duration(cfg.HttpTimeout) * time.Second
+	e.IPExtractor = appmiddleware.IPExtractor(cfg)
 
-	e.Use(middleware.Secure())
-	e.Use(middleware.RequestID())
+	e.Logger.SetLevel(logLevel(cfg.LogLevel))
+
+	e.Use(appmiddleware.TrackRequests(requests))
+	e.Use(appmiddleware.Reloadable(watcher, appmiddleware.Secure))
+	e.Use(appmiddleware.RequestID())
 	e.Use(middleware.Recover())
-	e.Use(middleware.Logger())
-	e.Use(middleware.TimeoutWithConfig(middleware.TimeoutConfig{
-		Timeout: time.Duration(cfg.HttpTimeout) * time.Second,
+	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
+		// requests are logged up to the info level
+		Skipper: func(echo.Context) bool {
+			return logLevel(watcher.Config().LogLevel) > gommonlog.INFO
+		},
 	}))
-	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+	e.Use(appmiddleware.Reloadable(watcher, func(cfg *config.Config) echo.MiddlewareFunc {
+		return middleware.TimeoutWithConfig(middleware.TimeoutConfig{
+			Timeout: time.Duration(cfg.HttpTimeout) * time.Second,
+		})
 	}))
+	e.Use(appmiddleware.Reloadable(watcher, appmiddleware.OperationTimeout))
+	e.Use(appmiddleware.Reloadable(watcher, appmiddleware.CORS))
+	e.Use(appmiddleware.Reloadable(watcher, appmiddleware.RateLimit(rateLimitStore)))
+
+	docsHandler, err := apidoc.Handler(router.QueryOperations()...)
+	if err != nil {
+		log.Fatalf("failed to build api documentation: %v", err)
+	}
+	e.GET("/api/*", docsHandler)
+}
+
+// sweepRateLimits forgets the idle buckets every minute, each sweep bounded by
+// the database operation timeout in effect.
+func sweepRateLimits(ctx context.Context, store ratelimit.Store, operationTimeout func() time.Duration) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := store.Sweep(database.WithOperationTimeout(ctx, operationTimeout()), time.Hour); err != nil {
+				log.Printf("failed to sweep rate limits: %v", err)
+			}
+		}
+	}
+}
+
+// logLevel returns the level of the server logs named by level, one of
+// config.LogLevels.
+func logLevel(level string) gommonlog.Lvl {
+	switch level {
+	case "debug":
+		return gommonlog.DEBUG
+	case "warn":
+		return gommonlog.WARN
+	case "error":
+		return gommonlog.ERROR
+	case "off":
+		return gommonlog.OFF
+	default:
+		return gommonlog.INFO
+	}
+}
+
+// printConfig writes the effective configuration, secrets redacted, followed
+// by its problems, returning the exit code.
+func printConfig(args []string) int {
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		return 2
+	}
 
-	e.GET("/api/*", echoSwagger.WrapHandler)
+	if err := cfg.Print(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print the configuration: %v\n", err)
+		return 1
+	}
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		return 1
+	}
+	return 0
 }
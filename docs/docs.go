@@ -23,9 +23,53 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/devices": {
+        "/attribute-definitions": {
             "get": {
-                "description": "Get all devices",
+                "description": "Returns the custom attribute schema of the tenant, ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attribute-definitions"
+                ],
+                "summary": "List attribute definitions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AttributeDefinitionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/attribute-definitions/{name}": {
+            "put": {
+                "description": "Declares the type of a custom attribute of the tenant devices, whether it is required and, for strings, its allowed values. Devices are validated against the schema when they are created or updated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,16 +77,122 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "attribute-definitions"
                 ],
-                "summary": "List devices",
+                "summary": "Create or replace an attribute definition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attribute definition payload",
+                        "name": "definition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PutAttributeDefinitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttributeDefinitionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes an attribute from the tenant schema, the values already stored on devices are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attribute-definitions"
+                ],
+                "summary": "Delete an attribute definition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/brands": {
+            "get": {
+                "description": "Returns the brand catalogue ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "brands"
+                ],
+                "summary": "List brands",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.DeviceResponse"
+                                "$ref": "#/definitions/dto.BrandResponse"
                             }
                         }
                     },
@@ -55,7 +205,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Registers a new device",
+                "description": "Registers a brand in the catalogue. Names and aliases are matched case insensitively and without company suffixes such as Inc or Ltd, so they must not resolve to another brand.",
                 "consumes": [
                     "application/json"
                 ],
@@ -63,17 +213,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "brands"
                 ],
-                "summary": "Create a new device",
+                "summary": "Create a brand",
                 "parameters": [
                     {
-                        "description": "Device payload",
-                        "name": "device",
+                        "description": "Brand payload",
+                        "name": "brand",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateDeviceRequest"
+                            "$ref": "#/definitions/dto.BrandRequest"
                         }
                     }
                 ],
@@ -81,7 +231,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.DeviceResponse"
+                            "$ref": "#/definitions/dto.BrandResponse"
                         }
                     },
                     "400": {
@@ -90,6 +240,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.DefaultErrorResult"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -99,20 +255,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/devices/{id}": {
+        "/brands/{id}": {
             "get": {
-                "description": "Returns a single device by its ID",
+                "description": "Returns a single brand with its aliases",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "brands"
                 ],
-                "summary": "Get device by ID",
+                "summary": "Get brand by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device ID",
+                        "description": "Brand ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -122,7 +278,64 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.DeviceResponse"
+                            "$ref": "#/definitions/dto.BrandResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Renames a brand and replaces its aliases, the devices of the brand are renamed too",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "brands"
+                ],
+                "summary": "Update a brand",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Brand payload",
+                        "name": "brand",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BrandRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BrandResponse"
                         }
                     },
                     "400": {
@@ -137,6 +350,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.DefaultErrorResult"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -146,18 +365,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Removes a device by ID",
+                "description": "Removes a brand from the catalogue, only brands without devices can be deleted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "brands"
                 ],
-                "summary": "Delete a device",
+                "summary": "Delete a brand",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Device ID",
+                        "description": "Brand ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -167,12 +386,24 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/errors.DefaultErrorResult"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -181,62 +412,2692 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CreateDeviceRequest": {
-            "type": "object",
-            "required": [
-                "brand",
-                "name",
-                "state"
-            ],
-            "properties": {
-                "brand": {
-                    "type": "string",
-                    "example": "Motorola"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Moto G100"
-                },
-                "state": {
-                    "type": "string",
-                    "enum": [
-                        "available",
-                        "in-use",
-                        "inactive"
-                    ],
-                    "example": "available"
-                }
-            }
         },
-        "dto.DeviceResponse": {
-            "type": "object",
-            "properties": {
-                "brand": {
-                    "type": "string",
-                    "example": "Apple"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-08-31T21:00:00Z"
-                },
-                "deleted_at": {
-                    "type": "string",
-                    "example": "null"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "iPhone 13"
-                },
+        "/devices": {
+            "get": {
+                "description": "Get all devices, optionally filtered and sorted. When the \"q\" parameter is informed a full-text and fuzzy search is made on name and brand, results are ranked by relevance and carry the highlighted matches. Custom attributes are filtered with one attr.\u003cname\u003e parameter per attribute, eg. attr.carrier=vodafone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "List devices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant whose attribute schema types the attr.\u003cname\u003e filters",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Devices ordered by name, or dto.DeviceSearchResponse items ordered by rank when q is informed",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DeviceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a new device on the database with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Create a new device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant whose attribute schema the device attributes must follow",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Device payload",
+                        "name": "device",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/by-serial/{serial}": {
+            "get": {
+                "description": "Returns a single device by its serial number, the lookup is case insensitive",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Get device by serial number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/stats": {
+            "get": {
+                "description": "Counts the devices by state, brand and optionally location, with the number of devices in each state at the end of every day of a period, rebuilt from the device history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Inventory stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeviceStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}": {
+            "get": {
+                "description": "Returns a single device by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Get device by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing device name and brand but only when the state is not \"in-use\", the fiel state can be updated anytime",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Updates device data by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant whose attribute schema the device attributes must follow",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Updated device payload",
+                        "name": "device",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a device by ID, only devices that are not \"in-use\" nor under \"maintenance\" can be deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Delete a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/disposal": {
+            "get": {
+                "description": "Returns how and when a retired device left the inventory",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "disposal"
+                ],
+                "summary": "Get the disposal record of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisposalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/history": {
+            "get": {
+                "description": "Returns what happened to a device, newest events first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Get device history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DeviceHistoryEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/maintenance": {
+            "post": {
+                "description": "Opens a maintenance record for an available or inactive device, the device stays under maintenance until the record is closed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Send a device to maintenance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Maintenance payload",
+                        "name": "maintenance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OpenMaintenanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/move": {
+            "post": {
+                "description": "Takes a device to another location, whatever its state, and records the move in the device history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Move a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Destination location",
+                        "name": "move",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MoveDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/reservations": {
+            "get": {
+                "description": "Returns the reservations of a device ordered by start, optionally only those overlapping a period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List the reservations of a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ReservationResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Books a device for a period, the periods booked for a device never overlap and inactive or retired devices cannot be booked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Reserve a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reservation payload",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/retire": {
+            "post": {
+                "description": "Disposes of an available or inactive device, devices in use can only be retired when lost or stolen. Recycled and sold devices must have had their data wiped. Retired devices cannot be updated, moved nor reserved and their upcoming reservations are cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "disposal"
+                ],
+                "summary": "Retire a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Disposal payload",
+                        "name": "disposal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RetireDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisposalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Queries devices along with their brand, model, location and reservations, or creates, updates and deletes devices. The schema is described in docs/graphql.md and can be introspected. Errors of the operation are answered with 200 on the errors of the response, each with a code extension",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Run a GraphQL operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant whose attribute schema applies to the devices",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "query, operationName and variables of the operation",
+                        "name": "operation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Answers 200 while the application is running, including while it shuts down",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Answers 200 once the application is started, and 503 as soon as it starts shutting down so no new requests are sent while the ones in flight are drained",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "Returns the locations ordered by name, each with the number of devices per state kept at it or below it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "List locations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.LocationResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a site, a room inside a site or a shelf inside a room, names are unique within the parent location regardless of case",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Create a location",
+                "parameters": [
+                    {
+                        "description": "Location payload",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}": {
+            "get": {
+                "description": "Returns a single location with the number of devices per state kept at it or below it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get location by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Renames a location or moves it, with everything inside it, to another parent location. The kind of a location cannot be changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Update a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location payload",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a location, only locations without locations or devices inside can be deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Delete a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}/devices": {
+            "get": {
+                "description": "Returns the devices kept at a location or any location below it, accepting the same filters, sorting and search as GET /devices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "List the devices of a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant whose attribute schema types the attr.\u003cname\u003e filters",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DeviceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance": {
+            "get": {
+                "description": "Returns the maintenance records, most recently opened first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "List maintenance records",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MaintenanceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/{id}": {
+            "get": {
+                "description": "Returns a single maintenance record",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Get maintenance record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Maintenance record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/{id}/close": {
+            "post": {
+                "description": "Ends the maintenance of a device, which becomes available again. The final cost replaces the estimated one when informed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Close a maintenance record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Maintenance record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resolution and final cost",
+                        "name": "maintenance",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloseMaintenanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/models": {
+            "get": {
+                "description": "Returns the model catalogue ordered by brand and name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "models"
+                ],
+                "summary": "List models",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ModelResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a device model of a brand in the catalogue, model names are unique within a brand regardless of case and spacing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "models"
+                ],
+                "summary": "Create a model",
+                "parameters": [
+                    {
+                        "description": "Model payload",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/{id}": {
+            "get": {
+                "description": "Returns a single model with its specs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "models"
+                ],
+                "summary": "Get model by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the specs of a model, when the model moves to another brand its devices follow it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "models"
+                ],
+                "summary": "Update a model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Model payload",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a model from the catalogue, only models without devices can be deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "models"
+                ],
+                "summary": "Delete a model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/depreciation": {
+            "get": {
+                "description": "Depreciates the purchase price of every device in a straight line to zero over its useful life, and totals the book values per brand and currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Device book values",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DepreciationReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/warranty": {
+            "get": {
+                "description": "Returns the devices whose warranty ends in the next days, the ones ending first come first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "List expiring warranties",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WarrantyEntryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "description": "Returns the reservations made by someone ordered by start, optionally only those overlapping a period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List the reservations of a user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ReservationResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "get": {
+                "description": "Returns a single reservation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get reservation by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancels a booked reservation, freeing its period for other bookings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Cancel a reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/checkout": {
+            "post": {
+                "description": "Hands the reserved device over during the reservation period, the device goes from available to in-use",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Check out a reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.DefaultErrorResult"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AttributeDefinitionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                },
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vodafone",
+                        "orange",
+                        "o2"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "carrier"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "type": "string",
+                    "example": "string"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                }
+            }
+        },
+        "dto.BrandBookValueResponse": {
+            "type": "object",
+            "properties": {
+                "book_value": {
+                    "type": "number",
+                    "example": 4859.64
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "devices": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "purchase_total": {
+                    "type": "number",
+                    "example": 9588
+                }
+            }
+        },
+        "dto.BrandCountResponse": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "brand_id": {
+                    "type": "string",
+                    "example": "3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"
+                },
+                "devices": {
+                    "type": "integer",
+                    "example": 18
+                }
+            }
+        },
+        "dto.BrandRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hp",
+                        "hewlett packard"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hewlett-Packard"
+                }
+            }
+        },
+        "dto.BrandResponse": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hp",
+                        "hewlett packard"
+                    ]
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hewlett-Packard"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                }
+            }
+        },
+        "dto.CloseMaintenanceRequest": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "number",
+                    "example": 120
+                },
+                "resolution": {
+                    "type": "string",
+                    "example": "screen replaced"
+                }
+            }
+        },
+        "dto.CreateDeviceRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "state"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "carrier": "vodafone",
+                        "cost_center": "cc-42"
+                    }
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Motorola"
+                },
+                "imei": {
+                    "type": "string",
+                    "example": "490154203237518"
+                },
+                "invoice_reference": {
+                    "type": "string",
+                    "example": "INV-2025-0042"
+                },
+                "model_id": {
+                    "type": "string",
+                    "example": "d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"
+                },
+                "model_identifier": {
+                    "type": "string",
+                    "example": "XT2125-4"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Moto G100"
+                },
+                "os_version": {
+                    "type": "string",
+                    "example": "Android 13"
+                },
+                "purchase_currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "purchase_date": {
+                    "description": "PurchaseDate and WarrantyEndsOn are dates in the YYYY-MM-DD format",
+                    "type": "string",
+                    "example": "2025-03-10"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "example": 349.9
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "ZY22C5XKQ7"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "in-use",
+                        "inactive"
+                    ],
+                    "example": "available"
+                },
+                "supplier": {
+                    "type": "string",
+                    "example": "ACME Distribution"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "qa",
+                        "lab"
+                    ]
+                },
+                "warranty_ends_on": {
+                    "type": "string",
+                    "example": "2027-03-10"
+                }
+            }
+        },
+        "dto.DailyStateCountResponse": {
+            "type": "object",
+            "properties": {
+                "by_state": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "available": 30,
+                        "in-use": 8,
+                        "inactive": 3,
+                        "maintenance": 1
+                    }
+                },
+                "day": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                }
+            }
+        },
+        "dto.DepreciationReportResponse": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "brands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BrandBookValueResponse"
+                    }
+                },
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DeviceBookValueResponse"
+                    }
+                },
+                "useful_life_months": {
+                    "type": "integer",
+                    "example": 36
+                }
+            }
+        },
+        "dto.DeviceBookValueResponse": {
+            "type": "object",
+            "properties": {
+                "book_value": {
+                    "type": "number",
+                    "example": 404.97
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Galaxy S21"
+                },
+                "purchase_date": {
+                    "type": "string",
+                    "example": "2024-03-10"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "example": 799
+                }
+            }
+        },
+        "dto.DeviceHistoryEventResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-01T10:00:00Z"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "moved"
+                },
+                "from_location_id": {
+                    "type": "string",
+                    "example": "5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401"
+                },
+                "from_state": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0e1f2a3b-4c5d-4e6f-8a7b-9c0d1e2f3a4b"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "back from the field test"
+                },
+                "to_location_id": {
+                    "type": "string",
+                    "example": "7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603"
+                },
+                "to_state": {
+                    "type": "string",
+                    "example": "null"
+                }
+            }
+        },
+        "dto.DeviceResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "carrier": "vodafone",
+                        "cost_center": "cc-42"
+                    }
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Apple"
+                },
+                "brand_id": {
+                    "type": "string",
+                    "example": "3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "imei": {
+                    "type": "string",
+                    "example": "490154203237518"
+                },
+                "invoice_reference": {
+                    "type": "string",
+                    "example": "INV-2025-0042"
+                },
+                "location_id": {
+                    "type": "string",
+                    "example": "7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603"
+                },
+                "model_id": {
+                    "type": "string",
+                    "example": "d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"
+                },
+                "model_identifier": {
+                    "type": "string",
+                    "example": "iPhone14,5"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iPhone 13"
+                },
+                "os_version": {
+                    "type": "string",
+                    "example": "iOS 17.5"
+                },
+                "purchase_currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "purchase_date": {
+                    "type": "string",
+                    "example": "2025-03-10"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "example": 999
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "F2LXK1ABCD12"
+                },
                 "state": {
                     "type": "string",
-                    "example": "available"
+                    "example": "available"
+                },
+                "supplier": {
+                    "type": "string",
+                    "example": "ACME Distribution"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "qa",
+                        "lab"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                },
+                "warranty_ends_on": {
+                    "type": "string",
+                    "example": "2027-03-10"
+                }
+            }
+        },
+        "dto.DeviceStatsResponse": {
+            "type": "object",
+            "properties": {
+                "by_brand": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BrandCountResponse"
+                    }
+                },
+                "by_location": {
+                    "description": "ByLocation is only present when by_location is requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LocationCountResponse"
+                    }
+                },
+                "by_state": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "available": 30,
+                        "in-use": 8,
+                        "inactive": 3,
+                        "maintenance": 1
+                    }
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DailyStateCountResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dto.DisposalResponse": {
+            "type": "object",
+            "properties": {
+                "certificate_reference": {
+                    "type": "string",
+                    "example": "WEEE-2025-0193"
+                },
+                "data_wiped": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"
+                },
+                "disposed_at": {
+                    "type": "string",
+                    "example": "2025-09-10T16:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2d3e4f50-6a7b-4c8d-9e0f-1a2b3c4d5e06"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "battery swollen, sent to the recycling partner"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "recycled"
+                }
+            }
+        },
+        "dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "live",
+                        "ready",
+                        "draining"
+                    ],
+                    "example": "ready"
+                }
+            }
+        },
+        "dto.LocationCountResponse": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "location_id": {
+                    "type": "string",
+                    "example": "7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Shelf A"
+                }
+            }
+        },
+        "dto.LocationRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "site",
+                        "room",
+                        "shelf"
+                    ],
+                    "example": "room"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "QA Lab"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "4c0d1e20-3f4a-4b5c-9d6e-7f8091a2b300"
+                }
+            }
+        },
+        "dto.LocationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                },
+                "device_counts": {
+                    "description": "DeviceCounts holds the number of devices per state at the location and below it",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "available": 3,
+                        "in-use": 2,
+                        "inactive": 0
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "room"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "QA Lab"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "4c0d1e20-3f4a-4b5c-9d6e-7f8091a2b300"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                }
+            }
+        },
+        "dto.MaintenanceResponse": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string",
+                    "example": "2025-09-12T16:30:00Z"
+                },
+                "cost": {
+                    "type": "number",
+                    "example": 89.9
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"
+                },
+                "expected_return_date": {
+                    "type": "string",
+                    "example": "2025-09-15"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9c8b7a6d-5e4f-4a3b-9c2d-1e0f9a8b7c05"
+                },
+                "opened_at": {
+                    "type": "string",
+                    "example": "2025-09-01T10:00:00Z"
+                },
+                "overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "cracked screen"
+                },
+                "resolution": {
+                    "type": "string",
+                    "example": "screen replaced"
+                },
+                "vendor": {
+                    "type": "string",
+                    "example": "iFix Lisboa"
+                }
+            }
+        },
+        "dto.ModelRequest": {
+            "type": "object",
+            "required": [
+                "brand_id",
+                "form_factor",
+                "name",
+                "os_family"
+            ],
+            "properties": {
+                "brand_id": {
+                    "type": "string",
+                    "example": "1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704"
+                },
+                "form_factor": {
+                    "type": "string",
+                    "enum": [
+                        "phone",
+                        "tablet",
+                        "laptop",
+                        "wearable",
+                        "other"
+                    ],
+                    "example": "phone"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pixel 7"
+                },
+                "os_family": {
+                    "type": "string",
+                    "enum": [
+                        "android",
+                        "ios",
+                        "ipados",
+                        "windows",
+                        "macos",
+                        "linux",
+                        "chromeos",
+                        "other"
+                    ],
+                    "example": "android"
+                },
+                "release_year": {
+                    "type": "integer",
+                    "example": 2022
+                },
+                "storage_variants": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        128,
+                        256
+                    ]
+                }
+            }
+        },
+        "dto.ModelResponse": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Google"
+                },
+                "brand_id": {
+                    "type": "string",
+                    "example": "1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                },
+                "form_factor": {
+                    "type": "string",
+                    "example": "phone"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pixel 7"
+                },
+                "os_family": {
+                    "type": "string",
+                    "example": "android"
+                },
+                "release_year": {
+                    "type": "integer",
+                    "example": 2022
+                },
+                "storage_variants": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        128,
+                        256
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                }
+            }
+        },
+        "dto.MoveDeviceRequest": {
+            "type": "object",
+            "required": [
+                "location_id"
+            ],
+            "properties": {
+                "location_id": {
+                    "type": "string",
+                    "example": "7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "back from the field test"
+                }
+            }
+        },
+        "dto.OpenMaintenanceRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "cost": {
+                    "type": "number",
+                    "example": 89.9
+                },
+                "expected_return_date": {
+                    "description": "ExpectedReturnDate is a date in the YYYY-MM-DD format",
+                    "type": "string",
+                    "example": "2025-09-15"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "cracked screen"
+                },
+                "vendor": {
+                    "type": "string",
+                    "example": "iFix Lisboa"
+                }
+            }
+        },
+        "dto.PutAttributeDefinitionRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vodafone",
+                        "orange",
+                        "o2"
+                    ]
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "boolean"
+                    ],
+                    "example": "string"
+                }
+            }
+        },
+        "dto.ReservationRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "reserved_by",
+                "starts_at"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-09-04T18:00:00Z"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "regression tests of the 2.4 release"
+                },
+                "reserved_by": {
+                    "type": "string",
+                    "example": "Ana Lima"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-09-04T09:00:00Z"
+                }
+            }
+        },
+        "dto.ReservationResponse": {
+            "type": "object",
+            "properties": {
+                "checked_out_at": {
+                    "type": "string",
+                    "example": "2025-09-04T09:05:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-31T21:00:00Z"
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-09-04T18:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c06"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "regression tests of the 2.4 release"
+                },
+                "reserved_by": {
+                    "type": "string",
+                    "example": "Ana Lima"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-09-04T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "booked"
                 },
                 "updated_at": {
                     "type": "string",
@@ -244,6 +3105,159 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.RetireDeviceRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "certificate_reference": {
+                    "type": "string",
+                    "example": "WEEE-2025-0193"
+                },
+                "data_wiped": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "note": {
+                    "type": "string",
+                    "example": "battery swollen, sent to the recycling partner"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "lost",
+                        "stolen",
+                        "recycled",
+                        "sold"
+                    ],
+                    "example": "recycled"
+                }
+            }
+        },
+        "dto.UpdateDeviceRequest": {
+            "type": "object",
+            "required": [
+                "state"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "carrier": "vodafone",
+                        "cost_center": "cc-42"
+                    }
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "imei": {
+                    "type": "string",
+                    "example": "356938035643809"
+                },
+                "invoice_reference": {
+                    "type": "string",
+                    "example": "INV-2025-0042"
+                },
+                "model_id": {
+                    "type": "string",
+                    "example": "d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"
+                },
+                "model_identifier": {
+                    "type": "string",
+                    "example": "SM-G991B"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Galaxy S21"
+                },
+                "os_version": {
+                    "type": "string",
+                    "example": "Android 14"
+                },
+                "purchase_currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "purchase_date": {
+                    "description": "PurchaseDate and WarrantyEndsOn are dates in the YYYY-MM-DD format",
+                    "type": "string",
+                    "example": "2025-03-10"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "example": 799
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "R58R12ABCDE"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "in-use",
+                        "inactive"
+                    ],
+                    "example": "in-use"
+                },
+                "supplier": {
+                    "type": "string",
+                    "example": "ACME Distribution"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "qa",
+                        "lab"
+                    ]
+                },
+                "warranty_ends_on": {
+                    "type": "string",
+                    "example": "2027-03-10"
+                }
+            }
+        },
+        "dto.WarrantyEntryResponse": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "days_left": {
+                    "type": "integer",
+                    "example": 19
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Galaxy S21"
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "R58R12ABCDE"
+                },
+                "supplier": {
+                    "type": "string",
+                    "example": "ACME Distribution"
+                },
+                "warranty_ends_on": {
+                    "type": "string",
+                    "example": "2025-09-20"
+                }
+            }
+        },
         "errors.DefaultErrorResult": {
             "type": "object",
             "properties": {
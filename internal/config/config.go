@@ -2,30 +2,129 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"log"
+	"net"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
-	"sync"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/tiagos4ntos/device-manager/internal/database"
 )
 
+// maxApplicationNameLength is the longest application_name Postgres keeps.
+const maxApplicationNameLength = 63
+
+// minHSTSPreloadMaxAge is the shortest max age, a year, browsers accept to
+// preload a host.
+const minHSTSPreloadMaxAge = 31536000
+
+// RateLimitStores lists where the rate limits may be counted.
+var RateLimitStores = []string{"memory", "postgres"}
+
+// LogLevels lists the supported log levels, from the most verbose.
+var LogLevels = []string{"debug", "info", "warn", "error", "off"}
+
 type Config struct {
 	AppName     string
 	ServerPort  string
+	GrpcPort    string
 	HttpTimeout int
+	// ShutdownTimeout bounds, in seconds, the shutdown, the readiness probe
+	// failing ShutdownDrainDelay seconds before the servers stop accepting
+	// requests.
+	ShutdownTimeout    int
+	ShutdownDrainDelay int
+
+	LogLevel string
+
+	CORSAllowOrigins     []string
+	CORSAllowHeaders     []string
+	CORSExposeHeaders    []string
+	CORSAllowCredentials bool
+	CORSMaxAge           int
+
+	// HSTSMaxAge, in seconds, is sent on the HTTPS responses only, zero meaning
+	// no Strict-Transport-Security header.
+	HSTSMaxAge            int
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+	ContentSecurityPolicy string
+
+	// RateLimitStore is where the requests are counted, memory or postgres. The
+	// limits of the clients are taken by minute, a burst of requests being
+	// allowed at once, zero requests a minute meaning no limit.
+	RateLimitStore          string
+	RateLimitReadPerMinute  int
+	RateLimitReadBurst      int
+	RateLimitWritePerMinute int
+	RateLimitWriteBurst     int
+	// RateLimitAPIKeys are the API keys whose requests are limited apart, those
+	// of the other clients being limited by IP. RateLimitAPIKeysFile, when
+	// informed, names the file they are read from in place of the keys above.
+	RateLimitAPIKeys     []string
+	RateLimitAPIKeysFile string
+	// TrustedProxies are the CIDRs of the proxies whose X-Forwarded-For header
+	// tells the client IP.
+	TrustedProxies []string
+
+	// TLSCertFile and TLSKeyFile, when informed, serve HTTPS and gRPC over TLS,
+	// the client certificates being required and verified by TLSClientCAFile
+	// when informed.
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSClientCAFile string
+
+	// ConfigWatchInterval is the interval, in seconds, to check the config file
+	// and the secret files for changes, zero meaning they are reloaded on
+	// SIGHUP only.
+	ConfigWatchInterval int
 
 	DatabaseHost string
 	DatabasePort string
 	DatabaseUser string
 	DatabasePass string
 	DatabaseName string
+	// DatabaseURL, when informed, is used in place of the host, port, user,
+	// password and name above.
+	DatabaseURL string
+	// DatabasePassFile and DatabaseURLFile, when informed, name the files the
+	// password and the URL are read from, Docker and Kubernetes secrets, in
+	// place of the values above.
+	DatabasePassFile string
+	DatabaseURLFile  string
+
+	DatabaseSSLMode     string
+	DatabaseSSLRootCert string
+	DatabaseSSLCert     string
+	DatabaseSSLKey      string
+
+	DatabaseApplicationName  string
+	DatabaseStatementTimeout int
+	// DatabaseOperationTimeout bounds, in seconds, each database operation
+	// made on behalf of a request, zero leaving them bounded by the request.
+	DatabaseOperationTimeout int
+	DatabaseMaxOpenConns     int
+	DatabaseMaxIdleConns     int
+	DatabaseConnMaxLifetime  int
+	DatabaseConnMaxIdleTime  int
+
+	DatabaseConnectMaxAttempts    int
+	DatabaseConnectTimeout        int
+	DatabaseConnectInitialBackoff int
+	DatabaseConnectMaxBackoff     int
+
+	// sources tells where the value of each setting comes from, by key.
+	sources map[string]Source
+	// configFile is the path of the config file read, if any.
+	configFile string
 }
 
 var (
-	cfg  *Config
-	once sync.Once
-
 	DefaultAppName              = "device-manager"
 	DefaultPostgresHost         = "localhost"
 	DefaultPostgresPort         = "5432"
@@ -34,62 +133,278 @@ var (
 	DefaultPostgresDatabaseName = "device_manager"
 )
 
-func LoadConfig() *Config {
-	once.Do(func() {
-		err := godotenv.Load()
-		if err != nil {
-			log.Println("No .env file found, falling back to system env")
+// Load reads the configuration from, by increasing precedence, the defaults,
+// the config file, the environment along with the .env file, and the
+// command-line flags in args. Every malformed value is reported, not only the
+// first one.
+func Load(args []string) (*Config, error) {
+	c := &Config{sources: map[string]Source{}}
+	settings := c.settings()
+
+	for _, s := range settings {
+		if err := s.value.Set(s.def); err != nil {
+			panic(fmt.Sprintf("config: default of %s: %v", s.key, err))
 		}
+		c.sources[s.key] = SourceDefault
+	}
+
+	flags, configFile, err := parseFlags(settings, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []error
 
-		cfg = &Config{
-			AppName:     getEnvOrDefaultValue("APP_NAME", DefaultAppName),
-			ServerPort:  getEnvOrDefaultValue("SERVER_PORT", "8080"),
-			HttpTimeout: getIntFromValue(getEnvOrDefaultValue("HTTP_TIMEOUT_IN_SECONDS", "10")),
+	c.configFile = configFile
+	if configFile != "" {
+		problems = append(problems, c.loadFile(settings, configFile)...)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, falling back to system env")
+	}
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.key); ok && value != "" {
+			problems = append(problems, c.set(s, value, SourceEnv))
+		}
+	}
 
-			DatabaseHost: getEnvOrDefaultValue("DATABASE_HOST", DefaultPostgresHost),
-			DatabasePort: getEnvOrDefaultValue("DATABASE_PORT", DefaultPostgresPort),
-			DatabaseUser: getEnvOrDefaultValue("DATABASE_USER", DefaultPostgresUserName),
-			DatabasePass: os.Getenv("DATABASE_PASS"),
-			DatabaseName: getEnvOrDefaultValue("DATABASE_NAME", DefaultPostgresDatabaseName),
+	for _, s := range settings {
+		if value, ok := flags[s.key]; ok {
+			problems = append(problems, c.set(s, value, SourceFlag))
 		}
-	})
-	return cfg
+	}
+
+	problems = append(problems, c.loadSecrets(settings)...)
+
+	if err := errors.Join(problems...); err != nil {
+		return nil, err
+	}
+
+	if c.DatabaseApplicationName == "" {
+		c.DatabaseApplicationName = c.AppName
+	}
+
+	return c, nil
 }
 
+// Validate checks the values are in range and consistent with each other,
+// reporting every problem found.
 func (c *Config) Validate() error {
+	var problems []error
+	fail := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
 	if c.ServerPort == "" {
-		return errors.New("server port is required")
+		fail("server port is required")
+	} else if !validPort(c.ServerPort) {
+		fail("server port must be a number between 1 and 65535")
 	}
-	if c.DatabaseHost == "" {
-		return errors.New("database host is required")
+	if c.GrpcPort == "" {
+		fail("grpc port is required")
+	} else if !validPort(c.GrpcPort) {
+		fail("grpc port must be a number between 1 and 65535")
 	}
-	if c.DatabasePort == "" {
-		return errors.New("database port is required")
+	if c.GrpcPort != "" && c.GrpcPort == c.ServerPort {
+		fail("grpc port must differ from the server port")
 	}
-	if c.DatabaseUser == "" {
-		return errors.New("database user is required")
+	if c.HttpTimeout <= 0 {
+		fail("http timeout must be positive")
 	}
-	if c.DatabasePass == "" {
-		return errors.New("database password is required")
+	if c.ShutdownTimeout <= 0 {
+		fail("shutdown timeout must be positive")
 	}
-	if c.DatabaseName == "" {
-		return errors.New("database name is required")
+	if c.ShutdownDrainDelay < 0 {
+		fail("shutdown drain delay must not be negative")
+	} else if c.ShutdownDrainDelay >= c.ShutdownTimeout && c.ShutdownTimeout > 0 {
+		fail("shutdown drain delay must be below the shutdown timeout")
+	}
+	if !slices.Contains(LogLevels, c.LogLevel) {
+		fail("log level must be one of: %s", strings.Join(LogLevels, ", "))
+	}
+	if len(c.CORSAllowOrigins) == 0 {
+		fail("cors allow origins is required, * allowing any")
+	}
+	for _, origin := range c.CORSAllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" {
+			fail("cors allow origin %q must be a scheme and host, as in https://example.com", origin)
+		}
+	}
+	if c.CORSAllowCredentials && slices.Contains(c.CORSAllowOrigins, "*") {
+		fail("cors allow credentials requires the allowed origins to be listed, not *")
+	}
+	if c.CORSMaxAge < 0 {
+		fail("cors max age must not be negative")
+	}
+	if c.HSTSMaxAge < 0 {
+		fail("hsts max age must not be negative")
+	}
+	if c.HSTSPreload && (c.HSTSMaxAge < minHSTSPreloadMaxAge || !c.HSTSIncludeSubdomains) {
+		fail("hsts preload requires a max age of at least %d seconds including the subdomains", minHSTSPreloadMaxAge)
+	}
+	if !slices.Contains(RateLimitStores, c.RateLimitStore) {
+		fail("rate limit store must be one of: %s", strings.Join(RateLimitStores, ", "))
+	}
+	for _, limit := range []struct {
+		name             string
+		perMinute, burst int
+	}{
+		{"read", c.RateLimitReadPerMinute, c.RateLimitReadBurst},
+		{"write", c.RateLimitWritePerMinute, c.RateLimitWriteBurst},
+	} {
+		if limit.perMinute < 0 {
+			fail("rate limit %s per minute must not be negative", limit.name)
+		} else if limit.perMinute > 0 && limit.burst < 1 {
+			fail("rate limit %s burst must be at least 1", limit.name)
+		}
+	}
+	for _, proxy := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil {
+			fail("trusted proxy %q must be a CIDR, as in 10.0.0.0/8", proxy)
+		}
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		fail("tls cert and key must be informed together")
+	}
+	if c.TLSClientCAFile != "" && c.TLSCertFile == "" {
+		fail("tls client ca requires the tls cert and key")
+	}
+	for _, file := range []struct{ name, path string }{
+		{"cert", c.TLSCertFile},
+		{"key", c.TLSKeyFile},
+		{"client ca", c.TLSClientCAFile},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			fail("tls %s is not readable: %w", file.name, err)
+		}
+	}
+	if c.ConfigWatchInterval < 0 {
+		fail("config watch interval must not be negative")
 	}
-	return nil
-}
 
-func getEnvOrDefaultValue(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+	if c.DatabaseURL != "" {
+		if _, err := c.DatabaseSettings().DSN(); err != nil {
+			problems = append(problems, err)
+		}
+	} else {
+		if c.DatabaseHost == "" {
+			fail("database host is required")
+		}
+		if c.DatabasePort == "" {
+			fail("database port is required")
+		} else if !validPort(c.DatabasePort) {
+			fail("database port must be a number between 1 and 65535")
+		}
+		if c.DatabaseUser == "" {
+			fail("database user is required")
+		}
+		if c.DatabasePass == "" {
+			fail("database password is required")
+		}
+		if c.DatabaseName == "" {
+			fail("database name is required")
+		}
+	}
+	if c.DatabaseSSLMode != "" && !slices.Contains(database.SSLModes, c.DatabaseSSLMode) {
+		fail("database sslmode must be one of: disable, require, verify-ca, verify-full")
 	}
-	return defaultValue
+	if (c.DatabaseSSLCert == "") != (c.DatabaseSSLKey == "") {
+		fail("database ssl cert and key must be informed together")
+	}
+	for _, file := range []struct{ name, path string }{
+		{"root cert", c.DatabaseSSLRootCert},
+		{"cert", c.DatabaseSSLCert},
+		{"key", c.DatabaseSSLKey},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			fail("database ssl %s is not readable: %w", file.name, err)
+		}
+	}
+	if len(c.DatabaseApplicationName) > maxApplicationNameLength {
+		fail("database application name must be up to %d characters", maxApplicationNameLength)
+	}
+	if c.DatabaseStatementTimeout < 0 {
+		fail("database statement timeout must not be negative")
+	}
+	if c.DatabaseOperationTimeout < 0 {
+		fail("database operation timeout must not be negative")
+	}
+	if c.DatabaseMaxOpenConns < 0 {
+		fail("database max open conns must not be negative")
+	}
+	if c.DatabaseMaxIdleConns < 0 {
+		fail("database max idle conns must not be negative")
+	}
+	if c.DatabaseMaxOpenConns > 0 && c.DatabaseMaxIdleConns > c.DatabaseMaxOpenConns {
+		fail("database max idle conns must not exceed max open conns")
+	}
+	if c.DatabaseConnMaxLifetime < 0 {
+		fail("database conn max lifetime must not be negative")
+	}
+	if c.DatabaseConnMaxIdleTime < 0 {
+		fail("database conn max idle time must not be negative")
+	}
+	if c.DatabaseConnectMaxAttempts < 0 {
+		fail("database connect max attempts must not be negative")
+	}
+	if c.DatabaseConnectTimeout <= 0 {
+		fail("database connect timeout must be positive")
+	}
+	if c.DatabaseConnectInitialBackoff <= 0 {
+		fail("database connect initial backoff must be positive")
+	}
+	if time.Duration(c.DatabaseConnectMaxBackoff)*time.Second < time.Duration(c.DatabaseConnectInitialBackoff)*time.Millisecond {
+		fail("database connect max backoff must not be below the initial backoff")
+	}
+
+	return errors.Join(problems...)
 }
 
-func getIntFromValue(value string) int {
-	intValue, err := strconv.Atoi(value)
+// TLSEnabled tells whether HTTPS and gRPC are served over TLS.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != ""
+}
 
-	if err != nil {
-		return 0
+// DatabaseSettings returns how to connect to the database, the durations
+// being configured in seconds but for the initial connect backoff.
+func (c *Config) DatabaseSettings() database.Settings {
+	return database.Settings{
+		URL:              c.DatabaseURL,
+		Host:             c.DatabaseHost,
+		Port:             c.DatabasePort,
+		User:             c.DatabaseUser,
+		Password:         c.DatabasePass,
+		Name:             c.DatabaseName,
+		SSLMode:          c.DatabaseSSLMode,
+		SSLRootCert:      c.DatabaseSSLRootCert,
+		SSLCert:          c.DatabaseSSLCert,
+		SSLKey:           c.DatabaseSSLKey,
+		ApplicationName:  c.DatabaseApplicationName,
+		StatementTimeout: time.Duration(c.DatabaseStatementTimeout) * time.Second,
+		MaxOpenConns:     c.DatabaseMaxOpenConns,
+		MaxIdleConns:     c.DatabaseMaxIdleConns,
+		ConnMaxLifetime:  time.Duration(c.DatabaseConnMaxLifetime) * time.Second,
+		ConnMaxIdleTime:  time.Duration(c.DatabaseConnMaxIdleTime) * time.Second,
+		ConnectRetry: database.ConnectRetry{
+			MaxAttempts:    c.DatabaseConnectMaxAttempts,
+			Timeout:        time.Duration(c.DatabaseConnectTimeout) * time.Second,
+			InitialBackoff: time.Duration(c.DatabaseConnectInitialBackoff) * time.Millisecond,
+			MaxBackoff:     time.Duration(c.DatabaseConnectMaxBackoff) * time.Second,
+		},
 	}
-	return intValue
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 1 && n <= 65535
 }
@@ -0,0 +1,391 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the config file when the --config flag is not informed.
+const ConfigFileEnv = "CONFIG_FILE"
+
+const redacted = "<redacted>"
+
+// Source tells where the value of a setting comes from.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+	// SourceSecretFile is the source of the secrets read from the files named
+	// by their _FILE settings.
+	SourceSecretFile Source = "secret file"
+)
+
+// setting binds a configuration field to its key, the name of its environment
+// variable. The config file key is the key lower cased, as in database_host,
+// and the flag the file key with dashes, as in --database-host.
+type setting struct {
+	key   string
+	def   string
+	usage string
+	value flag.Value
+	// redact, when set, hides the secrets of the value when printed.
+	redact func(value string) string
+	// file, when set, is the path of a file the value is read from, informed
+	// by the setting named after the key with the _FILE suffix.
+	file *string
+	// reloadable settings are applied when the configuration is reloaded, the
+	// others on restart.
+	reloadable bool
+}
+
+func (s *setting) fileKey() string {
+	return strings.ToLower(s.key)
+}
+
+func (s *setting) flagName() string {
+	return strings.ReplaceAll(s.fileKey(), "_", "-")
+}
+
+func (c *Config) settings() []*setting {
+	settings := []*setting{
+		{key: "APP_NAME", def: DefaultAppName, usage: "name of the application", value: stringValue{&c.AppName}},
+		{key: "SERVER_PORT", def: "8080", usage: "port of the REST API", value: stringValue{&c.ServerPort}},
+		{key: "GRPC_PORT", def: "9090", usage: "port of the gRPC API", value: stringValue{&c.GrpcPort}},
+		{key: "HTTP_TIMEOUT_IN_SECONDS", def: "10", usage: "timeout of the HTTP requests", value: intValue{&c.HttpTimeout}, reloadable: true},
+		{key: "SHUTDOWN_TIMEOUT_IN_SECONDS", def: "30", usage: "time given to the shutdown, the requests in flight being cut short past it", value: intValue{&c.ShutdownTimeout}},
+		{key: "SHUTDOWN_DRAIN_DELAY_IN_SECONDS", def: "0", usage: "time the readiness probe fails before the servers stop accepting requests", value: intValue{&c.ShutdownDrainDelay}},
+		{key: "LOG_LEVEL", def: "info", usage: "level of the server logs: debug, info, warn, error or off, requests being logged up to info", value: stringValue{&c.LogLevel}, reloadable: true},
+		{key: "CORS_ALLOW_ORIGINS", def: "*", usage: "comma separated origins allowed to call the REST API from browsers, * for any", value: stringsValue{&c.CORSAllowOrigins}, reloadable: true},
+		{key: "CORS_ALLOW_HEADERS", usage: "comma separated request headers allowed from browsers, the ones requested when empty", value: stringsValue{&c.CORSAllowHeaders}, reloadable: true},
+		{key: "CORS_EXPOSE_HEADERS", usage: "comma separated response headers exposed to browsers", value: stringsValue{&c.CORSExposeHeaders}, reloadable: true},
+		{key: "CORS_ALLOW_CREDENTIALS", def: "false", usage: "whether browsers may send cookies and authorization, not allowed along with the * origin", value: boolValue{&c.CORSAllowCredentials}, reloadable: true},
+		{key: "CORS_MAX_AGE_IN_SECONDS", def: "0", usage: "time browsers may cache the preflight responses, 0 for their default", value: intValue{&c.CORSMaxAge}, reloadable: true},
+
+		{key: "HSTS_MAX_AGE_IN_SECONDS", def: "0", usage: "time browsers must keep to HTTPS, sent on HTTPS responses, 0 to send no Strict-Transport-Security header", value: intValue{&c.HSTSMaxAge}, reloadable: true},
+		{key: "HSTS_INCLUDE_SUBDOMAINS", def: "true", usage: "whether HTTPS applies to the subdomains as well", value: boolValue{&c.HSTSIncludeSubdomains}, reloadable: true},
+		{key: "HSTS_PRELOAD", def: "false", usage: "whether the host may be preloaded by browsers as HTTPS only", value: boolValue{&c.HSTSPreload}, reloadable: true},
+		{key: "CONTENT_SECURITY_POLICY", usage: "Content-Security-Policy header of the responses, none when empty", value: stringValue{&c.ContentSecurityPolicy}, reloadable: true},
+
+		{key: "RATE_LIMIT_STORE", def: "memory", usage: "where the rate limits are counted: memory, for each instance, or postgres, shared by the instances", value: stringValue{&c.RateLimitStore}},
+		{key: "RATE_LIMIT_READ_PER_MINUTE", def: "1200", usage: "GET requests a client may send a minute, 0 for no limit", value: intValue{&c.RateLimitReadPerMinute}, reloadable: true},
+		{key: "RATE_LIMIT_READ_BURST", def: "100", usage: "GET requests a client may send at once", value: intValue{&c.RateLimitReadBurst}, reloadable: true},
+		{key: "RATE_LIMIT_WRITE_PER_MINUTE", def: "300", usage: "POST, PUT and DELETE requests a client may send a minute, 0 for no limit", value: intValue{&c.RateLimitWritePerMinute}, reloadable: true},
+		{key: "RATE_LIMIT_WRITE_BURST", def: "30", usage: "POST, PUT and DELETE requests a client may send at once", value: intValue{&c.RateLimitWriteBurst}, reloadable: true},
+		{key: "RATE_LIMIT_API_KEYS", usage: "comma separated API keys whose requests are limited apart, the other requests being limited by client IP", value: stringsValue{&c.RateLimitAPIKeys}, redact: redactSecret, file: &c.RateLimitAPIKeysFile, reloadable: true},
+		{key: "TRUSTED_PROXIES", usage: "comma separated CIDRs of the proxies whose X-Forwarded-For header tells the client IP, the connection IP being used when empty", value: stringsValue{&c.TrustedProxies}},
+
+		{key: "TLS_CERT_FILE", usage: "path to the certificate served over HTTPS and gRPC, along with its key, plain HTTP being served when empty", value: stringValue{&c.TLSCertFile}},
+		{key: "TLS_KEY_FILE", usage: "path to the key of the certificate", value: stringValue{&c.TLSKeyFile}},
+		{key: "TLS_CLIENT_CA_FILE", usage: "path to the CA certificates verifying the client certificates, required when informed", value: stringValue{&c.TLSClientCAFile}},
+
+		{key: "CONFIG_WATCH_INTERVAL_IN_SECONDS", def: "0", usage: "interval to check the config file and the secret files for changes, 0 to reload on SIGHUP only", value: intValue{&c.ConfigWatchInterval}},
+
+		{key: "DATABASE_HOST", def: DefaultPostgresHost, usage: "host of the database", value: stringValue{&c.DatabaseHost}},
+		{key: "DATABASE_PORT", def: DefaultPostgresPort, usage: "port of the database", value: stringValue{&c.DatabasePort}},
+		{key: "DATABASE_USER", def: DefaultPostgresUserName, usage: "user of the database", value: stringValue{&c.DatabaseUser}},
+		{key: "DATABASE_PASS", usage: "password of the database user", value: stringValue{&c.DatabasePass}, redact: redactSecret, file: &c.DatabasePassFile, reloadable: true},
+		{key: "DATABASE_NAME", def: DefaultPostgresDatabaseName, usage: "name of the database", value: stringValue{&c.DatabaseName}},
+		{key: "DATABASE_URL", usage: "connection URL, used in place of the database host, port, user, password and name", value: stringValue{&c.DatabaseURL}, redact: redactURL, file: &c.DatabaseURLFile, reloadable: true},
+
+		{key: "DATABASE_SSLMODE", usage: "TLS mode: disable, require, verify-ca or verify-full", value: stringValue{&c.DatabaseSSLMode}},
+		{key: "DATABASE_SSLROOTCERT", usage: "path to the CA certificate verifying the database", value: stringValue{&c.DatabaseSSLRootCert}},
+		{key: "DATABASE_SSLCERT", usage: "path to the client certificate", value: stringValue{&c.DatabaseSSLCert}},
+		{key: "DATABASE_SSLKEY", usage: "path to the client certificate key", value: stringValue{&c.DatabaseSSLKey}},
+
+		{key: "DATABASE_APPLICATION_NAME", usage: "name the connections report to the database, the application name by default", value: stringValue{&c.DatabaseApplicationName}},
+		{key: "DATABASE_STATEMENT_TIMEOUT_IN_SECONDS", def: "0", usage: "timeout of the statements, 0 for none", value: intValue{&c.DatabaseStatementTimeout}},
+		{key: "DATABASE_OPERATION_TIMEOUT_IN_SECONDS", def: "5", usage: "timeout of each database operation made on behalf of a request, 0 for none", value: intValue{&c.DatabaseOperationTimeout}, reloadable: true},
+		{key: "DATABASE_MAX_OPEN_CONNS", def: "25", usage: "maximum open connections, 0 for no limit", value: intValue{&c.DatabaseMaxOpenConns}},
+		{key: "DATABASE_MAX_IDLE_CONNS", def: "10", usage: "maximum idle connections", value: intValue{&c.DatabaseMaxIdleConns}},
+		{key: "DATABASE_CONN_MAX_LIFETIME_IN_SECONDS", def: "1800", usage: "age connections are closed at, 0 to keep them", value: intValue{&c.DatabaseConnMaxLifetime}},
+		{key: "DATABASE_CONN_MAX_IDLE_TIME_IN_SECONDS", def: "300", usage: "idle time connections are closed after, 0 to keep them", value: intValue{&c.DatabaseConnMaxIdleTime}},
+
+		{key: "DATABASE_CONNECT_MAX_ATTEMPTS", def: "0", usage: "connection attempts on startup, 0 to retry until the timeout", value: intValue{&c.DatabaseConnectMaxAttempts}},
+		{key: "DATABASE_CONNECT_TIMEOUT_IN_SECONDS", def: "60", usage: "time to wait on startup for the database", value: intValue{&c.DatabaseConnectTimeout}},
+		{key: "DATABASE_CONNECT_INITIAL_BACKOFF_IN_MILLISECONDS", def: "500", usage: "wait after the first failed connection attempt", value: intValue{&c.DatabaseConnectInitialBackoff}},
+		{key: "DATABASE_CONNECT_MAX_BACKOFF_IN_SECONDS", def: "10", usage: "longest wait between connection attempts", value: intValue{&c.DatabaseConnectMaxBackoff}},
+	}
+
+	for _, s := range settings {
+		if s.file != nil {
+			settings = append(settings, &setting{
+				key:        s.key + "_FILE",
+				usage:      "file holding the value of " + s.key,
+				value:      stringValue{s.file},
+				reloadable: s.reloadable,
+			})
+		}
+	}
+
+	return settings
+}
+
+// parseFlags returns the values of the flags informed in args, by setting key,
+// along with the config file to read.
+func parseFlags(settings []*setting, args []string) (map[string]string, string, error) {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(ConfigFileEnv), "path to a YAML config file, also read from "+ConfigFileEnv)
+
+	values := map[string]string{}
+	for _, s := range settings {
+		_, isBool := s.value.(boolValue)
+		fs.Var(&recordedValue{key: s.key, values: values, isBool: isBool}, s.flagName(), s.usage)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, "", err
+	}
+	if fs.NArg() > 0 {
+		return nil, "", fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	return values, *configFile, nil
+}
+
+// loadFile applies the settings of a YAML file holding a flat mapping of
+// setting file keys to scalar values.
+func (c *Config) loadFile(settings []*setting, path string) []error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return []error{fmt.Errorf("config file: %w", err)}
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return []error{fmt.Errorf("config file %s: %w", path, err)}
+	}
+
+	byFileKey := map[string]*setting{}
+	for _, s := range settings {
+		byFileKey[s.fileKey()] = s
+	}
+
+	var problems []error
+	for key, value := range values {
+		s, ok := byFileKey[key]
+		if !ok {
+			problems = append(problems, fmt.Errorf("config file %s: unknown setting %q", path, key))
+			continue
+		}
+		switch value.(type) {
+		case map[string]any, []any:
+			problems = append(problems, fmt.Errorf("config file %s: %s must be a single value", path, key))
+			continue
+		case nil:
+			continue
+		}
+		problems = append(problems, c.set(s, fmt.Sprint(value), SourceFile))
+	}
+
+	return problems
+}
+
+// loadSecrets applies the settings informed as files, trailing line breaks
+// left out, reporting every file that could not be read or holds a malformed
+// value.
+func (c *Config) loadSecrets(settings []*setting) []error {
+	var problems []error
+
+	for _, s := range settings {
+		if s.file == nil || *s.file == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(*s.file)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s_FILE: %w", s.key, err))
+			continue
+		}
+		problems = append(problems, c.set(s, strings.TrimRight(string(content), "\r\n"), SourceSecretFile))
+	}
+
+	return problems
+}
+
+func (c *Config) set(s *setting, value string, source Source) error {
+	if err := s.value.Set(value); err != nil {
+		return fmt.Errorf("%s from %s: %w", s.key, source, err)
+	}
+	c.sources[s.key] = source
+	return nil
+}
+
+// display returns the value of the setting as printed, secrets redacted.
+func (s *setting) display() string {
+	value := s.value.String()
+	if s.redact != nil && value != "" {
+		value = s.redact(value)
+	}
+	return value
+}
+
+// Print writes the effective configuration in the config file format, with
+// the source of each value and the secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	for _, s := range c.settings() {
+		value := s.display()
+		switch s.value.(type) {
+		case intValue, boolValue:
+		default:
+			value = strconv.Quote(value)
+		}
+
+		source := c.sources[s.key]
+		if source == "" {
+			source = SourceDefault
+		}
+
+		if _, err := fmt.Fprintf(w, "%s: %s # %s\n", s.fileKey(), value, source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func redactSecret(string) string {
+	return redacted
+}
+
+// sensitiveURLParams are the query parameters of a connection URL carrying
+// secrets, which the driver accepts in place of the userinfo password.
+var sensitiveURLParams = []string{"password", "sslpassword"}
+
+// redactURL masks the password of the userinfo and of the query parameters, as
+// url.URL.Redacted does for the userinfo.
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		return redacted
+	}
+
+	query := u.Query()
+	masked := false
+	for _, param := range sensitiveURLParams {
+		if query.Has(param) {
+			query.Set(param, "xxxxx")
+			masked = true
+		}
+	}
+	if masked {
+		u.RawQuery = query.Encode()
+	}
+
+	return u.Redacted()
+}
+
+type stringValue struct {
+	target *string
+}
+
+func (v stringValue) Set(value string) error {
+	*v.target = value
+	return nil
+}
+
+func (v stringValue) String() string {
+	if v.target == nil {
+		return ""
+	}
+	return *v.target
+}
+
+// intValue fails on anything but an integer, rather than falling back to zero.
+type intValue struct {
+	target *int
+}
+
+func (v intValue) Set(value string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", value)
+	}
+	*v.target = n
+	return nil
+}
+
+func (v intValue) String() string {
+	if v.target == nil {
+		return "0"
+	}
+	return strconv.Itoa(*v.target)
+}
+
+// boolValue fails on anything but a boolean, as in true, false, 1 or 0.
+type boolValue struct {
+	target *bool
+}
+
+func (v boolValue) Set(value string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", value)
+	}
+	*v.target = b
+	return nil
+}
+
+func (v boolValue) String() string {
+	if v.target == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*v.target)
+}
+
+// stringsValue holds a comma separated list, blanks left out.
+type stringsValue struct {
+	target *[]string
+}
+
+func (v stringsValue) Set(value string) error {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	*v.target = values
+	return nil
+}
+
+func (v stringsValue) String() string {
+	if v.target == nil {
+		return ""
+	}
+	return strings.Join(*v.target, ",")
+}
+
+// recordedValue keeps the value of a flag, applied once the file and the
+// environment are.
+type recordedValue struct {
+	key    string
+	values map[string]string
+	isBool bool
+}
+
+func (v *recordedValue) Set(value string) error {
+	v.values[v.key] = value
+	return nil
+}
+
+func (v *recordedValue) String() string {
+	if v == nil || v.values == nil {
+		return ""
+	}
+	return v.values[v.key]
+}
+
+// IsBoolFlag lets the boolean flags be informed without a value, as in
+// --hsts-preload.
+func (v *recordedValue) IsBoolFlag() bool {
+	return v.isBool
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Watcher holds the configuration in effect, loading it again on SIGHUP and,
+// when ConfigWatchInterval is set, when the config file or the secret files
+// change. Only the reloadable settings are applied, the others on restart.
+type Watcher struct {
+	args    []string
+	current atomic.Pointer[Config]
+
+	mu          sync.Mutex
+	subscribers []func(cfg *Config)
+}
+
+// NewWatcher watches cfg, loaded from args.
+func NewWatcher(cfg *Config, args []string) *Watcher {
+	w := &Watcher{args: args}
+	w.current.Store(cfg)
+	return w
+}
+
+// Config returns the configuration in effect. It must not be changed, a new
+// one being set on reload.
+func (w *Watcher) Config() *Config {
+	return w.current.Load()
+}
+
+// OnReload registers fn to be called with each configuration reloaded.
+func (w *Watcher) OnReload(fn func(cfg *Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload loads the configuration again, applying the reloadable settings that
+// changed and logging every change. When the new configuration is invalid the
+// one in effect is kept.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	loaded, err := Load(w.args)
+	if err != nil {
+		return err
+	}
+	if err := loaded.Validate(); err != nil {
+		return err
+	}
+
+	current := w.current.Load()
+	next, changes := current.merge(loaded)
+	for _, change := range changes {
+		log.Printf("config: %s", change)
+	}
+	if next == current {
+		return nil
+	}
+
+	w.current.Store(next)
+	for _, fn := range w.subscribers {
+		fn(next)
+	}
+	return nil
+}
+
+// Watch reloads the configuration on SIGHUP and, when ConfigWatchInterval is
+// set, when the config file or the secret files change, until ctx is done.
+func (w *Watcher) Watch(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	var tick <-chan time.Time
+	if interval := w.Config().ConfigWatchInterval; interval > 0 {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	files := w.Config().watchedFiles()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+		case <-tick:
+			if latest := w.Config().watchedFiles(); maps.Equal(files, latest) {
+				continue
+			}
+		}
+
+		if err := w.Reload(); err != nil {
+			log.Printf("config: failed to reload, keeping the configuration in effect:\n%v", err)
+		}
+		files = w.Config().watchedFiles()
+	}
+}
+
+// merge returns the configuration with the reloadable settings of loaded,
+// along with the description of each setting changed. The configuration itself
+// is returned when no reloadable setting changed.
+func (c *Config) merge(loaded *Config) (*Config, []string) {
+	next := *c
+	next.sources = maps.Clone(c.sources)
+
+	loadedSettings := map[string]*setting{}
+	for _, s := range loaded.settings() {
+		loadedSettings[s.key] = s
+	}
+
+	var changes []string
+	reloaded := false
+	for _, s := range next.settings() {
+		l := loadedSettings[s.key]
+		if s.value.String() == l.value.String() {
+			continue
+		}
+
+		change := fmt.Sprintf("%s changed from %q to %q", s.key, s.display(), l.display())
+		if !s.reloadable {
+			changes = append(changes, change+", applied on restart")
+			continue
+		}
+
+		if err := next.set(s, l.value.String(), loaded.sources[s.key]); err != nil {
+			panic(err)
+		}
+		changes = append(changes, change)
+		reloaded = true
+	}
+
+	if !reloaded {
+		return c, changes
+	}
+	return &next, changes
+}
+
+// watchedFiles returns the modification time and size of the config file and
+// the secret files, by path, to tell when they change.
+func (c *Config) watchedFiles() map[string]string {
+	paths := []string{c.configFile}
+	for _, s := range c.settings() {
+		if s.file != nil {
+			paths = append(paths, *s.file)
+		}
+	}
+
+	files := map[string]string{}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			files[path] = "missing"
+			continue
+		}
+		if err != nil {
+			files[path] = err.Error()
+			continue
+		}
+		files[path] = fmt.Sprintf("%s %d", info.ModTime(), info.Size())
+	}
+	return files
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWatcher(t *testing.T, content string) (*Watcher, string) {
+	clearEnv(t)
+	path := writeConfigFile(t, content)
+	args := []string{"--config", path}
+
+	cfg, err := Load(args)
+	assert.NoError(t, err)
+	assert.NoError(t, cfg.Validate())
+
+	return NewWatcher(cfg, args), path
+}
+
+func Test_Watcher_Reload(t *testing.T) {
+	const content = "database_pass: s3cret\nhttp_timeout_in_seconds: 10\n"
+
+	t.Run("Reload Reloadable Settings Case", func(t *testing.T) {
+		w, path := newTestWatcher(t, content)
+		previous := w.Config()
+		var reloaded *Config
+		w.OnReload(func(cfg *Config) { reloaded = cfg })
+
+		assert.NoError(t, os.WriteFile(path, []byte("database_pass: s3cret\nhttp_timeout_in_seconds: 20\nlog_level: debug\nserver_port: 8081\n"), 0o600))
+		assert.NoError(t, w.Reload())
+
+		assert.Same(t, w.Config(), reloaded)
+		assert.Equal(t, 20, w.Config().HttpTimeout)
+		assert.Equal(t, "debug", w.Config().LogLevel)
+		assert.Equal(t, "8080", w.Config().ServerPort)
+		assert.Equal(t, 10, previous.HttpTimeout)
+	})
+
+	t.Run("Reload Without Changes Case", func(t *testing.T) {
+		w, _ := newTestWatcher(t, content)
+		previous := w.Config()
+		called := false
+		w.OnReload(func(*Config) { called = true })
+
+		assert.NoError(t, w.Reload())
+
+		assert.Same(t, previous, w.Config())
+		assert.False(t, called)
+	})
+
+	t.Run("Reload Invalid Config Keeping Previous Case", func(t *testing.T) {
+		w, path := newTestWatcher(t, content)
+		previous := w.Config()
+
+		assert.NoError(t, os.WriteFile(path, []byte("database_pass: s3cret\nhttp_timeout_in_seconds: 20\nlog_level: verbose\n"), 0o600))
+
+		assert.ErrorContains(t, w.Reload(), "log level must be one of: debug, info, warn, error, off")
+		assert.Same(t, previous, w.Config())
+	})
+
+	t.Run("Reload Malformed Config Keeping Previous Case", func(t *testing.T) {
+		w, path := newTestWatcher(t, content)
+		previous := w.Config()
+
+		assert.NoError(t, os.WriteFile(path, []byte("database_pass: s3cret\nhttp_timeout_in_seconds: twenty\n"), 0o600))
+
+		assert.ErrorContains(t, w.Reload(), `invalid integer "twenty"`)
+		assert.Same(t, previous, w.Config())
+	})
+
+	t.Run("Reload Rotated Secret File Case", func(t *testing.T) {
+		clearEnv(t)
+		secret := writeConfigFile(t, "s3cret\n")
+		t.Setenv("DATABASE_PASS_FILE", secret)
+		cfg, err := Load(nil)
+		assert.NoError(t, err)
+		w := NewWatcher(cfg, nil)
+
+		assert.NoError(t, os.WriteFile(secret, []byte("rotated\n"), 0o600))
+
+		assert.NoError(t, w.Reload())
+		assert.Equal(t, "rotated", w.Config().DatabasePass)
+		assert.Equal(t, SourceSecretFile, w.Config().sources["DATABASE_PASS"])
+	})
+
+	t.Run("Reload Missing Secret File Keeping Previous Case", func(t *testing.T) {
+		clearEnv(t)
+		secret := writeConfigFile(t, "s3cret\n")
+		t.Setenv("DATABASE_PASS_FILE", secret)
+		cfg, err := Load(nil)
+		assert.NoError(t, err)
+		w := NewWatcher(cfg, nil)
+
+		assert.NoError(t, os.Remove(secret))
+
+		assert.ErrorContains(t, w.Reload(), "DATABASE_PASS_FILE:")
+		assert.Equal(t, "s3cret", w.Config().DatabasePass)
+	})
+}
+
+func Test_Config_Merge(t *testing.T) {
+	clearEnv(t)
+	current, err := Load([]string{"--database-pass", "s3cret"})
+	assert.NoError(t, err)
+	loaded, err := Load([]string{"--database-pass", "rotated", "--server-port", "8081", "--cors-allow-origins", "https://a.example.com, https://b.example.com"})
+	assert.NoError(t, err)
+
+	next, changes := current.merge(loaded)
+
+	assert.Equal(t, []string{
+		`SERVER_PORT changed from "8080" to "8081", applied on restart`,
+		`CORS_ALLOW_ORIGINS changed from "*" to "https://a.example.com,https://b.example.com"`,
+		`DATABASE_PASS changed from "<redacted>" to "<redacted>"`,
+	}, changes)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, next.CORSAllowOrigins)
+	assert.Equal(t, "rotated", next.DatabasePass)
+	assert.Equal(t, "8080", next.ServerPort)
+	assert.Equal(t, "s3cret", current.DatabasePass)
+	assert.Equal(t, SourceDefault, current.sources["CORS_ALLOW_ORIGINS"])
+	assert.Equal(t, SourceFlag, next.sources["CORS_ALLOW_ORIGINS"])
+}
+
+func Test_Config_Watched_Files(t *testing.T) {
+	w, path := newTestWatcher(t, "database_pass: s3cret\n")
+	files := w.Config().watchedFiles()
+	assert.Contains(t, files, path)
+
+	assert.NoError(t, os.WriteFile(path, []byte("database_pass: rotated\n"), 0o600))
+	assert.NotEqual(t, files, w.Config().watchedFiles())
+
+	assert.NoError(t, os.Remove(path))
+	assert.Equal(t, "missing", w.Config().watchedFiles()[path])
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync/atomic"
+
+	"github.com/lib/pq"
+)
+
+// Connector opens the connections of the pool with the settings it was last
+// updated with, so rotated credentials are used by the new connections without
+// a restart while the ones already open are kept.
+type Connector struct {
+	current atomic.Pointer[connectorSettings]
+}
+
+type connectorSettings struct {
+	settings Settings
+	dsn      string
+}
+
+func NewConnector(settings Settings) (*Connector, error) {
+	c := &Connector{}
+	if err := c.Update(settings); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update replaces the settings of the new connections, keeping the previous
+// ones when settings are not valid.
+func (c *Connector) Update(settings Settings) error {
+	dsn, err := settings.DSN()
+	if err != nil {
+		return err
+	}
+	c.current.Store(&connectorSettings{settings: settings, dsn: dsn})
+	return nil
+}
+
+// Settings returns the settings the connections are opened with.
+func (c *Connector) Settings() Settings {
+	return c.current.Load().settings
+}
+
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	connector, err := pq.NewConnector(c.current.Load().dsn)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+func (c *Connector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
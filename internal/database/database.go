@@ -1,26 +1,111 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-
-	_ "github.com/lib/pq"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
 )
 
-func NewPostgresDB(host, port, user, password, dbname string) (*sql.DB, error) {
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbname,
-	)
+// SSLModes lists the sslmode values supported by the driver.
+var SSLModes = []string{"disable", "require", "verify-ca", "verify-full"}
 
-	db, err := sql.Open("postgres", dsn)
-	if err != nil {
-		return nil, err
-	}
+// Settings tells how to reach Postgres and how to size the connection pool.
+type Settings struct {
+	// URL is a postgres:// connection URL, when informed it is used in place of
+	// Host, Port, User, Password and Name.
+	URL      string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+
+	// SSLMode defaults to the sslmode of the URL, or to disable when connecting
+	// by host. The certificates are paths to PEM files.
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+
+	ApplicationName string
+	// StatementTimeout aborts the statements running longer, zero means no timeout.
+	StatementTimeout time.Duration
+
+	// MaxOpenConns and MaxIdleConns bound the pool, zero meaning no limit on open
+	// connections. ConnMaxLifetime and ConnMaxIdleTime close the connections
+	// older or idle for longer, zero meaning they are kept.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	ConnectRetry ConnectRetry
+}
+
+// NewPostgresDB opens the connection pool, waiting for the database to accept
+// connections as told by the ConnectRetry of the connector settings. Once
+// opened, connections lost are replaced by the pool as they are needed, with
+// the settings the connector was last updated with.
+func NewPostgresDB(ctx context.Context, connector *Connector) (*sql.DB, error) {
+	settings := connector.Settings()
+	db := sql.OpenDB(connector)
+
+	db.SetMaxOpenConns(settings.MaxOpenConns)
+	db.SetMaxIdleConns(settings.MaxIdleConns)
+	db.SetConnMaxLifetime(settings.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(settings.ConnMaxIdleTime)
 
-	if err := db.Ping(); err != nil {
+	if err := settings.ConnectRetry.connect(ctx, db.PingContext); err != nil {
+		db.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// DSN returns the connection URL, the options informed on the settings
+// replacing the ones of URL.
+func (s Settings) DSN() (string, error) {
+	var dsn *url.URL
+
+	if s.URL != "" {
+		var err error
+		if dsn, err = url.Parse(s.URL); err != nil {
+			return "", fmt.Errorf("invalid database url: %w", err)
+		}
+		if dsn.Scheme != "postgres" && dsn.Scheme != "postgresql" {
+			return "", fmt.Errorf("invalid database url scheme %q, must be postgres or postgresql", dsn.Scheme)
+		}
+	} else {
+		dsn = &url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(s.User, s.Password),
+			Host:     net.JoinHostPort(s.Host, s.Port),
+			Path:     "/" + s.Name,
+			RawQuery: url.Values{"sslmode": {"disable"}}.Encode(),
+		}
+	}
+
+	query := dsn.Query()
+	setIfInformed(query, "sslmode", s.SSLMode)
+	setIfInformed(query, "sslrootcert", s.SSLRootCert)
+	setIfInformed(query, "sslcert", s.SSLCert)
+	setIfInformed(query, "sslkey", s.SSLKey)
+	setIfInformed(query, "application_name", s.ApplicationName)
+	if s.StatementTimeout > 0 {
+		query.Set("statement_timeout", strconv.FormatInt(s.StatementTimeout.Milliseconds(), 10))
+	}
+	dsn.RawQuery = query.Encode()
+
+	return dsn.String(), nil
+}
+
+func setIfInformed(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
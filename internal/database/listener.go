@@ -0,0 +1,170 @@
+package database
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// notifier is the part of a *pq.Listener a Listener relies on.
+type notifier interface {
+	Listen(channel string) error
+	NotificationChannel() <-chan *pq.Notification
+	Close() error
+}
+
+// Listener listens to the notifications sent on channels with the settings a
+// Connector was last updated with. It reconnects on its own whenever the
+// connection is lost, and opens a new connection once told the settings were
+// updated, so rotated credentials are used for the reconnections as well.
+type Listener struct {
+	connector *Connector
+	channels  []string
+	open      func(dsn string) notifier
+	notify    chan *pq.Notification
+	done      chan struct{}
+	forwarded sync.WaitGroup
+
+	mu      sync.Mutex
+	current notifier
+	// pending listens with the updated settings until it takes the place of
+	// current.
+	pending notifier
+	dsn     string
+	closed  bool
+}
+
+// NewPostgresListener listens to the notifications sent on channels, waiting
+// for the first connection to be opened.
+func NewPostgresListener(connector *Connector, channels ...string) (*Listener, error) {
+	return newListener(connector, openPostgresNotifier, channels...)
+}
+
+func openPostgresNotifier(dsn string) notifier {
+	return pq.NewListener(dsn, 10*time.Second, time.Minute, func(_ pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("database listener: %v", err)
+		}
+	})
+}
+
+func newListener(connector *Connector, open func(dsn string) notifier, channels ...string) (*Listener, error) {
+	l := &Listener{
+		connector: connector,
+		channels:  channels,
+		open:      open,
+		notify:    make(chan *pq.Notification),
+		done:      make(chan struct{}),
+		dsn:       connector.current.Load().dsn,
+	}
+
+	l.current = open(l.dsn)
+	if err := l.listen(l.current); err != nil {
+		l.current.Close()
+		return nil, err
+	}
+	l.forward(l.current)
+
+	return l, nil
+}
+
+// NotificationChannel returns the channel the notifications are received on,
+// a nil notification following each reconnection. It is closed along with the
+// listener.
+func (l *Listener) NotificationChannel() <-chan *pq.Notification {
+	return l.notify
+}
+
+// Update opens a new connection, in the background, when the settings of the
+// connector changed since the listener connected. The previous connection is
+// closed once the new one listens, the changes notified meanwhile being
+// received twice at most.
+func (l *Listener) Update() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	dsn := l.connector.current.Load().dsn
+	if l.closed || dsn == l.dsn {
+		return
+	}
+	l.dsn = dsn
+
+	// settings updated again before the previous update took place
+	if l.pending != nil {
+		l.pending.Close()
+	}
+	next := l.open(dsn)
+	l.pending = next
+
+	go l.replace(next)
+}
+
+func (l *Listener) replace(next notifier) {
+	if err := l.listen(next); err != nil {
+		// closed by a newer update or along with the listener
+		next.Close()
+		return
+	}
+
+	l.mu.Lock()
+	if l.pending != next {
+		l.mu.Unlock()
+		next.Close()
+		return
+	}
+	previous := l.current
+	l.current, l.pending = next, nil
+	l.forward(next)
+	l.mu.Unlock()
+
+	log.Println("database listener: listening with the updated settings")
+	previous.Close()
+}
+
+func (l *Listener) listen(n notifier) error {
+	for _, channel := range l.channels {
+		if err := n.Listen(channel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forward sends the notifications of n on the listener channel until n is
+// closed.
+func (l *Listener) forward(n notifier) {
+	l.forwarded.Add(1)
+	go func() {
+		defer l.forwarded.Done()
+		for notification := range n.NotificationChannel() {
+			select {
+			case l.notify <- notification:
+			case <-l.done:
+				return
+			}
+		}
+	}()
+}
+
+// Close closes the connections and the notification channel.
+func (l *Listener) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	close(l.done)
+	err := l.current.Close()
+	if l.pending != nil {
+		l.pending.Close()
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	l.forwarded.Wait()
+	close(l.notify)
+	return err
+}
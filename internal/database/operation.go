@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type operationTimeoutKey struct{}
+
+// WithOperationTimeout returns a copy of ctx bounding each database operation
+// made with it to timeout, a zero timeout leaving them bounded by ctx only.
+func WithOperationTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, operationTimeoutKey{}, timeout)
+}
+
+// OperationContext returns the context of a database operation, bounded by the
+// operation timeout ctx carries, if any. The cancel func must be called once
+// the operation is over.
+func OperationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout, ok := ctx.Value(operationTimeoutKey{}).(time.Duration); ok {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// IsTimeout tells whether err is caused by an operation running out of time,
+// its context deadline or the statement timeout. Statements canceled because
+// their context was canceled are reported as timed out as well, the caller
+// telling them apart by its own context.
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	// query_canceled
+	return errors.As(err, &pqErr) && pqErr.Code == "57014"
+}
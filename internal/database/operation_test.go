@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OperationContext(t *testing.T) {
+	t.Run("Operation Timeout Case", func(t *testing.T) {
+		ctx, cancel := OperationContext(WithOperationTimeout(context.Background(), time.Minute))
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	})
+
+	t.Run("Without Operation Timeout Case", func(t *testing.T) {
+		ctx, cancel := OperationContext(WithOperationTimeout(context.Background(), 0))
+		defer cancel()
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+	})
+
+	t.Run("Canceled Parent Case", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(WithOperationTimeout(context.Background(), time.Minute))
+		ctx, cancel := OperationContext(parent)
+		defer cancel()
+
+		cancelParent()
+
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
+
+func Test_IsTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Deadline Exceeded Case", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: true},
+		{name: "Query Canceled Case", err: &pq.Error{Code: "57014"}, want: true},
+		{name: "Canceled Case", err: context.Canceled, want: false},
+		{name: "Other Error Case", err: &pq.Error{Code: "23505"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTimeout(tt.err))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ConnectRetry tells how long to wait for the database to accept connections
+// on startup, retrying with an exponential backoff and jitter.
+type ConnectRetry struct {
+	// MaxAttempts bounds the attempts, zero meaning they go on until Timeout.
+	MaxAttempts int
+	// Timeout bounds the time spent connecting, all attempts together.
+	Timeout        time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// backoff returns the wait after the failed attempt, doubling from
+// InitialBackoff up to MaxBackoff, the second half of it being random so
+// instances started together do not retry in lockstep.
+func (r ConnectRetry) backoff(attempt int) time.Duration {
+	wait := r.MaxBackoff
+	if attempt < 32 {
+		wait = min(r.InitialBackoff<<(attempt-1), r.MaxBackoff)
+	}
+	half := wait / 2
+	return half + rand.N(wait-half+1)
+}
+
+// connect calls ping until it succeeds, the attempts or the timeout run out,
+// logging every failed attempt.
+func (r ConnectRetry) connect(ctx context.Context, ping func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx)
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("database: connected on attempt %d", attempt)
+			}
+			return nil
+		}
+
+		if r.MaxAttempts > 0 && attempt >= r.MaxAttempts {
+			return fmt.Errorf("database still unavailable after %d attempts: %w", attempt, err)
+		}
+
+		wait := r.backoff(attempt)
+		log.Printf("database: connection attempt %d failed, retrying in %v: %v", attempt, wait.Round(time.Millisecond), err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database still unavailable after %v: %w", r.Timeout, err)
+		case <-time.After(wait):
+		}
+	}
+}
+
+// IsUnavailable tells whether err comes from the database being unreachable or
+// refusing connections for now, rather than from the statement itself.
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03", "53300":
+			// admin_shutdown, crash_shutdown, cannot_connect_now, too_many_connections
+			return true
+		}
+		return pqErr.Code.Class() == "08"
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
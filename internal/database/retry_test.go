@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Connect_Retry_Backoff(t *testing.T) {
+	retry := ConnectRetry{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, wantMax := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second, 40: time.Second} {
+		for range 20 {
+			wait := retry.backoff(attempt)
+			assert.GreaterOrEqual(t, wait, wantMax/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, wait, wantMax, "attempt %d", attempt)
+		}
+	}
+}
+
+func Test_Connect_Retry(t *testing.T) {
+	errRefused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+	tests := []struct {
+		name         string
+		retry        ConnectRetry
+		failures     int
+		wantAttempts int
+		wantErr      string
+	}{
+		{
+			name:         "Connect On First Attempt Case",
+			retry:        ConnectRetry{Timeout: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+			wantAttempts: 1,
+		},
+		{
+			name:         "Connect After Failed Attempts Case",
+			retry:        ConnectRetry{Timeout: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+			failures:     3,
+			wantAttempts: 4,
+		},
+		{
+			name:         "Connect Out Of Attempts Case",
+			retry:        ConnectRetry{MaxAttempts: 2, Timeout: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+			failures:     5,
+			wantAttempts: 2,
+			wantErr:      "database still unavailable after 2 attempts: dial tcp: connection refused",
+		},
+		{
+			name:         "Connect Out Of Time Case",
+			retry:        ConnectRetry{Timeout: 50 * time.Millisecond, InitialBackoff: time.Second, MaxBackoff: time.Second},
+			failures:     5,
+			wantAttempts: 1,
+			wantErr:      "database still unavailable after 50ms: dial tcp: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := tt.retry.connect(context.TODO(), func(ctx context.Context) error {
+				attempts++
+				if attempts <= tt.failures {
+					return errRefused
+				}
+				return nil
+			})
+
+			assert.Equal(t, tt.wantAttempts, attempts)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+		})
+	}
+}
+
+func Test_Is_Unavailable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Connection Refused Case", err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, want: true},
+		{name: "Bad Connection Case", err: fmt.Errorf("listing devices: %w", driver.ErrBadConn), want: true},
+		{name: "Server Shutting Down Case", err: &pq.Error{Code: "57P01"}, want: true},
+		{name: "Connection Failure Case", err: &pq.Error{Code: "08006"}, want: true},
+		{name: "Too Many Connections Case", err: &pq.Error{Code: "53300"}, want: true},
+		{name: "Unique Violation Case", err: &pq.Error{Code: "23505"}, want: false},
+		{name: "No Rows Case", err: sql.ErrNoRows, want: false},
+		{name: "Generic Error Case", err: errors.New("some database error"), want: false},
+		{name: "Nil Case", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsUnavailable(tt.err))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// TxMaxAttempts is how many times, at most, a transaction is run when it keeps
+// failing on serialization failures.
+const TxMaxAttempts = 5
+
+// txRetryBackoff is the base of the jittered wait before running a
+// transaction again, growing with each attempt.
+const txRetryBackoff = 10 * time.Millisecond
+
+// Conn is what the repositories run their statements on, the connection pool
+// or a transaction.
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// WithinTx runs fn within a transaction begun on conn with opts, committing it
+// when fn succeeds and rolling it back otherwise. Transactions failing on a
+// serialization failure or a deadlock are run again, up to TxMaxAttempts
+// times, fn having to be safe to run more than once.
+//
+// When conn is already a transaction fn joins it, opts being ignored and the
+// retries left to the transaction it joins.
+func WithinTx(ctx context.Context, conn Conn, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	if tx, ok := conn.(*sql.Tx); ok {
+		return fn(tx)
+	}
+	db, ok := conn.(*sql.DB)
+	if !ok {
+		return errors.New("transactions must be begun on a *sql.DB or joined on a *sql.Tx")
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = runTx(ctx, db, opts, fn)
+		if !IsSerializationFailure(err) || attempt == TxMaxAttempts {
+			return err
+		}
+
+		backoff := time.Duration(attempt) * txRetryBackoff
+		select {
+		case <-time.After(backoff/2 + rand.N(backoff/2+1)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+func runTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	ctx, cancel := OperationContext(ctx)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// IsSerializationFailure tells whether err is caused by a transaction aborted
+// for conflicting with a concurrent one, which may succeed when run again.
+func IsSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	// serialization_failure, deadlock_detected
+	return pqErr.Code == "40001" || pqErr.Code == "40P01"
+}
+
+// IsUniqueViolation tells whether err is caused by a row conflicting with an
+// existing one on a unique constraint.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	// unique_violation
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithinTx(t *testing.T) {
+	const update = `UPDATE devices SET state = 'inactive' WHERE id = \$1`
+	errSerializationFailure := &pq.Error{Code: "40001"}
+
+	testCases := []struct {
+		name      string
+		sqlMock   func(mock sqlmock.Sqlmock)
+		wantedErr error
+		wantRuns  int
+	}{
+		{
+			name: "Within Tx Commit Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantRuns: 1,
+		},
+		{
+			name: "Within Tx Rollback On Error Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantedErr: sql.ErrConnDone,
+			wantRuns:  1,
+		},
+		{
+			name: "Within Tx Retry Serialization Failure Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WillReturnError(errSerializationFailure)
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantRuns: 2,
+		},
+		{
+			name: "Within Tx Retry Serialization Failure On Commit Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit().WillReturnError(errSerializationFailure)
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantRuns: 2,
+		},
+		{
+			name: "Within Tx Serialization Failure Attempts Exhausted Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				for range TxMaxAttempts {
+					mock.ExpectBegin()
+					mock.ExpectExec(update).WillReturnError(errSerializationFailure)
+					mock.ExpectRollback()
+				}
+			},
+			wantedErr: errSerializationFailure,
+			wantRuns:  TxMaxAttempts,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tc.sqlMock(mock)
+
+			runs := 0
+			err = WithinTx(context.Background(), db, &sql.TxOptions{Isolation: sql.LevelRepeatableRead}, func(tx *sql.Tx) error {
+				runs++
+				_, err := tx.ExecContext(context.Background(), `UPDATE devices SET state = 'inactive' WHERE id = $1`, 1)
+				return err
+			})
+
+			assert.Equal(t, tc.wantedErr, err)
+			assert.Equal(t, tc.wantRuns, runs)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_WithinTx_Joins_Tx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	err = WithinTx(context.Background(), db, nil, func(outer *sql.Tx) error {
+		return WithinTx(context.Background(), outer, nil, func(inner *sql.Tx) error {
+			assert.Same(t, outer, inner)
+			return nil
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_IsSerializationFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Serialization Failure Case", err: &pq.Error{Code: "40001"}, want: true},
+		{name: "Deadlock Case", err: &pq.Error{Code: "40P01"}, want: true},
+		{name: "Wrapped Serialization Failure Case", err: errors.Join(errors.New("updating device"), &pq.Error{Code: "40001"}), want: true},
+		{name: "Unique Violation Case", err: &pq.Error{Code: "23505"}, want: false},
+		{name: "Other Error Case", err: sql.ErrNoRows, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsSerializationFailure(tt.err))
+		})
+	}
+}
+
+func Test_IsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Unique Violation Case", err: &pq.Error{Code: "23505"}, want: true},
+		{name: "Wrapped Unique Violation Case", err: errors.Join(errors.New("creating device"), &pq.Error{Code: "23505"}), want: true},
+		{name: "Foreign Key Violation Case", err: &pq.Error{Code: "23503"}, want: false},
+		{name: "Other Error Case", err: sql.ErrNoRows, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsUniqueViolation(tt.err))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Brand struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Aliases are alternative names resolved to the brand, stored normalized.
+	Aliases   []string   `json:"aliases"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+var (
+	spaces          = regexp.MustCompile(`\s+`)
+	companySuffixes = regexp.MustCompile(`([ ,]+(inc|incorporated|corp|corporation|co|company|ltd|limited|llc|plc|gmbh|ag|sa)\.?)+$`)
+)
+
+// CleanBrandName trims and collapses the spaces of a brand name, keeping its case.
+func CleanBrandName(name string) string {
+	return spaces.ReplaceAllString(strings.TrimSpace(name), " ")
+}
+
+// NormalizeBrandName is the key brands are unique by and looked up with, it lower
+// cases the name and drops company suffixes, so "APPLE Inc" and "apple " are both
+// "apple". The 005_brands migration mirrors it to backfill existing devices.
+func NormalizeBrandName(name string) string {
+	normalized := strings.ToLower(CleanBrandName(name))
+	return strings.TrimSpace(companySuffixes.ReplaceAllString(normalized, ""))
+}
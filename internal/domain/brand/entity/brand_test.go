@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Normalize_Brand_Name(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "Lower Cases", value: "APPLE", want: "apple"},
+		{name: "Trims And Collapses Spaces", value: "  Sony   Ericsson ", want: "sony ericsson"},
+		{name: "Drops Company Suffix", value: "APPLE Inc", want: "apple"},
+		{name: "Drops Company Suffix With Comma And Dot", value: "Samsung Electronics Co., Ltd.", want: "samsung electronics"},
+		{name: "Keeps Suffix Inside Name", value: "Incipio", want: "incipio"},
+		{name: "Keeps Punctuation", value: "AT&T", want: "at&t"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeBrandName(tt.value))
+		})
+	}
+}
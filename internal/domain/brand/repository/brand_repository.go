@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+)
+
+//go:generate mockgen -source=brand_repository.go -destination=../../mocks/brand_repository_mock.go -package=mocks
+
+type BrandRepository interface {
+	CreateBrand(ctx context.Context, brand *entity.Brand) error
+	GetBrandByID(ctx context.Context, id uuid.UUID) (entity.Brand, error)
+	FindBrandByName(ctx context.Context, normalizedName string) (entity.Brand, error)
+	ListBrands(ctx context.Context) ([]entity.Brand, error)
+	ListBrandsByIDs(ctx context.Context, ids []uuid.UUID) ([]entity.Brand, error)
+	UpdateBrand(ctx context.Context, brand *entity.Brand) error
+	DeleteBrand(ctx context.Context, id uuid.UUID) error
+}
+
+// brandColumns reads a brand with its aliases aggregated, always an array.
+const brandColumns = `b.id, b.name,
+		coalesce(array_agg(a.alias ORDER BY a.alias) FILTER (WHERE a.alias IS NOT NULL), '{}') AS aliases,
+		b.created_at, b.updated_at`
+
+func brandScanFields(brand *entity.Brand) []any {
+	return []any{
+		&brand.ID,
+		&brand.Name,
+		pq.Array(&brand.Aliases),
+		&brand.CreatedAt,
+		&brand.UpdatedAt,
+	}
+}
+
+type postgresBrandRepository struct {
+	db *sql.DB
+}
+
+func NewBrandRepository(db *sql.DB) *postgresBrandRepository {
+	return &postgresBrandRepository{db: db}
+}
+
+// CreateBrand inserts the brand and its aliases in a single transaction.
+func (r *postgresBrandRepository) CreateBrand(ctx context.Context, brand *entity.Brand) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	const query = `
+	INSERT INTO brands (id, name, normalized_name)
+	VALUES ($1, $2, $3)
+	RETURNING created_at, updated_at;`
+
+	return database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query, brand.ID, brand.Name, entity.NormalizeBrandName(brand.Name)).
+			Scan(&brand.CreatedAt, &brand.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		return insertBrandAliases(ctx, tx, brand)
+	})
+}
+
+func (r *postgresBrandRepository) GetBrandByID(ctx context.Context, id uuid.UUID) (entity.Brand, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var brand entity.Brand
+
+	query := `
+	SELECT ` + brandColumns + `
+	FROM brands b
+	LEFT JOIN brand_aliases a ON a.brand_id = b.id
+	WHERE b.id = $1
+	GROUP BY b.id;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return brand, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, id).Scan(brandScanFields(&brand)...)
+	if err != nil {
+		return brand, err
+	}
+
+	return brand, nil
+}
+
+// FindBrandByName returns the brand whose normalized name or one of its aliases
+// is normalizedName.
+func (r *postgresBrandRepository) FindBrandByName(ctx context.Context, normalizedName string) (entity.Brand, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var brand entity.Brand
+
+	query := `
+	SELECT ` + brandColumns + `
+	FROM brands b
+	LEFT JOIN brand_aliases a ON a.brand_id = b.id
+	WHERE b.normalized_name = $1
+		OR b.id = (SELECT brand_id FROM brand_aliases WHERE alias = $1)
+	GROUP BY b.id;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return brand, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, normalizedName).Scan(brandScanFields(&brand)...)
+	if err != nil {
+		return brand, err
+	}
+
+	return brand, nil
+}
+
+func (r *postgresBrandRepository) ListBrands(ctx context.Context) ([]entity.Brand, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	return r.queryBrands(ctx, `
+	SELECT `+brandColumns+`
+	FROM brands b
+	LEFT JOIN brand_aliases a ON a.brand_id = b.id
+	GROUP BY b.id
+	ORDER BY b.name;`)
+}
+
+func (r *postgresBrandRepository) ListBrandsByIDs(ctx context.Context, ids []uuid.UUID) ([]entity.Brand, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	return r.queryBrands(ctx, `
+	SELECT `+brandColumns+`
+	FROM brands b
+	LEFT JOIN brand_aliases a ON a.brand_id = b.id
+	WHERE b.id = ANY($1::uuid[])
+	GROUP BY b.id
+	ORDER BY b.name;`, pq.Array(ids))
+}
+
+func (r *postgresBrandRepository) queryBrands(ctx context.Context, query string, params ...any) ([]entity.Brand, error) {
+	var brands []entity.Brand
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b entity.Brand
+		err = rows.Scan(brandScanFields(&b)...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		brands = append(brands, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return brands, nil
+}
+
+// UpdateBrand renames the brand, replaces its aliases and keeps the brand name
+// copied on its devices in sync, in a single transaction.
+func (r *postgresBrandRepository) UpdateBrand(ctx context.Context, brand *entity.Brand) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	const query = `
+	UPDATE brands SET
+		name = $2,
+		normalized_name = $3,
+		updated_at = now()
+	WHERE id = $1
+	RETURNING created_at, updated_at;`
+
+	return database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query, brand.ID, brand.Name, entity.NormalizeBrandName(brand.Name)).
+			Scan(&brand.CreatedAt, &brand.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, `DELETE FROM brand_aliases WHERE brand_id = $1;`, brand.ID); err != nil {
+			return err
+		}
+
+		if err = insertBrandAliases(ctx, tx, brand); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE devices SET brand = $2 WHERE brand_id = $1 AND brand <> $2;`, brand.ID, brand.Name)
+		return err
+	})
+}
+
+// DeleteBrand fails with a foreign key violation while devices reference the brand.
+func (r *postgresBrandRepository) DeleteBrand(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	query := `DELETE FROM brands WHERE id = $1;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount <= 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func insertBrandAliases(ctx context.Context, tx *sql.Tx, brand *entity.Brand) error {
+	if len(brand.Aliases) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `
+	INSERT INTO brand_aliases (alias, brand_id)
+	SELECT unnest($2::text[]), $1;`, brand.ID, pq.Array(brand.Aliases))
+
+	return err
+}
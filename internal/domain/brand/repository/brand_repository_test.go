@@ -0,0 +1,492 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+)
+
+var brandRowColumns = []string{"id", "name", "aliases", "created_at", "updated_at"}
+
+var hpBrandID = uuid.MustParse("3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10")
+
+func Test_Create_Brand(t *testing.T) {
+	assert := assert.New(t)
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	insertBrandQuery := regexp.QuoteMeta(`
+	INSERT INTO brands (id, name, normalized_name)
+	VALUES ($1, $2, $3)
+	RETURNING created_at, updated_at;`)
+
+	insertAliasesQuery := regexp.QuoteMeta(`
+	INSERT INTO brand_aliases (alias, brand_id)
+	SELECT unnest($2::text[]), $1;`)
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		brand        entity.Brand
+		wantedErr    error
+		wantedResult entity.Brand
+	}{
+		{
+			name: "Create Brand With Aliases Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertBrandQuery).
+					WithArgs(hpBrandID, "Hewlett-Packard Inc.", "hewlett-packard").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, nil))
+				mock.ExpectExec(insertAliasesQuery).
+					WithArgs(hpBrandID, pq.Array([]string{"hp"})).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			brand:        entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard Inc.", Aliases: []string{"hp"}},
+			wantedErr:    nil,
+			wantedResult: entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard Inc.", Aliases: []string{"hp"}, CreatedAt: createdAt},
+		},
+		{
+			name: "Create Brand Without Aliases Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertBrandQuery).
+					WithArgs(hpBrandID, "Hewlett-Packard", "hewlett-packard").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, nil))
+				mock.ExpectCommit()
+			},
+			brand:        entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard"},
+			wantedErr:    nil,
+			wantedResult: entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard", CreatedAt: createdAt},
+		},
+		{
+			name: "Create Brand Rolls Back When Aliases Fail",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertBrandQuery).
+					WithArgs(hpBrandID, "Hewlett-Packard", "hewlett-packard").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, nil))
+				mock.ExpectExec(insertAliasesQuery).
+					WithArgs(hpBrandID, pq.Array([]string{"hp"})).
+					WillReturnError(fmt.Errorf("some database error"))
+				mock.ExpectRollback()
+			},
+			brand:        entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard", Aliases: []string{"hp"}},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard", Aliases: []string{"hp"}, CreatedAt: createdAt},
+		},
+		{
+			name: "Create Brand Fails on Begin",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(fmt.Errorf("some database error"))
+			},
+			brand:        entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard"},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewBrandRepository(db)
+
+			tt.sqlMock(mock)
+
+			brand := tt.brand
+			err = repository.CreateBrand(context.TODO(), &brand)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, brand)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Find_Brand_By_Name(t *testing.T) {
+	assert := assert.New(t)
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	findQuery := regexp.QuoteMeta(`
+	SELECT ` + brandColumns + `
+	FROM brands b
+	LEFT JOIN brand_aliases a ON a.brand_id = b.id
+	WHERE b.normalized_name = $1
+		OR b.id = (SELECT brand_id FROM brand_aliases WHERE alias = $1)
+	GROUP BY b.id;`)
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult entity.Brand
+	}{
+		{
+			name: "Find Brand By Alias Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(findQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs("hp").
+					WillReturnRows(sqlmock.NewRows(brandRowColumns).
+						AddRow(hpBrandID, "Hewlett-Packard", "{hewlett packard,hp}", createdAt, nil))
+			},
+			wantedErr:    nil,
+			wantedResult: entity.Brand{ID: hpBrandID, Name: "Hewlett-Packard", Aliases: []string{"hewlett packard", "hp"}, CreatedAt: createdAt},
+		},
+		{
+			name: "Find Brand Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(findQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs("hp").
+					WillReturnRows(sqlmock.NewRows(brandRowColumns))
+			},
+			wantedErr:    sql.ErrNoRows,
+			wantedResult: entity.Brand{},
+		},
+		{
+			name: "Find Brand Fails on Prepare Statement",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(findQuery).
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: entity.Brand{},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewBrandRepository(db)
+
+			tt.sqlMock(mock)
+
+			brand, err := repository.FindBrandByName(context.TODO(), "hp")
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, brand)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_List_Brands(t *testing.T) {
+	assert := assert.New(t)
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+	appleBrandID := uuid.MustParse("6c1f4b8e-2a7d-4e0b-8f3c-9d4a5b6c7e02")
+
+	listQuery := regexp.QuoteMeta(`
+	SELECT ` + brandColumns + `
+	FROM brands b
+	LEFT JOIN brand_aliases a ON a.brand_id = b.id
+	GROUP BY b.id
+	ORDER BY b.name;`)
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult []entity.Brand
+	}{
+		{
+			name: "List Brands Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs().
+					WillReturnRows(sqlmock.NewRows(brandRowColumns).
+						AddRow(appleBrandID, "Apple", "{}", createdAt, nil).
+						AddRow(hpBrandID, "Hewlett-Packard", "{hp}", createdAt, createdAt))
+			},
+			wantedErr: nil,
+			wantedResult: []entity.Brand{
+				{ID: appleBrandID, Name: "Apple", Aliases: []string{}, CreatedAt: createdAt},
+				{ID: hpBrandID, Name: "Hewlett-Packard", Aliases: []string{"hp"}, CreatedAt: createdAt, UpdatedAt: &createdAt},
+			},
+		},
+		{
+			name: "List Brands Fails on Query",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs().
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewBrandRepository(db)
+
+			tt.sqlMock(mock)
+
+			brands, err := repository.ListBrands(context.TODO())
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, brands)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_List_Brands_By_IDs(t *testing.T) {
+	assert := assert.New(t)
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	db, mock, err := sqlmock.New()
+	assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+	repository := NewBrandRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`
+	SELECT ` + brandColumns + `
+	FROM brands b
+	LEFT JOIN brand_aliases a ON a.brand_id = b.id
+	WHERE b.id = ANY($1::uuid[])
+	GROUP BY b.id
+	ORDER BY b.name;`)).
+		WillBeClosed().
+		ExpectQuery().
+		WithArgs(pq.Array([]uuid.UUID{hpBrandID})).
+		WillReturnRows(sqlmock.NewRows(brandRowColumns).
+			AddRow(hpBrandID, "Hewlett-Packard", "{hp}", createdAt, nil))
+
+	brands, err := repository.ListBrandsByIDs(context.TODO(), []uuid.UUID{hpBrandID})
+
+	assert.NoError(err)
+	assert.Equal([]entity.Brand{{ID: hpBrandID, Name: "Hewlett-Packard", Aliases: []string{"hp"}, CreatedAt: createdAt}}, brands)
+
+	mock.ExpectClose()
+
+	err = db.Close()
+	assert.NoErrorf(err, "db was not closed")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(err, "there were unfulfilled expectations")
+}
+
+func Test_Update_Brand(t *testing.T) {
+	assert := assert.New(t)
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+	updatedAt := lo.Must(time.Parse(time.DateTime, "2025-09-01 10:00:00"))
+
+	updateBrandQuery := regexp.QuoteMeta(`
+	UPDATE brands SET
+		name = $2,
+		normalized_name = $3,
+		updated_at = now()
+	WHERE id = $1
+	RETURNING created_at, updated_at;`)
+
+	deleteAliasesQuery := regexp.QuoteMeta(`DELETE FROM brand_aliases WHERE brand_id = $1;`)
+
+	insertAliasesQuery := regexp.QuoteMeta(`
+	INSERT INTO brand_aliases (alias, brand_id)
+	SELECT unnest($2::text[]), $1;`)
+
+	renameDevicesQuery := regexp.QuoteMeta(`UPDATE devices SET brand = $2 WHERE brand_id = $1 AND brand <> $2;`)
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult entity.Brand
+	}{
+		{
+			name: "Update Brand Renames Devices Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateBrandQuery).
+					WithArgs(hpBrandID, "HP", "hp").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, updatedAt))
+				mock.ExpectExec(deleteAliasesQuery).
+					WithArgs(hpBrandID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertAliasesQuery).
+					WithArgs(hpBrandID, pq.Array([]string{"hewlett-packard"})).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(renameDevicesQuery).
+					WithArgs(hpBrandID, "HP").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			wantedErr:    nil,
+			wantedResult: entity.Brand{ID: hpBrandID, Name: "HP", Aliases: []string{"hewlett-packard"}, CreatedAt: createdAt, UpdatedAt: &updatedAt},
+		},
+		{
+			name: "Update Brand Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateBrandQuery).
+					WithArgs(hpBrandID, "HP", "hp").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}))
+				mock.ExpectRollback()
+			},
+			wantedErr:    sql.ErrNoRows,
+			wantedResult: entity.Brand{ID: hpBrandID, Name: "HP", Aliases: []string{"hewlett-packard"}},
+		},
+		{
+			name: "Update Brand Rolls Back When Renaming Devices Fails",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateBrandQuery).
+					WithArgs(hpBrandID, "HP", "hp").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, updatedAt))
+				mock.ExpectExec(deleteAliasesQuery).
+					WithArgs(hpBrandID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertAliasesQuery).
+					WithArgs(hpBrandID, pq.Array([]string{"hewlett-packard"})).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(renameDevicesQuery).
+					WithArgs(hpBrandID, "HP").
+					WillReturnError(fmt.Errorf("some database error"))
+				mock.ExpectRollback()
+			},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: entity.Brand{ID: hpBrandID, Name: "HP", Aliases: []string{"hewlett-packard"}, CreatedAt: createdAt, UpdatedAt: &updatedAt},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewBrandRepository(db)
+
+			tt.sqlMock(mock)
+
+			brand := entity.Brand{ID: hpBrandID, Name: "HP", Aliases: []string{"hewlett-packard"}}
+			err = repository.UpdateBrand(context.TODO(), &brand)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, brand)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Delete_Brand(t *testing.T) {
+	assert := assert.New(t)
+
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM brands WHERE id = $1;`)
+
+	errBrandInUse := &pq.Error{Code: "23503", Constraint: "devices_brand_id_fkey"}
+
+	testCases := []struct {
+		name      string
+		sqlMock   func(mock sqlmock.Sqlmock)
+		wantedErr error
+	}{
+		{
+			name: "Delete Brand Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deleteQuery).
+					WillBeClosed().
+					ExpectExec().
+					WithArgs(hpBrandID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantedErr: nil,
+		},
+		{
+			name: "Delete Brand Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deleteQuery).
+					WillBeClosed().
+					ExpectExec().
+					WithArgs(hpBrandID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+		{
+			name: "Delete Brand Used by Devices Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deleteQuery).
+					WillBeClosed().
+					ExpectExec().
+					WithArgs(hpBrandID).
+					WillReturnError(errBrandInUse)
+			},
+			wantedErr: errBrandInUse,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewBrandRepository(db)
+
+			tt.sqlMock(mock)
+
+			err = repository.DeleteBrand(context.TODO(), hpBrandID)
+
+			assert.Equal(tt.wantedErr, err)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
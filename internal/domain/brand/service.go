@@ -0,0 +1,230 @@
+package brand
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+)
+
+const maxBrandNameLength = 100
+
+type BrandService interface {
+	List(ctx context.Context) ([]entity.Brand, error)
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Brand, error)
+	// ListByIDs returns the brands among ids, ids of unknown brands are ignored.
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]entity.Brand, error)
+	Create(ctx context.Context, brand entity.Brand) (entity.Brand, error)
+	Update(ctx context.Context, brand entity.Brand) (entity.Brand, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Find returns the brand matching name or one of its aliases once normalized.
+	Find(ctx context.Context, name string) (entity.Brand, error)
+	// Resolve finds the brand like Find, registering it when it does not exist yet.
+	Resolve(ctx context.Context, name string) (entity.Brand, error)
+}
+
+type brandService struct {
+	repo repository.BrandRepository
+}
+
+func NewBrandService(repo repository.BrandRepository) *brandService {
+	return &brandService{repo: repo}
+}
+
+func (s *brandService) List(ctx context.Context) ([]entity.Brand, error) {
+	brands, err := s.repo.ListBrands(ctx)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing brands", err)
+	}
+	return brands, nil
+}
+
+func (s *brandService) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]entity.Brand, error) {
+	brands, err := s.repo.ListBrandsByIDs(ctx, ids)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing brands", err)
+	}
+	return brands, nil
+}
+
+func (s *brandService) GetByID(ctx context.Context, id uuid.UUID) (entity.Brand, error) {
+	brand, err := s.repo.GetBrandByID(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Brand{}, errors.NewDeviceError(errors.ErrNotFound, "brand not found", err)
+		}
+		return entity.Brand{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving brand", err)
+	}
+	return brand, nil
+}
+
+func (s *brandService) Create(ctx context.Context, brand entity.Brand) (entity.Brand, error) {
+	brand.ID = uuid.New()
+	if err := s.normalizeAndValidate(ctx, &brand); err != nil {
+		return brand, err
+	}
+
+	err := s.repo.CreateBrand(ctx, &brand)
+	if err != nil {
+		if conflictErr := brandConflictError(err); conflictErr != nil {
+			return brand, conflictErr
+		}
+		return brand, errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating brand", err)
+	}
+	return brand, nil
+}
+
+func (s *brandService) Update(ctx context.Context, brand entity.Brand) (entity.Brand, error) {
+	if _, err := s.GetByID(ctx, brand.ID); err != nil {
+		return entity.Brand{}, err
+	}
+
+	if err := s.normalizeAndValidate(ctx, &brand); err != nil {
+		return entity.Brand{}, err
+	}
+
+	err := s.repo.UpdateBrand(ctx, &brand)
+	if err != nil {
+		if conflictErr := brandConflictError(err); conflictErr != nil {
+			return entity.Brand{}, conflictErr
+		}
+		return entity.Brand{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while updating brand", err)
+	}
+	return brand, nil
+}
+
+func (s *brandService) Delete(ctx context.Context, id uuid.UUID) error {
+	err := s.repo.DeleteBrand(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.NewDeviceError(errors.ErrNotFound, "brand not found", err)
+		}
+		if conflictErr := brandConflictError(err); conflictErr != nil {
+			return conflictErr
+		}
+		return errors.NewDeviceError(errors.ErrInternal, "something went wrong while deleting brand", err)
+	}
+	return nil
+}
+
+func (s *brandService) Find(ctx context.Context, name string) (entity.Brand, error) {
+	normalized := entity.NormalizeBrandName(name)
+	if normalized == "" {
+		return entity.Brand{}, errors.NewDeviceError(errors.ErrInvalid, "brand must not be empty", nil)
+	}
+
+	brand, err := s.repo.FindBrandByName(ctx, normalized)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Brand{}, errors.NewDeviceError(errors.ErrNotFound, "brand not found", err)
+		}
+		return entity.Brand{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving brand", err)
+	}
+	return brand, nil
+}
+
+func (s *brandService) Resolve(ctx context.Context, name string) (entity.Brand, error) {
+	brand, err := s.Find(ctx, name)
+	if !isNotFound(err) {
+		return brand, err
+	}
+
+	brand, err = s.Create(ctx, entity.Brand{Name: name})
+	if err != nil {
+		var brandErr *errors.DeviceError
+		// another request registered the same brand in the meantime
+		if goerrors.As(err, &brandErr) && brandErr.Type == errors.ErrConflict {
+			return s.Find(ctx, name)
+		}
+		return entity.Brand{}, err
+	}
+	return brand, nil
+}
+
+// normalizeAndValidate cleans the name and normalizes the aliases, neither may
+// resolve to another brand.
+func (s *brandService) normalizeAndValidate(ctx context.Context, brand *entity.Brand) error {
+	brand.Name = entity.CleanBrandName(brand.Name)
+	normalized := entity.NormalizeBrandName(brand.Name)
+
+	if normalized == "" {
+		return errors.NewDeviceError(errors.ErrInvalid, "brand name must not be empty", nil)
+	}
+	if len(brand.Name) > maxBrandNameLength {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("brand name must have at most %d characters", maxBrandNameLength), nil)
+	}
+
+	if err := s.checkNameIsFree(ctx, brand.ID, normalized, "a brand with this name already exists"); err != nil {
+		return err
+	}
+
+	aliases := make([]string, 0, len(brand.Aliases))
+	for _, alias := range brand.Aliases {
+		alias = entity.NormalizeBrandName(alias)
+		if alias == "" || alias == normalized || slices.Contains(aliases, alias) {
+			continue
+		}
+
+		if err := s.checkNameIsFree(ctx, brand.ID, alias, fmt.Sprintf("alias %q already belongs to another brand", alias)); err != nil {
+			return err
+		}
+		aliases = append(aliases, alias)
+	}
+	slices.Sort(aliases)
+	brand.Aliases = aliases
+
+	return nil
+}
+
+// checkNameIsFree fails when normalizedName resolves to a brand other than brandID.
+func (s *brandService) checkNameIsFree(ctx context.Context, brandID uuid.UUID, normalizedName string, conflictMessage string) error {
+	existing, err := s.repo.FindBrandByName(ctx, normalizedName)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving brand", err)
+	}
+
+	if existing.ID != brandID {
+		return errors.NewDeviceError(errors.ErrConflict, conflictMessage, nil)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var brandErr *errors.DeviceError
+	return goerrors.As(err, &brandErr) && brandErr.Type == errors.ErrNotFound
+}
+
+// brandConflictError maps the unique violations (SQLSTATE 23505) of concurrent
+// requests and the foreign key violation (SQLSTATE 23503) of a brand still used
+// by devices or models to conflict errors, or returns nil for any other error.
+func brandConflictError(err error) *errors.DeviceError {
+	var pqErr *pq.Error
+	if !goerrors.As(err, &pqErr) {
+		return nil
+	}
+
+	switch pqErr.Code {
+	case "23505":
+		if pqErr.Constraint == "brand_aliases_pkey" {
+			return errors.NewDeviceError(errors.ErrConflict, "alias already belongs to another brand", err)
+		}
+		return errors.NewDeviceError(errors.ErrConflict, "a brand with this name already exists", err)
+	case "23503":
+		if pqErr.Constraint == "models_brand_id_fkey" {
+			return errors.NewDeviceError(errors.ErrConflict, "brand has models and cannot be deleted", err)
+		}
+		return errors.NewDeviceError(errors.ErrConflict, "brand is used by devices and cannot be deleted", err)
+	default:
+		return nil
+	}
+}
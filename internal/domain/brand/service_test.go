@@ -0,0 +1,302 @@
+package brand
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+)
+
+var errDatabaseGeneric = fmt.Errorf("some database error")
+
+var (
+	hpBrand    = entity.Brand{ID: uuid.MustParse("3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"), Name: "Hewlett-Packard", Aliases: []string{"hp"}}
+	appleBrand = entity.Brand{ID: uuid.MustParse("6c1f4b8e-2a7d-4e0b-8f3c-9d4a5b6c7e02"), Name: "Apple"}
+)
+
+func Test_Create_Brand(t *testing.T) {
+	errNameUniqueViolation := &pq.Error{Code: "23505", Constraint: "uq_brands_normalized_name"}
+
+	tests := []struct {
+		name                string
+		brand               entity.Brand
+		existing            map[string]entity.Brand
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantName            string
+		wantAliases         []string
+		wantErr             error
+	}{
+		{
+			name:                "Create Brand Normalizes Aliases Case",
+			brand:               entity.Brand{Name: "  Hewlett-Packard   Inc. ", Aliases: []string{"HP", "hp inc", "Hewlett-Packard", " "}},
+			wantRepositoryCalls: 1,
+			wantName:            "Hewlett-Packard Inc.",
+			wantAliases:         []string{"hp"},
+		},
+		{
+			name:    "Create Brand Empty Name Case",
+			brand:   entity.Brand{Name: "   "},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "brand name must not be empty", nil),
+		},
+		{
+			name:     "Create Brand Name Already Exists Case",
+			brand:    entity.Brand{Name: "apple, inc"},
+			existing: map[string]entity.Brand{"apple": appleBrand},
+			wantErr:  errors.NewDeviceError(errors.ErrConflict, "a brand with this name already exists", nil),
+		},
+		{
+			name:     "Create Brand Alias of Another Brand Case",
+			brand:    entity.Brand{Name: "HP Enterprise", Aliases: []string{"HP"}},
+			existing: map[string]entity.Brand{"hp": hpBrand},
+			wantErr:  errors.NewDeviceError(errors.ErrConflict, `alias "hp" already belongs to another brand`, nil),
+		},
+		{
+			name:                "Create Brand Concurrent Conflict Case",
+			brand:               entity.Brand{Name: "Apple"},
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errNameUniqueViolation,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "a brand with this name already exists", errNameUniqueViolation),
+		},
+		{
+			name:                "Create Brand Repository Error Case",
+			brand:               entity.Brand{Name: "Apple"},
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating brand", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockBrandRepository(mockCtrl)
+			service := NewBrandService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				FindBrandByName(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, name string) (entity.Brand, error) {
+					if brand, ok := tt.existing[name]; ok {
+						return brand, nil
+					}
+					return entity.Brand{}, sql.ErrNoRows
+				}).
+				AnyTimes()
+
+			mockRepo.
+				EXPECT().
+				CreateBrand(context.TODO(), gomock.Any()).
+				Return(tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			brand, err := service.Create(context.TODO(), tt.brand)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEqual(t, uuid.Nil, brand.ID)
+				assert.Equal(t, tt.wantName, brand.Name)
+				assert.Equal(t, tt.wantAliases, brand.Aliases)
+			}
+		})
+	}
+}
+
+func Test_Update_Brand(t *testing.T) {
+	tests := []struct {
+		name                string
+		brand               entity.Brand
+		wantGetErr          error
+		wantRepositoryCalls int
+		wantErr             error
+	}{
+		{
+			name:                "Update Brand Keeping Its Own Alias Case",
+			brand:               entity.Brand{ID: hpBrand.ID, Name: "HP Inc.", Aliases: []string{"Hewlett-Packard"}},
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:       "Update Brand Not Found Case",
+			brand:      entity.Brand{ID: hpBrand.ID, Name: "HP"},
+			wantGetErr: sql.ErrNoRows,
+			wantErr:    errors.NewDeviceError(errors.ErrNotFound, "brand not found", sql.ErrNoRows),
+		},
+		{
+			name:    "Update Brand To Another Brand Name Case",
+			brand:   entity.Brand{ID: hpBrand.ID, Name: "Apple"},
+			wantErr: errors.NewDeviceError(errors.ErrConflict, "a brand with this name already exists", nil),
+		},
+	}
+
+	existing := map[string]entity.Brand{"hp": hpBrand, "hewlett-packard": hpBrand, "apple": appleBrand}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockBrandRepository(mockCtrl)
+			service := NewBrandService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				GetBrandByID(context.TODO(), tt.brand.ID).
+				Return(hpBrand, tt.wantGetErr)
+
+			mockRepo.
+				EXPECT().
+				FindBrandByName(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, name string) (entity.Brand, error) {
+					if brand, ok := existing[name]; ok {
+						return brand, nil
+					}
+					return entity.Brand{}, sql.ErrNoRows
+				}).
+				AnyTimes()
+
+			mockRepo.
+				EXPECT().
+				UpdateBrand(context.TODO(), gomock.Any()).
+				Return(nil).
+				Times(tt.wantRepositoryCalls)
+
+			_, err := service.Update(context.TODO(), tt.brand)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_Delete_Brand(t *testing.T) {
+	errBrandInUse := &pq.Error{Code: "23503", Constraint: "devices_brand_id_fkey"}
+
+	tests := []struct {
+		name              string
+		wantRepositoryErr error
+		wantErr           error
+	}{
+		{
+			name: "Delete Brand Success Case",
+		},
+		{
+			name:              "Delete Brand Not Found Case",
+			wantRepositoryErr: sql.ErrNoRows,
+			wantErr:           errors.NewDeviceError(errors.ErrNotFound, "brand not found", sql.ErrNoRows),
+		},
+		{
+			name:              "Delete Brand Used by Devices Case",
+			wantRepositoryErr: errBrandInUse,
+			wantErr:           errors.NewDeviceError(errors.ErrConflict, "brand is used by devices and cannot be deleted", errBrandInUse),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockBrandRepository(mockCtrl)
+			service := NewBrandService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				DeleteBrand(context.TODO(), hpBrand.ID).
+				Return(tt.wantRepositoryErr)
+
+			err := service.Delete(context.TODO(), hpBrand.ID)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_Resolve_Brand(t *testing.T) {
+	errNameUniqueViolation := &pq.Error{Code: "23505", Constraint: "uq_brands_normalized_name"}
+
+	tests := []struct {
+		name            string
+		brand           string
+		findResults     []error
+		wantCreateCalls int
+		wantCreateErr   error
+		wantName        string
+		wantErr         error
+	}{
+		{
+			name:        "Resolve Existing Brand by Alias Case",
+			brand:       "HP Inc",
+			findResults: []error{nil},
+			wantName:    "Hewlett-Packard",
+		},
+		{
+			name:            "Resolve Registers Unknown Brand Case",
+			brand:           " Fairphone  B.V.",
+			findResults:     []error{sql.ErrNoRows, sql.ErrNoRows},
+			wantCreateCalls: 1,
+			wantName:        "Fairphone B.V.",
+		},
+		{
+			name:            "Resolve Brand Registered Concurrently Case",
+			brand:           "Hewlett-Packard",
+			findResults:     []error{sql.ErrNoRows, sql.ErrNoRows, nil},
+			wantCreateCalls: 1,
+			wantCreateErr:   errNameUniqueViolation,
+			wantName:        "Hewlett-Packard",
+		},
+		{
+			name:        "Resolve Empty Brand Case",
+			brand:       "  ",
+			findResults: []error{},
+			wantErr:     errors.NewDeviceError(errors.ErrInvalid, "brand must not be empty", nil),
+		},
+		{
+			name:        "Resolve Repository Error Case",
+			brand:       "HP",
+			findResults: []error{errDatabaseGeneric},
+			wantErr:     errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving brand", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockBrandRepository(mockCtrl)
+			service := NewBrandService(mockRepo)
+
+			// every lookup answers with the next result, a nil result finds hpBrand
+			calls := []*gomock.Call{}
+			for _, findErr := range tt.findResults {
+				found := hpBrand
+				if findErr != nil {
+					found = entity.Brand{}
+				}
+				calls = append(calls, mockRepo.
+					EXPECT().
+					FindBrandByName(context.TODO(), gomock.Any()).
+					Return(found, findErr))
+			}
+			gomock.InOrder(calls...)
+
+			mockRepo.
+				EXPECT().
+				CreateBrand(context.TODO(), gomock.Any()).
+				Return(tt.wantCreateErr).
+				Times(tt.wantCreateCalls)
+
+			brand, err := service.Resolve(context.TODO(), tt.brand)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantName, brand.Name)
+			}
+		})
+	}
+}
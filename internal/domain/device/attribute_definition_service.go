@@ -0,0 +1,121 @@
+package device
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/tenant"
+)
+
+// AttributeDefinitionService manages the attribute schema of the tenant in ctx.
+// Changing a definition only applies to devices created or updated afterwards.
+type AttributeDefinitionService interface {
+	List(ctx context.Context) ([]entity.AttributeDefinition, error)
+	Put(ctx context.Context, definition entity.AttributeDefinition) (entity.AttributeDefinition, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type attributeDefinitionService struct {
+	repo repository.AttributeDefinitionRepository
+}
+
+func NewAttributeDefinitionService(repo repository.AttributeDefinitionRepository) *attributeDefinitionService {
+	return &attributeDefinitionService{repo: repo}
+}
+
+func (s *attributeDefinitionService) List(ctx context.Context) ([]entity.AttributeDefinition, error) {
+	tenantID, err := requiredTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := s.repo.ListAttributeDefinitions(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing attribute definitions", err)
+	}
+	return definitions, nil
+}
+
+func (s *attributeDefinitionService) Put(ctx context.Context, definition entity.AttributeDefinition) (entity.AttributeDefinition, error) {
+	tenantID, err := requiredTenant(ctx)
+	if err != nil {
+		return definition, err
+	}
+	definition.TenantID = tenantID
+
+	if err := validateAttributeDefinition(definition); err != nil {
+		return definition, err
+	}
+
+	err = s.repo.UpsertAttributeDefinition(ctx, &definition)
+	if err != nil {
+		return definition, errors.NewDeviceError(errors.ErrInternal, "something went wrong while saving attribute definition", err)
+	}
+	return definition, nil
+}
+
+func (s *attributeDefinitionService) Delete(ctx context.Context, name string) error {
+	tenantID, err := requiredTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteAttributeDefinition(ctx, tenantID, name)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.NewDeviceError(errors.ErrNotFound, "attribute definition not found", err)
+		}
+		return errors.NewDeviceError(errors.ErrInternal, "something went wrong while deleting attribute definition", err)
+	}
+	return nil
+}
+
+func requiredTenant(ctx context.Context) (string, error) {
+	tenantID, ok := tenant.IDFromContext(ctx)
+	if !ok {
+		return "", errors.NewDeviceError(errors.ErrInvalid, "a tenant must be informed to manage attribute definitions", nil)
+	}
+	return tenantID, nil
+}
+
+func validateAttributeDefinition(definition entity.AttributeDefinition) error {
+	if !entity.ValidAttributeName.MatchString(definition.Name) {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attribute name %q, %s", definition.Name, invalidAttributeNameHint), nil)
+	}
+
+	if !slices.Contains(entity.AttributeTypes, definition.Type) {
+		types := make([]string, 0, len(entity.AttributeTypes))
+		for _, t := range entity.AttributeTypes {
+			types = append(types, t.String())
+		}
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attribute type %q, must be one of: %s", definition.Type, strings.Join(types, ", ")), nil)
+	}
+
+	if len(definition.Enum) == 0 {
+		return nil
+	}
+
+	if definition.Type != entity.AttributeString {
+		return errors.NewDeviceError(errors.ErrInvalid, "enum is only supported by string attributes", nil)
+	}
+
+	seen := map[string]bool{}
+	for _, value := range definition.Enum {
+		if strings.TrimSpace(value) == "" {
+			return errors.NewDeviceError(errors.ErrInvalid, "enum values must not be empty", nil)
+		}
+		if seen[value] {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("enum value %q is informed more than once", value), nil)
+		}
+		seen[value] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package device
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+	"github.com/tiagos4ntos/device-manager/internal/domain/tenant"
+)
+
+func Test_Put_Attribute_Definition(t *testing.T) {
+	tenantContext := tenant.WithID(context.TODO(), "acme")
+
+	tests := []struct {
+		name                string
+		context             context.Context
+		definition          entity.AttributeDefinition
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantErr             error
+	}{
+		{
+			name:                "Put Attribute Definition Success Case",
+			context:             tenantContext,
+			definition:          entity.AttributeDefinition{Name: "carrier", Type: entity.AttributeString, Required: true, Enum: []string{"vodafone", "orange"}},
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:       "Put Attribute Definition Without Tenant Case",
+			context:    context.TODO(),
+			definition: entity.AttributeDefinition{Name: "carrier", Type: entity.AttributeString},
+			wantErr:    errors.NewDeviceError(errors.ErrInvalid, "a tenant must be informed to manage attribute definitions", nil),
+		},
+		{
+			name:       "Put Attribute Definition Invalid Name Case",
+			context:    tenantContext,
+			definition: entity.AttributeDefinition{Name: "Cost Center", Type: entity.AttributeString},
+			wantErr:    errors.NewDeviceError(errors.ErrInvalid, `invalid attribute name "Cost Center", `+invalidAttributeNameHint, nil),
+		},
+		{
+			name:       "Put Attribute Definition Invalid Type Case",
+			context:    tenantContext,
+			definition: entity.AttributeDefinition{Name: "cost", Type: entity.AttributeType("money")},
+			wantErr:    errors.NewDeviceError(errors.ErrInvalid, `invalid attribute type "money", must be one of: string, number, boolean`, nil),
+		},
+		{
+			name:       "Put Attribute Definition Enum On Number Case",
+			context:    tenantContext,
+			definition: entity.AttributeDefinition{Name: "cost", Type: entity.AttributeNumber, Enum: []string{"1"}},
+			wantErr:    errors.NewDeviceError(errors.ErrInvalid, "enum is only supported by string attributes", nil),
+		},
+		{
+			name:       "Put Attribute Definition Repeated Enum Value Case",
+			context:    tenantContext,
+			definition: entity.AttributeDefinition{Name: "carrier", Type: entity.AttributeString, Enum: []string{"o2", "o2"}},
+			wantErr:    errors.NewDeviceError(errors.ErrInvalid, `enum value "o2" is informed more than once`, nil),
+		},
+		{
+			name:                "Put Attribute Definition Repository Error Case",
+			context:             tenantContext,
+			definition:          entity.AttributeDefinition{Name: "carrier", Type: entity.AttributeString},
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while saving attribute definition", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockAttributeDefinitionRepository(mockCtrl)
+			service := NewAttributeDefinitionService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				UpsertAttributeDefinition(tt.context, gomock.Any()).
+				Return(tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			definition, err := service.Put(tt.context, tt.definition)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantRepositoryCalls > 0 {
+				assert.Equal(t, "acme", definition.TenantID)
+			}
+		})
+	}
+}
+
+func Test_Delete_Attribute_Definition(t *testing.T) {
+	tenantContext := tenant.WithID(context.TODO(), "acme")
+
+	tests := []struct {
+		name              string
+		wantRepositoryErr error
+		wantErr           error
+	}{
+		{
+			name: "Delete Attribute Definition Success Case",
+		},
+		{
+			name:              "Delete Attribute Definition Not Found Case",
+			wantRepositoryErr: sql.ErrNoRows,
+			wantErr:           errors.NewDeviceError(errors.ErrNotFound, "attribute definition not found", sql.ErrNoRows),
+		},
+		{
+			name:              "Delete Attribute Definition Repository Error Case",
+			wantRepositoryErr: errDatabaseGeneric,
+			wantErr:           errors.NewDeviceError(errors.ErrInternal, "something went wrong while deleting attribute definition", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockAttributeDefinitionRepository(mockCtrl)
+			service := NewAttributeDefinitionService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				DeleteAttributeDefinition(tenantContext, "acme", "carrier").
+				Return(tt.wantRepositoryErr)
+
+			err := service.Delete(tenantContext, "carrier")
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package device
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+)
+
+const (
+	invalidAttributeNameHint = "must start with a lower case letter and contain only lower case letters, digits and _, up to 64 characters"
+	invalidTagHint           = "must start with a lower case letter or digit and contain only lower case letters, digits and _ . : -, up to 50 characters"
+)
+
+// normalizeTags lower cases, deduplicates and sorts the tags, so the same set of
+// tags is always stored the same way.
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !entity.ValidTag.MatchString(tag) {
+			return nil, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid tag %q, %s", tag, invalidTagHint), nil)
+		}
+		normalized = append(normalized, tag)
+	}
+
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
+
+// validateAttributes checks every attribute is a flat string, number or boolean
+// value, then checks them against the tenant definitions, if any. Attributes
+// without a definition are accepted as they are.
+func validateAttributes(attributes entity.Attributes, definitions []entity.AttributeDefinition) error {
+	for _, name := range sortedAttributeNames(attributes) {
+		if !entity.ValidAttributeName.MatchString(name) {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attribute name %q, %s", name, invalidAttributeNameHint), nil)
+		}
+
+		switch attributes[name].(type) {
+		case string, float64, bool:
+		default:
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attribute %s, values must be a string, a number or a boolean", name), nil)
+		}
+	}
+
+	for _, definition := range definitions {
+		value, ok := attributes[definition.Name]
+		if !ok {
+			if definition.Required {
+				return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("attribute %s is required", definition.Name), nil)
+			}
+			continue
+		}
+
+		if !attributeHasType(value, definition.Type) {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attribute %s, must be a %s", definition.Name, definition.Type), nil)
+		}
+
+		if len(definition.Enum) > 0 && !slices.Contains(definition.Enum, value.(string)) {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attribute %s value %q, must be one of: %s", definition.Name, value, strings.Join(definition.Enum, ", ")), nil)
+		}
+	}
+
+	return nil
+}
+
+// typedAttributeFilter converts the attribute filter values, informed as text,
+// to the type declared by the tenant definitions so they match the stored JSON.
+// Attributes without a definition are matched as strings.
+func typedAttributeFilter(filter entity.Attributes, definitions []entity.AttributeDefinition) (entity.Attributes, error) {
+	typed := make(entity.Attributes, len(filter))
+
+	for _, name := range sortedAttributeNames(filter) {
+		if !entity.ValidAttributeName.MatchString(name) {
+			return nil, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attribute name %q, %s", name, invalidAttributeNameHint), nil)
+		}
+
+		value := fmt.Sprint(filter[name])
+		typed[name] = value
+
+		index := slices.IndexFunc(definitions, func(d entity.AttributeDefinition) bool { return d.Name == name })
+		if index < 0 {
+			continue
+		}
+
+		switch definitions[index].Type {
+		case entity.AttributeNumber:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attr.%s value %q, must be a number", name, value), nil)
+			}
+			typed[name] = number
+		case entity.AttributeBoolean:
+			boolean, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid attr.%s value %q, must be true or false", name, value), nil)
+			}
+			typed[name] = boolean
+		}
+	}
+
+	return typed, nil
+}
+
+func attributeHasType(value any, attributeType entity.AttributeType) bool {
+	switch value.(type) {
+	case string:
+		return attributeType == entity.AttributeString
+	case float64:
+		return attributeType == entity.AttributeNumber
+	case bool:
+		return attributeType == entity.AttributeBoolean
+	default:
+		return false
+	}
+}
+
+// sortedAttributeNames makes validation report the same error for the same input.
+func sortedAttributeNames(attributes entity.Attributes) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
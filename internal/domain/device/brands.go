@@ -0,0 +1,15 @@
+package device
+
+import (
+	"context"
+
+	brandentity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+)
+
+//go:generate mockgen -source=brands.go -destination=../mocks/brand_resolver_mock.go -package=mocks
+
+// BrandResolver maps the brand informed on a device to the brand catalogue.
+type BrandResolver interface {
+	Find(ctx context.Context, name string) (brandentity.Brand, error)
+	Resolve(ctx context.Context, name string) (brandentity.Brand, error)
+}
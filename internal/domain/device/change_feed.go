@@ -0,0 +1,89 @@
+package device
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+// changeFeedBuffer is how many changes a subscriber may lag behind before it
+// is dropped.
+const changeFeedBuffer = 64
+
+// ChangeWatcher streams the changes made to devices.
+type ChangeWatcher interface {
+	// Subscribe returns the changes made from now on, until ctx is done. The
+	// channel is also closed when the subscriber falls behind or the feed is
+	// closed, the subscriber is expected to list the devices again before
+	// subscribing anew.
+	Subscribe(ctx context.Context) <-chan entity.DeviceChange
+}
+
+type changeFeed struct {
+	mu          sync.Mutex
+	closed      bool
+	subscribers map[chan entity.DeviceChange]struct{}
+}
+
+// NewChangeFeed fans the published device changes out to its subscribers.
+func NewChangeFeed() *changeFeed {
+	return &changeFeed{subscribers: map[chan entity.DeviceChange]struct{}{}}
+}
+
+func (f *changeFeed) Subscribe(ctx context.Context) <-chan entity.DeviceChange {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	changes := make(chan entity.DeviceChange, changeFeedBuffer)
+	if f.closed {
+		close(changes)
+		return changes
+	}
+
+	f.subscribers[changes] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		f.unsubscribe(changes)
+	}()
+
+	return changes
+}
+
+// Publish hands the change to every subscriber without waiting, subscribers
+// whose buffer is full are dropped.
+func (f *changeFeed) Publish(change entity.DeviceChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for changes := range f.subscribers {
+		select {
+		case changes <- change:
+		default:
+			delete(f.subscribers, changes)
+			close(changes)
+		}
+	}
+}
+
+// Close drops every subscriber, later subscriptions end right away.
+func (f *changeFeed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	for changes := range f.subscribers {
+		delete(f.subscribers, changes)
+		close(changes)
+	}
+}
+
+func (f *changeFeed) unsubscribe(changes chan entity.DeviceChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.subscribers[changes]; ok {
+		delete(f.subscribers, changes)
+		close(changes)
+	}
+}
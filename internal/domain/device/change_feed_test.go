@@ -0,0 +1,69 @@
+package device
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+func Test_Change_Feed(t *testing.T) {
+	change := entity.DeviceChange{
+		DeviceID:  uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
+		Type:      entity.ChangeUpdated,
+		ChangedAt: time.Now(),
+	}
+
+	t.Run("Publish To Every Subscriber Case", func(t *testing.T) {
+		feed := NewChangeFeed()
+		first := feed.Subscribe(context.Background())
+		second := feed.Subscribe(context.Background())
+
+		feed.Publish(change)
+
+		assert.Equal(t, change, <-first)
+		assert.Equal(t, change, <-second)
+	})
+
+	t.Run("Unsubscribe When Context Is Done Case", func(t *testing.T) {
+		feed := NewChangeFeed()
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := feed.Subscribe(ctx)
+
+		cancel()
+
+		_, ok := <-changes
+		assert.False(t, ok)
+	})
+
+	t.Run("Drop Subscriber Falling Behind Case", func(t *testing.T) {
+		feed := NewChangeFeed()
+		changes := feed.Subscribe(context.Background())
+
+		for range changeFeedBuffer + 1 {
+			feed.Publish(change)
+		}
+
+		received := 0
+		for range changes {
+			received++
+		}
+		assert.Equal(t, changeFeedBuffer, received)
+	})
+
+	t.Run("Close Ends Every Subscription Case", func(t *testing.T) {
+		feed := NewChangeFeed()
+		before := feed.Subscribe(context.Background())
+
+		feed.Close()
+		after := feed.Subscribe(context.Background())
+
+		_, ok := <-before
+		assert.False(t, ok)
+		_, ok = <-after
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+)
+
+// Attributes holds the custom attributes of a device, values are strings,
+// numbers (float64) or booleans.
+type Attributes map[string]any
+
+type AttributeType string
+
+const (
+	AttributeString  AttributeType = "string"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+)
+
+func (at AttributeType) String() string {
+	return string(at)
+}
+
+// AttributeTypes lists every supported attribute type, in the order they are documented.
+var AttributeTypes = []AttributeType{AttributeString, AttributeNumber, AttributeBoolean}
+
+// AttributeDefinition constrains a custom attribute for the devices of a tenant.
+type AttributeDefinition struct {
+	TenantID string
+	Name     string
+	Type     AttributeType
+	Required bool
+	// Enum lists the allowed values of a string attribute, when empty any value is accepted.
+	Enum      []string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// ValidAttributeName accepts lower case names such as "carrier" or "cost_center",
+// the same names are used by the attr.<name> list filter.
+var ValidAttributeName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ValidTag accepts lower case tags such as "qa", "team:mobile" or "v2.1".
+var ValidTag = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:-]{0,49}$`)
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "created"
+	ChangeUpdated ChangeType = "updated"
+	ChangeDeleted ChangeType = "deleted"
+)
+
+func (ct ChangeType) String() string {
+	return string(ct)
+}
+
+// DeviceChange tells a device was created, updated or deleted, it carries no
+// device data as the device may have changed again by the time it is read.
+type DeviceChange struct {
+	DeviceID  uuid.UUID  `json:"device_id"`
+	Type      ChangeType `json:"change"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
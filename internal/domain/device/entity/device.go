@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,18 +14,68 @@ const (
 	Available DeviceState = "available"
 	InUse     DeviceState = "in-use"
 	Inactive  DeviceState = "inactive"
+	// Maintenance is only entered and left through maintenance records.
+	Maintenance DeviceState = "maintenance"
+	// Retired is terminal, it is entered by disposing of the device.
+	Retired DeviceState = "retired"
 )
 
+// WritableStates are the states a device may be created or updated with, the
+// others are only entered through maintenance and disposal.
+var WritableStates = []DeviceState{Available, InUse, Inactive}
+
 func (ds DeviceState) String() string {
 	return string(ds)
 }
 
+// Writable tells whether a device may be created or updated with the state.
+func (ds DeviceState) Writable() bool {
+	return slices.Contains(WritableStates, ds)
+}
+
+// ParseDate reads a date informed to the APIs in the field, as YYYY-MM-DD,
+// nil staying nil.
+func ParseDate(field string, value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	date, err := time.Parse(time.DateOnly, *value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s format, must be a YYYY-MM-DD date", field)
+	}
+	return &date, nil
+}
+
 type Device struct {
-	ID        uuid.UUID   `json:"id"`
-	Name      string      `json:"name"`
-	Brand     string      `json:"brand"`
-	State     DeviceState `json:"status"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt *time.Time  `json:"updated_at"`
-	DeletedAt *time.Time  `json:"deleted_at"`
+	ID              uuid.UUID   `json:"id"`
+	Name            string      `json:"name"`
+	Brand           string      `json:"brand"`
+	BrandID         uuid.UUID   `json:"brand_id"`
+	ModelID         *uuid.UUID  `json:"model_id"`
+	LocationID      *uuid.UUID  `json:"location_id"`
+	State           DeviceState `json:"status"`
+	SerialNumber    *string     `json:"serial_number"`
+	IMEI            *string     `json:"imei"`
+	ModelIdentifier *string     `json:"model_identifier"`
+	OSVersion       *string     `json:"os_version"`
+	// PurchaseDate and WarrantyEndsOn are dates, their time of day is always
+	// midnight UTC. PurchasePrice is in PurchaseCurrency, an ISO 4217 code.
+	PurchaseDate     *time.Time `json:"purchase_date"`
+	PurchasePrice    *float64   `json:"purchase_price"`
+	PurchaseCurrency *string    `json:"purchase_currency"`
+	Supplier         *string    `json:"supplier"`
+	InvoiceReference *string    `json:"invoice_reference"`
+	WarrantyEndsOn   *time.Time `json:"warranty_ends_on"`
+	Tags             []string   `json:"tags"`
+	Attributes       Attributes `json:"attributes"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at"`
+}
+
+type DeviceSearchResult struct {
+	Device         Device  `json:"device"`
+	Rank           float64 `json:"rank"`
+	NameHighlight  string  `json:"name_highlight"`
+	BrandHighlight string  `json:"brand_highlight"`
 }
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type SortField string
+
+const (
+	SortByName      SortField = "name"
+	SortByBrand     SortField = "brand"
+	SortByState     SortField = "state"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+)
+
+func (sf SortField) String() string {
+	return string(sf)
+}
+
+// SortFields lists every field devices can be sorted by, in the order they are documented.
+var SortFields = []SortField{SortByName, SortByBrand, SortByState, SortByCreatedAt, SortByUpdatedAt}
+
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// ParseSortKeys reads the sort keys as informed to the APIs, field names
+// preceded by "-" to sort descending, eg. -created_at,name.
+func ParseSortKeys(values []string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, value := range values {
+		keys = append(keys, SortKey{Field: SortField(strings.TrimPrefix(value, "-")), Descending: strings.HasPrefix(value, "-")})
+	}
+
+	if err := ValidateSortKeys(keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ValidateSortKeys checks that every key sorts by one of the SortFields, each
+// field informed once.
+func ValidateSortKeys(keys []SortKey) error {
+	seen := map[SortField]bool{}
+
+	for _, key := range keys {
+		if !slices.Contains(SortFields, key.Field) {
+			return fmt.Errorf("invalid sort field %q, must be any of: %s", key.Field, strings.Join(lo.Map(SortFields, func(field SortField, _ int) string { return field.String() }), ", "))
+		}
+		if seen[key.Field] {
+			return fmt.Errorf("sort field %q is informed more than once", key.Field)
+		}
+		seen[key.Field] = true
+	}
+
+	return nil
+}
+
+// DeviceFilter narrows a device listing, every informed criterion must match.
+// Nil pointers and empty slices mean the criterion is not applied.
+type DeviceFilter struct {
+	Brand   *string
+	BrandID *uuid.UUID
+	// Model is a model ID or a model name, resolved to ModelIDs by the service.
+	Model    *string
+	ModelIDs []uuid.UUID
+	// LocationID matches the devices kept at the location or any location below it.
+	LocationID *uuid.UUID
+	// States matches any of the states. When empty, retired devices are left
+	// out unless IncludeRetired is set.
+	States         []DeviceState
+	IncludeRetired bool
+	NameContains   *string
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	UpdatedSince   *time.Time
+	// Tags lists tags the device must carry, all of them.
+	Tags []string
+	// Attributes holds the values the device attributes must be equal to.
+	Attributes Attributes
+}
+
+// ListOptions combines the filter with the sort keys, applied in order, and the
+// page to return. A zero Limit returns every device.
+type ListOptions struct {
+	Filter DeviceFilter
+	Sort   []SortKey
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse_Sort_Keys(t *testing.T) {
+	testCases := []struct {
+		name    string
+		values  []string
+		want    []SortKey
+		wantErr error
+	}{
+		{
+			name:   "Ascending And Descending Keys",
+			values: []string{"-created_at", "name"},
+			want:   []SortKey{{Field: SortByCreatedAt, Descending: true}, {Field: SortByName}},
+		},
+		{
+			name:   "No Keys",
+			values: nil,
+			want:   nil,
+		},
+		{
+			name:    "Unknown Field",
+			values:  []string{"-colour"},
+			wantErr: errors.New(`invalid sort field "colour", must be any of: name, brand, state, created_at, updated_at`),
+		},
+		{
+			name:    "Field Informed Twice",
+			values:  []string{"name", "-name"},
+			wantErr: errors.New(`sort field "name" is informed more than once`),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseSortKeys(tt.values)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, keys)
+		})
+	}
+}
+
+func Test_Parse_Date(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   *string
+		want    *time.Time
+		wantErr error
+	}{
+		{name: "Date", value: lo.ToPtr("2025-08-31"), want: lo.ToPtr(time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC))},
+		{name: "Not Informed", value: nil, want: nil},
+		{name: "Timestamp", value: lo.ToPtr("2025-08-31T21:00:00Z"), wantErr: errors.New("invalid purchase_date format, must be a YYYY-MM-DD date")},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := ParseDate("purchase_date", tt.value)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, date)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HistoryEventType string
+
+const (
+	Moved        HistoryEventType = "moved"
+	Created      HistoryEventType = "created"
+	StateChanged HistoryEventType = "state-changed"
+	Deleted      HistoryEventType = "deleted"
+)
+
+func (het HistoryEventType) String() string {
+	return string(het)
+}
+
+// HistoryEvent records something that happened to a device. Moves carry the
+// location the device left, nil when it had none, and the one it went to.
+// Creations, state changes and deletions carry the state before and after,
+// they are recorded by the database whatever changed the device.
+type HistoryEvent struct {
+	ID             uuid.UUID        `json:"id"`
+	DeviceID       uuid.UUID        `json:"device_id"`
+	Event          HistoryEventType `json:"event"`
+	FromLocationID *uuid.UUID       `json:"from_location_id"`
+	ToLocationID   *uuid.UUID       `json:"to_location_id"`
+	FromState      *DeviceState     `json:"from_state"`
+	ToState        *DeviceState     `json:"to_state"`
+	Note           *string          `json:"note"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeIdentifier trims and upper cases a serial number or IMEI/MEID, so the
+// same identifier typed differently is stored and looked up the same way.
+func NormalizeIdentifier(identifier string) string {
+	return strings.ToUpper(strings.TrimSpace(identifier))
+}
+
+// IsValidIMEI reports whether value is a 15 digit IMEI whose last digit is the
+// Luhn check digit of the first 14.
+func IsValidIMEI(value string) bool {
+	if len(value) != 15 || !isDecimal(value) {
+		return false
+	}
+	return luhnValid(value, 10)
+}
+
+func isDecimal(value string) bool {
+	return strings.IndexFunc(value, func(r rune) bool { return r < '0' || r > '9' }) < 0
+}
+
+// IsValidMEID reports whether value is a MEID in hexadecimal form, either the 14
+// digit identifier alone or followed by its base 16 Luhn check digit. A 15 digit
+// decimal value is read as an IMEI, so it is never accepted as a MEID.
+func IsValidMEID(value string) bool {
+	if strings.IndexFunc(value, func(r rune) bool { return !unicode.Is(unicode.ASCII_Hex_Digit, r) }) >= 0 {
+		return false
+	}
+
+	switch len(value) {
+	case 14:
+		return true
+	case 15:
+		return !isDecimal(value) && luhnValid(value, 16)
+	default:
+		return false
+	}
+}
+
+// luhnValid checks the Luhn check digit, the last digit of value, in the given base.
+func luhnValid(value string, base int) bool {
+	sum := 0
+	double := false
+
+	for i := len(value) - 1; i >= 0; i-- {
+		digit := hexDigitValue(value[i])
+		if double {
+			digit *= 2
+			digit = digit/base + digit%base
+		}
+		sum += digit
+		double = !double
+	}
+
+	return sum%base == 0
+}
+
+func hexDigitValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10
+	default:
+		return int(c-'A') + 10
+	}
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Is_Valid_IMEI(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "Valid IMEI", value: "490154203237518", want: true},
+		{name: "Wrong Check Digit", value: "490154203237519", want: false},
+		{name: "Too Short", value: "49015420323751", want: false},
+		{name: "Non Digit", value: "49015420323751A", want: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidIMEI(tt.value))
+		})
+	}
+}
+
+func Test_Is_Valid_MEID(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "Valid MEID Without Check Digit", value: "A10000009296F2", want: true},
+		{name: "Valid MEID With Check Digit", value: "A10000009296F2F", want: true},
+		{name: "Wrong Check Digit", value: "A10000009296F20", want: false},
+		{name: "Decimal Value Is An IMEI", value: "490154203237519", want: false},
+		{name: "Non Hexadecimal", value: "G10000009296F2", want: false},
+		{name: "Too Long", value: "A10000009296F2F0", want: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidMEID(tt.value))
+		})
+	}
+}
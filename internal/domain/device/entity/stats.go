@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// States lists every state a device can be in.
+var States = []DeviceState{Available, InUse, Inactive, Maintenance, Retired}
+
+// StatsOptions selects what goes in the inventory stats. The daily series
+// covers From to To, both dates included.
+type StatsOptions struct {
+	ByLocation bool
+	From       time.Time
+	To         time.Time
+}
+
+// DeviceStats summarises the devices that are not deleted.
+type DeviceStats struct {
+	Total   int
+	ByState map[DeviceState]int
+	ByBrand []BrandCount
+	// ByLocation is only filled when requested, devices without a location
+	// are counted with a nil LocationID.
+	ByLocation []LocationCount
+	Daily      []DailyStateCount
+}
+
+type BrandCount struct {
+	BrandID uuid.UUID
+	Brand   string
+	Devices int
+}
+
+type LocationCount struct {
+	LocationID *uuid.UUID
+	Name       *string
+	Devices    int
+}
+
+// DailyStateCount holds how many devices were in each state at the end of Day,
+// a date at midnight UTC, as recorded in the device history.
+type DailyStateCount struct {
+	Day     time.Time
+	ByState map[DeviceState]int
+}
@@ -7,6 +7,7 @@ type DeviceErrorType string
 const (
 	ErrNotFound DeviceErrorType = "not_found"
 	ErrInvalid  DeviceErrorType = "invalid"
+	ErrConflict DeviceErrorType = "conflict"
 	ErrInternal DeviceErrorType = "internal"
 )
 
@@ -23,6 +24,12 @@ func (e *DeviceError) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the cause of the error, so it can be told by errors.Is and
+// errors.As, as a context canceled.
+func (e *DeviceError) Unwrap() error {
+	return e.Err
+}
+
 func NewDeviceError(t DeviceErrorType, msg string, err error) *DeviceError {
 	return &DeviceError{
 		Type:    t,
@@ -0,0 +1,16 @@
+package device
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	modelentity "github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+)
+
+//go:generate mockgen -source=models.go -destination=../mocks/model_catalogue_mock.go -package=mocks
+
+// ModelCatalogue reads the models devices reference from the model catalogue.
+type ModelCatalogue interface {
+	GetByID(ctx context.Context, id uuid.UUID) (modelentity.Model, error)
+	FindByName(ctx context.Context, name string, brandID *uuid.UUID) ([]modelentity.Model, error)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+//go:generate mockgen -source=attribute_definition_repository.go -destination=../../mocks/attribute_definition_repository_mock.go -package=mocks
+
+type AttributeDefinitionRepository interface {
+	ListAttributeDefinitions(ctx context.Context, tenantID string) ([]entity.AttributeDefinition, error)
+	UpsertAttributeDefinition(ctx context.Context, definition *entity.AttributeDefinition) error
+	DeleteAttributeDefinition(ctx context.Context, tenantID string, name string) error
+}
+
+type postgresAttributeDefinitionRepository struct {
+	db *sql.DB
+}
+
+func NewAttributeDefinitionRepository(db *sql.DB) *postgresAttributeDefinitionRepository {
+	return &postgresAttributeDefinitionRepository{db: db}
+}
+
+func (r *postgresAttributeDefinitionRepository) ListAttributeDefinitions(ctx context.Context, tenantID string) ([]entity.AttributeDefinition, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var definitions []entity.AttributeDefinition
+
+	query := `
+	SELECT tenant_id, name, type, required, enum, created_at, updated_at
+	FROM attribute_definitions
+	WHERE tenant_id = $1
+	ORDER BY name;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d entity.AttributeDefinition
+		err = rows.Scan(&d.TenantID, &d.Name, &d.Type, &d.Required, pq.Array(&d.Enum), &d.CreatedAt, &d.UpdatedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		definitions = append(definitions, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+// UpsertAttributeDefinition creates the definition or replaces the one with the same name.
+func (r *postgresAttributeDefinitionRepository) UpsertAttributeDefinition(ctx context.Context, definition *entity.AttributeDefinition) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	query := `
+	INSERT INTO attribute_definitions (tenant_id, name, type, required, enum)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (tenant_id, name) DO UPDATE SET
+		type = EXCLUDED.type,
+		required = EXCLUDED.required,
+		enum = EXCLUDED.enum,
+		updated_at = now()
+	RETURNING created_at, updated_at;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	enum := definition.Enum
+	if enum == nil {
+		enum = []string{}
+	}
+
+	err = stmt.QueryRowContext(
+		ctx,
+		definition.TenantID,
+		definition.Name,
+		definition.Type.String(),
+		definition.Required,
+		pq.Array(enum),
+	).Scan(&definition.CreatedAt, &definition.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresAttributeDefinitionRepository) DeleteAttributeDefinition(ctx context.Context, tenantID string, name string) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	query := `DELETE FROM attribute_definitions WHERE tenant_id = $1 AND name = $2;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, tenantID, name)
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount <= 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
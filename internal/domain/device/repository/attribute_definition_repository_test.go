@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+func Test_List_Attribute_Definitions(t *testing.T) {
+	assert := assert.New(t)
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	listQuery := regexp.QuoteMeta(`
+	SELECT tenant_id, name, type, required, enum, created_at, updated_at
+	FROM attribute_definitions
+	WHERE tenant_id = $1
+	ORDER BY name;`)
+
+	columns := []string{"tenant_id", "name", "type", "required", "enum", "created_at", "updated_at"}
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult []entity.AttributeDefinition
+	}{
+		{
+			name: "List Attribute Definitions Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs("acme").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("acme", "carrier", "string", true, "{vodafone,orange}", createdAt, nil).
+						AddRow("acme", "cost", "number", false, "{}", createdAt, createdAt))
+			},
+			wantedErr: nil,
+			wantedResult: []entity.AttributeDefinition{
+				{TenantID: "acme", Name: "carrier", Type: entity.AttributeString, Required: true, Enum: []string{"vodafone", "orange"}, CreatedAt: createdAt},
+				{TenantID: "acme", Name: "cost", Type: entity.AttributeNumber, Enum: []string{}, CreatedAt: createdAt, UpdatedAt: &createdAt},
+			},
+		},
+		{
+			name: "List Attribute Definitions Fails on Prepare Statement",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQuery).
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: nil,
+		},
+		{
+			name: "List Attribute Definitions Fails on Query",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs("acme").
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewAttributeDefinitionRepository(db)
+
+			tt.sqlMock(mock)
+
+			definitions, err := repository.ListAttributeDefinitions(context.TODO(), "acme")
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, definitions)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Upsert_Attribute_Definition(t *testing.T) {
+	assert := assert.New(t)
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	upsertQuery := regexp.QuoteMeta(`
+	INSERT INTO attribute_definitions (tenant_id, name, type, required, enum)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (tenant_id, name) DO UPDATE SET
+		type = EXCLUDED.type,
+		required = EXCLUDED.required,
+		enum = EXCLUDED.enum,
+		updated_at = now()
+	RETURNING created_at, updated_at;`)
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		definition   entity.AttributeDefinition
+		wantedErr    error
+		wantedResult entity.AttributeDefinition
+	}{
+		{
+			name: "Upsert Attribute Definition Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(upsertQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs("acme", "cost", "number", false, pq.Array([]string{})).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, nil))
+			},
+			definition:   entity.AttributeDefinition{TenantID: "acme", Name: "cost", Type: entity.AttributeNumber},
+			wantedErr:    nil,
+			wantedResult: entity.AttributeDefinition{TenantID: "acme", Name: "cost", Type: entity.AttributeNumber, CreatedAt: createdAt},
+		},
+		{
+			name: "Upsert Attribute Definition Fails on Insert",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(upsertQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			definition:   entity.AttributeDefinition{TenantID: "acme", Name: "carrier", Type: entity.AttributeString, Enum: []string{"vodafone"}},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: entity.AttributeDefinition{TenantID: "acme", Name: "carrier", Type: entity.AttributeString, Enum: []string{"vodafone"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewAttributeDefinitionRepository(db)
+
+			tt.sqlMock(mock)
+
+			definition := tt.definition
+			err = repository.UpsertAttributeDefinition(context.TODO(), &definition)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, definition)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Delete_Attribute_Definition(t *testing.T) {
+	assert := assert.New(t)
+
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM attribute_definitions WHERE tenant_id = $1 AND name = $2;`)
+
+	testCases := []struct {
+		name      string
+		sqlMock   func(mock sqlmock.Sqlmock)
+		wantedErr error
+	}{
+		{
+			name: "Delete Attribute Definition Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deleteQuery).
+					WillBeClosed().
+					ExpectExec().
+					WithArgs("acme", "carrier").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantedErr: nil,
+		},
+		{
+			name: "Delete Attribute Definition Not Found",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deleteQuery).
+					WillBeClosed().
+					ExpectExec().
+					WithArgs("acme", "carrier").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+		{
+			name: "Delete Attribute Definition Fails on Exec",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deleteQuery).
+					WillBeClosed().
+					ExpectExec().
+					WithArgs("acme", "carrier").
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			wantedErr: fmt.Errorf("some database error"),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewAttributeDefinitionRepository(db)
+
+			tt.sqlMock(mock)
+
+			err = repository.DeleteAttributeDefinition(context.TODO(), "acme", "carrier")
+
+			assert.Equal(tt.wantedErr, err)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+// DeviceChangesChannel is the channel the database notifies device changes on.
+const DeviceChangesChannel = "device_changes"
+
+// ListenDeviceChanges publishes the device changes read from notifications
+// until ctx is done or notifications is closed. Malformed notifications are
+// logged and skipped.
+func ListenDeviceChanges(ctx context.Context, notifications <-chan *pq.Notification, publish func(entity.DeviceChange)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+			// a nil notification follows a reconnection, changes made while
+			// disconnected were not notified
+			if notification == nil {
+				log.Println("device changes listener reconnected, changes made meanwhile were missed")
+				continue
+			}
+
+			var change entity.DeviceChange
+			if err := json.Unmarshal([]byte(notification.Extra), &change); err != nil {
+				log.Printf("invalid device change notification %q: %v", notification.Extra, err)
+				continue
+			}
+			publish(change)
+		}
+	}
+}
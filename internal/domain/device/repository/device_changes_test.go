@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+func Test_Listen_Device_Changes(t *testing.T) {
+	notifications := make(chan *pq.Notification, 4)
+	notifications <- &pq.Notification{Channel: DeviceChangesChannel, Extra: `{"device_id": "b44ecc02-872e-4c18-8d2a-ac09dfc4b49a", "change": "created", "changed_at": "2025-09-01T10:00:00.123456+00:00"}`}
+	notifications <- nil
+	notifications <- &pq.Notification{Channel: DeviceChangesChannel, Extra: `not json`}
+	notifications <- &pq.Notification{Channel: DeviceChangesChannel, Extra: `{"device_id": "c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19", "change": "deleted", "changed_at": "2025-09-01T10:05:00+00:00"}`}
+	close(notifications)
+
+	var published []entity.DeviceChange
+	ListenDeviceChanges(context.Background(), notifications, func(change entity.DeviceChange) {
+		published = append(published, change)
+	})
+
+	wanted := []entity.DeviceChange{
+		{
+			DeviceID:  uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
+			Type:      entity.ChangeCreated,
+			ChangedAt: time.Date(2025, 9, 1, 10, 0, 0, 123456000, time.UTC),
+		},
+		{
+			DeviceID:  uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"),
+			Type:      entity.ChangeDeleted,
+			ChangedAt: time.Date(2025, 9, 1, 10, 5, 0, 0, time.UTC),
+		},
+	}
+
+	assert.Len(t, published, len(wanted))
+	for i, change := range published {
+		assert.Equal(t, wanted[i].DeviceID, change.DeviceID)
+		assert.Equal(t, wanted[i].Type, change.Type)
+		assert.True(t, wanted[i].ChangedAt.Equal(change.ChangedAt))
+	}
+}
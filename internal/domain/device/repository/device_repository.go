@@ -3,38 +3,140 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"html"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/database"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
 )
 
 //go:generate mockgen -source=device_repository.go -destination=../../mocks/device_repository_mock.go -package=mocks
 
 type DeviceRepository interface {
+	WithinTx(ctx context.Context, isolation sql.IsolationLevel, fn func(repo DeviceRepository) error) error
 	CreateDevice(ctx context.Context, device *entity.Device) error
 	GetDeviceByID(ctx context.Context, id uuid.UUID) (entity.Device, error)
+	GetDeviceForUpdate(ctx context.Context, id uuid.UUID) (entity.Device, error)
+	GetDeviceBySerialNumber(ctx context.Context, serialNumber string) (entity.Device, error)
 	FullyUpdateDevice(ctx context.Context, device *entity.Device) error
 	UpdateDeviceState(ctx context.Context, deviceID uuid.UUID, newState entity.DeviceState) (entity.Device, error)
 	DeleteDevice(ctx context.Context, id uuid.UUID) error
-	ListDevices(ctx context.Context, params map[string]any) ([]entity.Device, error)
+	ListDevices(ctx context.Context, opts entity.ListOptions) ([]entity.Device, error)
+	SearchDevices(ctx context.Context, term string, opts entity.ListOptions) ([]entity.DeviceSearchResult, error)
+	MoveDevice(ctx context.Context, deviceID uuid.UUID, locationID uuid.UUID, note *string) (entity.Device, error)
+	ListDeviceHistory(ctx context.Context, deviceID uuid.UUID) ([]entity.HistoryEvent, error)
+	HasUpcomingReservations(ctx context.Context, deviceID uuid.UUID) (bool, error)
+	GetDeviceStats(ctx context.Context, opts entity.StatsOptions) (entity.DeviceStats, error)
+}
+
+// deviceColumns is the column list read into an entity.Device by deviceScanFields, in the same order.
+const deviceColumns = `id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at`
+
+func deviceScanFields(device *entity.Device) []any {
+	return []any{
+		&device.ID,
+		&device.Name,
+		&device.Brand,
+		&device.BrandID,
+		&device.ModelID,
+		&device.LocationID,
+		&device.State,
+		&device.SerialNumber,
+		&device.IMEI,
+		&device.ModelIdentifier,
+		&device.OSVersion,
+		&device.PurchaseDate,
+		&device.PurchasePrice,
+		&device.PurchaseCurrency,
+		&device.Supplier,
+		&device.InvoiceReference,
+		&device.WarrantyEndsOn,
+		pq.Array(&device.Tags),
+		&attributesColumn{dest: &device.Attributes},
+		&device.CreatedAt,
+		&device.UpdatedAt,
+		&device.DeletedAt,
+	}
+}
+
+// attributesColumn scans the attributes JSONB column into entity.Attributes.
+type attributesColumn struct {
+	dest *entity.Attributes
+}
+
+func (ac *attributesColumn) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*ac.dest = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, ac.dest)
+	case string:
+		return json.Unmarshal([]byte(value), ac.dest)
+	default:
+		return fmt.Errorf("unsupported attributes value of type %T", src)
+	}
+}
+
+// tagsArgument never sends NULL, devices without tags store an empty array.
+func tagsArgument(tags []string) any {
+	if tags == nil {
+		tags = []string{}
+	}
+	return pq.Array(tags)
+}
+
+// attributesArgument encodes the attributes as a JSON object, devices without
+// attributes store an empty object.
+func attributesArgument(attributes entity.Attributes) (string, error) {
+	if attributes == nil {
+		return "{}", nil
+	}
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
 }
 
 type postegresDeviceRepository struct {
-	db *sql.DB
+	db database.Conn
 }
 
 func NewDeviceRepository(db *sql.DB) *postegresDeviceRepository {
 	return &postegresDeviceRepository{db: db}
 }
 
+// WithinTx runs fn with a repository whose operations share a transaction of
+// the isolation level, run again on serialization failures. Within a
+// transaction already, fn joins it.
+func (r *postegresDeviceRepository) WithinTx(ctx context.Context, isolation sql.IsolationLevel, fn func(repo DeviceRepository) error) error {
+	return database.WithinTx(ctx, r.db, &sql.TxOptions{Isolation: isolation}, func(tx *sql.Tx) error {
+		return fn(&postegresDeviceRepository{db: tx})
+	})
+}
+
 func (r *postegresDeviceRepository) CreateDevice(ctx context.Context, device *entity.Device) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
 	const query = `
-	INSERT INTO devices (id, name, brand, state)
-	VALUES ($1, $2, $3, $4)
+	INSERT INTO devices (id, name, brand, state, serial_number, imei, model_identifier, os_version, tags, attributes, brand_id, model_id,
+		purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	RETURNING id, created_at, updated_at, deleted_at;`
 
+	attributes, err := attributesArgument(device.Attributes)
+	if err != nil {
+		return err
+	}
+
 	statment, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return err
@@ -47,6 +149,20 @@ func (r *postegresDeviceRepository) CreateDevice(ctx context.Context, device *en
 			device.Name,
 			device.Brand,
 			device.State.String(),
+			device.SerialNumber,
+			device.IMEI,
+			device.ModelIdentifier,
+			device.OSVersion,
+			tagsArgument(device.Tags),
+			attributes,
+			device.BrandID,
+			device.ModelID,
+			device.PurchaseDate,
+			device.PurchasePrice,
+			device.PurchaseCurrency,
+			device.Supplier,
+			device.InvoiceReference,
+			device.WarrantyEndsOn,
 		).
 		Scan(&device.ID, &device.CreatedAt, &device.UpdatedAt, &device.DeletedAt)
 
@@ -58,10 +174,13 @@ func (r *postegresDeviceRepository) CreateDevice(ctx context.Context, device *en
 }
 
 func (r *postegresDeviceRepository) GetDeviceByID(ctx context.Context, id uuid.UUID) (entity.Device, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
 	var device entity.Device
 
 	query := `
-	SELECT id, name, brand, state, created_at, updated_at, deleted_at
+	SELECT ` + deviceColumns + `
 	FROM devices
 	WHERE id = $1 AND deleted_at IS NULL;`
 
@@ -74,14 +193,67 @@ func (r *postegresDeviceRepository) GetDeviceByID(ctx context.Context, id uuid.U
 	err = stmt.QueryRowContext(
 		ctx,
 		id.String(),
-	).Scan(
-		&device.ID,
-		&device.Name,
-		&device.Brand,
-		&device.State,
-		&device.CreatedAt,
-		&device.UpdatedAt,
-		&device.DeletedAt)
+	).Scan(deviceScanFields(&device)...)
+	if err != nil {
+		return device, err
+	}
+
+	return device, nil
+}
+
+// GetDeviceForUpdate reads the device and locks its row until the transaction
+// it runs within ends, so concurrent writers of the device, reservations
+// included, wait for the changes decided on what was read.
+func (r *postegresDeviceRepository) GetDeviceForUpdate(ctx context.Context, id uuid.UUID) (entity.Device, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var device entity.Device
+
+	query := `
+	SELECT ` + deviceColumns + `
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return device, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(
+		ctx,
+		id.String(),
+	).Scan(deviceScanFields(&device)...)
+	if err != nil {
+		return device, err
+	}
+
+	return device, nil
+}
+
+func (r *postegresDeviceRepository) GetDeviceBySerialNumber(ctx context.Context, serialNumber string) (entity.Device, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var device entity.Device
+
+	query := `
+	SELECT ` + deviceColumns + `
+	FROM devices
+	WHERE serial_number = $1 AND deleted_at IS NULL;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return device, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(
+		ctx,
+		serialNumber,
+	).Scan(deviceScanFields(&device)...)
 	if err != nil {
 		return device, err
 	}
@@ -90,15 +262,37 @@ func (r *postegresDeviceRepository) GetDeviceByID(ctx context.Context, id uuid.U
 }
 
 func (r *postegresDeviceRepository) FullyUpdateDevice(ctx context.Context, device *entity.Device) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
 	query := `
 	UPDATE devices SET 
 		name = $2,
 		brand = $3,
 		state = $4,
+		serial_number = $5,
+		imei = $6,
+		model_identifier = $7,
+		os_version = $8,
+		tags = $9,
+		attributes = $10,
+		brand_id = $11,
+		model_id = $12,
+		purchase_date = $13,
+		purchase_price = $14,
+		purchase_currency = $15,
+		supplier = $16,
+		invoice_reference = $17,
+		warranty_ends_on = $18,
 		updated_at = now()
 	WHERE id = $1 AND deleted_at IS NULL
 	RETURNING id, created_at, updated_at, deleted_at;`
 
+	attributes, err := attributesArgument(device.Attributes)
+	if err != nil {
+		return err
+	}
+
 	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return err
@@ -111,6 +305,20 @@ func (r *postegresDeviceRepository) FullyUpdateDevice(ctx context.Context, devic
 		device.Name,
 		device.Brand,
 		device.State.String(),
+		device.SerialNumber,
+		device.IMEI,
+		device.ModelIdentifier,
+		device.OSVersion,
+		tagsArgument(device.Tags),
+		attributes,
+		device.BrandID,
+		device.ModelID,
+		device.PurchaseDate,
+		device.PurchasePrice,
+		device.PurchaseCurrency,
+		device.Supplier,
+		device.InvoiceReference,
+		device.WarrantyEndsOn,
 	).Scan(
 		&device.ID,
 		&device.CreatedAt,
@@ -126,13 +334,16 @@ func (r *postegresDeviceRepository) FullyUpdateDevice(ctx context.Context, devic
 }
 
 func (r *postegresDeviceRepository) UpdateDeviceState(ctx context.Context, deviceID uuid.UUID, newStatus entity.DeviceState) (entity.Device, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
 	var device entity.Device
 	query := `
 	UPDATE devices SET 
 		state = $2,
 		updated_at = now()
 	WHERE id = $1 AND deleted_at IS NULL
-	RETURNING id, name, brand, state, created_at, updated_at, deleted_at;`
+	RETURNING ` + deviceColumns + `;`
 
 	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
@@ -144,15 +355,7 @@ func (r *postegresDeviceRepository) UpdateDeviceState(ctx context.Context, devic
 		ctx,
 		deviceID.String(),
 		newStatus.String(),
-	).Scan(
-		&device.ID,
-		&device.Name,
-		&device.Brand,
-		&device.State,
-		&device.CreatedAt,
-		&device.UpdatedAt,
-		&device.DeletedAt,
-	)
+	).Scan(deviceScanFields(&device)...)
 
 	if err != nil {
 		return device, err
@@ -162,7 +365,10 @@ func (r *postegresDeviceRepository) UpdateDeviceState(ctx context.Context, devic
 }
 
 func (r *postegresDeviceRepository) DeleteDevice(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE devices SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL AND state <> 'in-use';`
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	query := `UPDATE devices SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL AND state NOT IN ('in-use', 'maintenance');`
 
 	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
@@ -189,10 +395,16 @@ func (r *postegresDeviceRepository) DeleteDevice(ctx context.Context, id uuid.UU
 	return nil
 }
 
-func (r *postegresDeviceRepository) ListDevices(ctx context.Context, filterBy map[string]any) ([]entity.Device, error) {
+func (r *postegresDeviceRepository) ListDevices(ctx context.Context, opts entity.ListOptions) ([]entity.Device, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
 	var devices []entity.Device
 
-	query, params := buildListDeviceQueryWithParams(filterBy)
+	query, params, err := buildListDeviceQueryWithParams(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
@@ -208,14 +420,7 @@ func (r *postegresDeviceRepository) ListDevices(ctx context.Context, filterBy ma
 
 	for rows.Next() {
 		var d entity.Device
-		err = rows.Scan(
-			&d.ID,
-			&d.Name,
-			&d.Brand,
-			&d.State,
-			&d.CreatedAt,
-			&d.UpdatedAt,
-			&d.DeletedAt)
+		err = rows.Scan(deviceScanFields(&d)...)
 
 		if err != nil {
 			return nil, err
@@ -231,29 +436,477 @@ func (r *postegresDeviceRepository) ListDevices(ctx context.Context, filterBy ma
 	return devices, nil
 }
 
-func buildListDeviceQueryWithParams(filterBy map[string]any) (string, []any) {
+func (r *postegresDeviceRepository) SearchDevices(ctx context.Context, term string, opts entity.ListOptions) ([]entity.DeviceSearchResult, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var results []entity.DeviceSearchResult
+
+	query, params, err := buildSearchDeviceQueryWithParams(term, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result entity.DeviceSearchResult
+		err = rows.Scan(append(deviceScanFields(&result.Device), &result.Rank, &result.NameHighlight, &result.BrandHighlight)...)
+
+		if err != nil {
+			return nil, err
+		}
+		result.NameHighlight = highlightHTML(result.NameHighlight)
+		result.BrandHighlight = highlightHTML(result.BrandHighlight)
+
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// MoveDevice takes the device to locationID and records the move in the device
+// history, in a single transaction. The device row is locked first so the
+// location recorded as left is the one the device was really at.
+func (r *postegresDeviceRepository) MoveDevice(ctx context.Context, deviceID uuid.UUID, locationID uuid.UUID, note *string) (entity.Device, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var device entity.Device
+
+	err := database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		var fromLocationID *uuid.UUID
+		err := tx.QueryRowContext(ctx, `
+	SELECT location_id
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`, deviceID).Scan(&fromLocationID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+	UPDATE devices SET
+		location_id = $2,
+		updated_at = now()
+	WHERE id = $1
+	RETURNING `+deviceColumns+`;`, deviceID, locationID).Scan(deviceScanFields(&device)...)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+	INSERT INTO device_history (id, device_id, event, from_location_id, to_location_id, note)
+	VALUES ($1, $2, $3, $4, $5, $6);`, uuid.New(), deviceID, entity.Moved.String(), fromLocationID, locationID, note)
+		return err
+	})
+
+	return device, err
+}
+
+func (r *postegresDeviceRepository) ListDeviceHistory(ctx context.Context, deviceID uuid.UUID) ([]entity.HistoryEvent, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var events []entity.HistoryEvent
+
+	query := `
+	SELECT id, device_id, event, from_location_id, to_location_id, from_state, to_state, note, created_at
+	FROM device_history
+	WHERE device_id = $1
+	ORDER BY created_at DESC;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e entity.HistoryEvent
+		err = rows.Scan(&e.ID, &e.DeviceID, &e.Event, &e.FromLocationID, &e.ToLocationID, &e.FromState, &e.ToState, &e.Note, &e.CreatedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// HasUpcomingReservations reports whether the device has reservations, not
+// cancelled, that have not ended yet.
+func (r *postegresDeviceRepository) HasUpcomingReservations(ctx context.Context, deviceID uuid.UUID) (bool, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var upcoming bool
+
+	query := `
+	SELECT EXISTS (
+		SELECT 1
+		FROM reservations
+		WHERE device_id = $1 AND status <> 'cancelled' AND upper(period) > now()
+	);`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, deviceID).Scan(&upcoming)
+	if err != nil {
+		return false, err
+	}
+
+	return upcoming, nil
+}
+
+func buildListDeviceQueryWithParams(opts entity.ListOptions) (string, []any, error) {
+	queryFilters, params, err := buildListDeviceFilters(opts.Filter, 1)
+	if err != nil {
+		return "", nil, err
+	}
+
+	orderBy, err := buildListDeviceOrderBy(opts.Sort, "name")
+	if err != nil {
+		return "", nil, err
+	}
+
+	page, params := buildListDevicePage(opts, &orderBy, params)
+
+	baseQuery := `SELECT ` + deviceColumns + `
+	FROM devices
+	WHERE deleted_at IS NULL %v
+	ORDER BY %v%v;`
+
+	return fmt.Sprintf(baseQuery, strings.Join(queryFilters, " "), orderBy, page), params, nil
+}
+
+// highlightStart and highlightStop delimit the matched words in the headlines,
+// private use characters so they are not mistaken for markup sent in the
+// device fields.
+const (
+	highlightStart  = "\uE000"
+	highlightStop   = "\uE001"
+	headlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", HighlightAll=true"
+)
+
+var highlightMarkup = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// highlightHTML escapes the headline as HTML, name and brand being user input,
+// and only then wraps the matched words in <mark> tags.
+func highlightHTML(headline string) string {
+	return highlightMarkup.Replace(html.EscapeString(headline))
+}
+
+// buildSearchDeviceQueryWithParams ranks devices by full-text relevance on name
+// and brand, falling back to trigram similarity so misspelled terms still match.
+// $1 is the prefix tsquery and $2 the raw term used for similarity.
+func buildSearchDeviceQueryWithParams(term string, opts entity.ListOptions) (string, []any, error) {
+	queryFilters, filterParams, err := buildListDeviceFilters(opts.Filter, 3)
+	if err != nil {
+		return "", nil, err
+	}
+	params := append([]any{toPrefixTsQuery(term), strings.ToLower(strings.TrimSpace(term))}, filterParams...)
+
+	orderBy, err := buildListDeviceOrderBy(opts.Sort, "rank DESC, name")
+	if err != nil {
+		return "", nil, err
+	}
+
+	page, params := buildListDevicePage(opts, &orderBy, params)
+
+	baseQuery := `SELECT ` + deviceColumns + `,
+		ts_rank(search_vector, query) + greatest(similarity(name, $2), similarity(brand, $2)) AS rank,
+		ts_headline('simple', name, query, '` + headlineOptions + `') AS name_highlight,
+		ts_headline('simple', brand, query, '` + headlineOptions + `') AS brand_highlight
+	FROM devices, to_tsquery('simple', $1) query
+	WHERE deleted_at IS NULL AND (search_vector @@ query OR name %% $2 OR brand %% $2) %v
+	ORDER BY %v%v;`
+
+	return fmt.Sprintf(baseQuery, strings.Join(queryFilters, " "), orderBy, page), params, nil
+}
+
+// buildListDevicePage limits the listing to the requested page. The id breaks
+// ties in the order, so that pages neither repeat nor skip devices.
+func buildListDevicePage(opts entity.ListOptions, orderBy *string, params []any) (string, []any) {
+	if opts.Limit <= 0 {
+		return "", params
+	}
+
+	*orderBy += ", id"
+	params = append(params, opts.Limit, opts.Offset)
+	return fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(params)-1, len(params)), params
+}
+
+// buildListDeviceFilters always emits the conditions in the same order, so the
+// same filter produces the same statement and argument positions.
+func buildListDeviceFilters(filter entity.DeviceFilter, firstParam int) ([]string, []any, error) {
 	queryFilters := []string{}
 	params := make([]any, 0)
 
-	fieldPrefix := map[string]string{
-		"brand": "lower(brand)",
-		"state": "state",
+	addFilter := func(condition string, value any) {
+		queryFilters = append(queryFilters, fmt.Sprintf("AND "+condition, firstParam+len(params)))
+		params = append(params, value)
 	}
 
-	counter := 1
-	for field, value := range filterBy {
-		if value == nil {
-			continue
+	if filter.Brand != nil {
+		addFilter("lower(brand) = lower($%v)", *filter.Brand)
+	}
+	if filter.BrandID != nil {
+		addFilter("brand_id = $%v", *filter.BrandID)
+	}
+	if len(filter.ModelIDs) > 0 {
+		modelIDs := make([]string, 0, len(filter.ModelIDs))
+		for _, modelID := range filter.ModelIDs {
+			modelIDs = append(modelIDs, modelID.String())
 		}
-		queryFilters = append(queryFilters, fmt.Sprintf("AND %v = $%v", fieldPrefix[field], counter))
-		params = append(params, value)
-		counter++
+		addFilter("model_id = ANY($%v::uuid[])", pq.Array(modelIDs))
+	}
+	if filter.LocationID != nil {
+		// the location and every location below it, walked by a recursive CTE
+		addFilter(`location_id IN (
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM locations WHERE id = $%v
+			UNION ALL
+			SELECT child.id FROM locations child JOIN subtree s ON child.parent_id = s.id
+		)
+		SELECT id FROM subtree)`, *filter.LocationID)
+	}
+	if len(filter.States) > 0 {
+		states := make([]string, 0, len(filter.States))
+		for _, state := range filter.States {
+			states = append(states, state.String())
+		}
+		addFilter("state = ANY($%v::device_state[])", pq.Array(states))
+	} else if !filter.IncludeRetired {
+		queryFilters = append(queryFilters, "AND state <> 'retired'")
+	}
+	if filter.NameContains != nil {
+		addFilter("name ILIKE $%v", "%"+escapeLikePattern(*filter.NameContains)+"%")
+	}
+	if filter.CreatedAfter != nil {
+		addFilter("created_at > $%v", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		addFilter("created_at < $%v", *filter.CreatedBefore)
+	}
+	if filter.UpdatedSince != nil {
+		addFilter("updated_at >= $%v", *filter.UpdatedSince)
+	}
+	if len(filter.Tags) > 0 {
+		addFilter("tags @> $%v::text[]", pq.Array(filter.Tags))
+	}
+	if len(filter.Attributes) > 0 {
+		attributes, err := attributesArgument(filter.Attributes)
+		if err != nil {
+			return nil, nil, err
+		}
+		addFilter("attributes @> $%v::jsonb", attributes)
+	}
+
+	return queryFilters, params, nil
+}
+
+// sortColumns is the whitelist of sortable columns, sort keys are never
+// interpolated into the statement unless they are listed here.
+var sortColumns = map[entity.SortField]string{
+	entity.SortByName:      "name",
+	entity.SortByBrand:     "lower(brand)",
+	entity.SortByState:     "state",
+	entity.SortByCreatedAt: "created_at",
+	entity.SortByUpdatedAt: "updated_at",
+}
+
+func buildListDeviceOrderBy(keys []entity.SortKey, defaultOrder string) (string, error) {
+	if len(keys) == 0 {
+		return defaultOrder, nil
+	}
+
+	orderBy := make([]string, 0, len(keys))
+	for _, key := range keys {
+		column, ok := sortColumns[key.Field]
+		if !ok {
+			return "", fmt.Errorf("unsupported sort field %q", key.Field)
+		}
+		if key.Descending {
+			column += " DESC"
+		}
+		orderBy = append(orderBy, column)
 	}
 
-	baseQuery := `SELECT id, name, brand, state, created_at, updated_at, deleted_at
+	return strings.Join(orderBy, ", "), nil
+}
+
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLikePattern makes LIKE wildcards in user input match literally.
+func escapeLikePattern(value string) string {
+	return likePatternEscaper.Replace(value)
+}
+
+// toPrefixTsQuery turns free text into a tsquery where every word must match as
+// a prefix, e.g. "sony eric" becomes "sony:* & eric:*". Anything that is not a
+// letter or a digit is treated as a separator, so the result is always a valid
+// tsquery.
+func toPrefixTsQuery(term string) string {
+	words := strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+// GetDeviceStats aggregates the devices by state, brand and, when requested,
+// location, and rebuilds their daily state counts from the history. The
+// queries share a snapshot so the numbers add up. States without devices are
+// left out of the maps.
+func (r *postegresDeviceRepository) GetDeviceStats(ctx context.Context, opts entity.StatsOptions) (entity.DeviceStats, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var stats entity.DeviceStats
+
+	err := database.WithinTx(ctx, r.db, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, func(tx *sql.Tx) error {
+		stats = entity.DeviceStats{ByState: map[entity.DeviceState]int{}}
+
+		err := queryRows(ctx, tx, `
+	SELECT state, count(*)
 	FROM devices
-	WHERE deleted_at IS NULL %v
-	ORDER BY name;`
+	WHERE deleted_at IS NULL
+	GROUP BY state;`, nil, func(rows *sql.Rows) error {
+			var state entity.DeviceState
+			var devices int
+			if err := rows.Scan(&state, &devices); err != nil {
+				return err
+			}
+			stats.ByState[state] = devices
+			stats.Total += devices
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = queryRows(ctx, tx, `
+	SELECT brand_id, brand, count(*) AS devices
+	FROM devices
+	WHERE deleted_at IS NULL
+	GROUP BY brand_id, brand
+	ORDER BY devices DESC, brand;`, nil, func(rows *sql.Rows) error {
+			var count entity.BrandCount
+			if err := rows.Scan(&count.BrandID, &count.Brand, &count.Devices); err != nil {
+				return err
+			}
+			stats.ByBrand = append(stats.ByBrand, count)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if opts.ByLocation {
+			err = queryRows(ctx, tx, `
+	SELECT d.location_id, l.name, count(*) AS devices
+	FROM devices d
+	LEFT JOIN locations l ON l.id = d.location_id
+	WHERE d.deleted_at IS NULL
+	GROUP BY d.location_id, l.name
+	ORDER BY devices DESC, l.name NULLS LAST;`, nil, func(rows *sql.Rows) error {
+				var count entity.LocationCount
+				if err := rows.Scan(&count.LocationID, &count.Name, &count.Devices); err != nil {
+					return err
+				}
+				stats.ByLocation = append(stats.ByLocation, count)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// the state of a device at the end of a day is the one of its last state
+		// event until then, deleted devices have none and are not counted
+		err = queryRows(ctx, tx, `
+	WITH days AS (
+		SELECT generate_series($1::date, $2::date, interval '1 day')::date AS day
+	),
+	last_events AS (
+		SELECT DISTINCT ON (days.day, h.device_id) days.day, h.to_state
+		FROM days
+		JOIN device_history h ON h.event <> 'moved' AND h.created_at < days.day + 1
+		ORDER BY days.day, h.device_id, h.created_at DESC
+	)
+	SELECT day, to_state, count(*)
+	FROM last_events
+	WHERE to_state IS NOT NULL
+	GROUP BY day, to_state
+	ORDER BY day;`, []any{opts.From, opts.To}, func(rows *sql.Rows) error {
+			var day time.Time
+			var state entity.DeviceState
+			var devices int
+			if err := rows.Scan(&day, &state, &devices); err != nil {
+				return err
+			}
+			last := len(stats.Daily) - 1
+			if last < 0 || !stats.Daily[last].Day.Equal(day) {
+				stats.Daily = append(stats.Daily, entity.DailyStateCount{Day: day, ByState: map[entity.DeviceState]int{}})
+				last++
+			}
+			stats.Daily[last].ByState[state] = devices
+			return nil
+		})
+		return err
+	})
+
+	return stats, err
+}
+
+// queryRows runs query in tx and hands each row to scan.
+func queryRows(ctx context.Context, tx *sql.Tx, query string, params []any, scan func(rows *sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query, params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
 
-	return fmt.Sprintf(baseQuery, strings.Join(queryFilters, " ")), params
+	return rows.Err()
 }
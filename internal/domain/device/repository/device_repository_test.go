@@ -10,18 +10,35 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
 )
 
+var (
+	samsungBrandID      = uuid.MustParse("0b9e0d3c-5f41-4c57-9a52-2d2f0e6f1a01")
+	appleBrandID        = uuid.MustParse("6c1f4b8e-2a7d-4e0b-8f3c-9d4a5b6c7e02")
+	sonyEricssonBrandID = uuid.MustParse("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c03")
+)
+
+// deviceRowColumns lists the columns returned for a device, in the order they are scanned.
+var deviceRowColumns = []string{"id", "name", "brand", "brand_id", "model_id", "location_id", "state", "serial_number", "imei", "model_identifier", "os_version", "purchase_date", "purchase_price", "purchase_currency", "supplier", "invoice_reference", "warranty_ends_on", "tags", "attributes", "created_at", "updated_at", "deleted_at"}
+
 func makeExpectedDeviceRecord() entity.Device {
 	return entity.Device{
-		ID:        uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
-		Name:      "Galaxy S23 FE",
-		Brand:     "Samsumg",
-		State:     "available",
-		CreatedAt: lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02")),
+		ID:              uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
+		Name:            "Galaxy S23 FE",
+		Brand:           "Samsumg",
+		BrandID:         samsungBrandID,
+		State:           "available",
+		SerialNumber:    lo.ToPtr("R5CW30ABCDE"),
+		IMEI:            lo.ToPtr("490154203237518"),
+		ModelIdentifier: lo.ToPtr("SM-S711B"),
+		OSVersion:       lo.ToPtr("Android 14"),
+		Tags:            []string{"lab", "qa"},
+		Attributes:      entity.Attributes{"carrier": "vodafone", "cost": 120.5},
+		CreatedAt:       lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02")),
 	}
 }
 
@@ -29,8 +46,9 @@ func Test_Create_Device(t *testing.T) {
 	assert := assert.New(t)
 
 	deviceCreateQuery := regexp.QuoteMeta(`
-	INSERT INTO devices (id, name, brand, state)
-	VALUES ($1, $2, $3, $4)
+	INSERT INTO devices (id, name, brand, state, serial_number, imei, model_identifier, os_version, tags, attributes, brand_id, model_id,
+		purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	RETURNING id, created_at, updated_at, deleted_at;`)
 
 	expectedDevice := makeExpectedDeviceRecord()
@@ -42,10 +60,17 @@ func Test_Create_Device(t *testing.T) {
 	testArgs := args{
 		context: context.TODO(),
 		deviceToBeCreated: entity.Device{
-			ID:    expectedDevice.ID,
-			Name:  expectedDevice.Name,
-			Brand: expectedDevice.Brand,
-			State: expectedDevice.State,
+			ID:              expectedDevice.ID,
+			Name:            expectedDevice.Name,
+			Brand:           expectedDevice.Brand,
+			BrandID:         expectedDevice.BrandID,
+			State:           expectedDevice.State,
+			SerialNumber:    expectedDevice.SerialNumber,
+			IMEI:            expectedDevice.IMEI,
+			ModelIdentifier: expectedDevice.ModelIdentifier,
+			OSVersion:       expectedDevice.OSVersion,
+			Tags:            expectedDevice.Tags,
+			Attributes:      expectedDevice.Attributes,
 		},
 	}
 
@@ -62,7 +87,10 @@ func Test_Create_Device(t *testing.T) {
 				mock.ExpectPrepare(deviceCreateQuery).
 					WillBeClosed().
 					ExpectQuery().
-					WithArgs(expectedDevice.ID, expectedDevice.Name, expectedDevice.Brand, expectedDevice.State.String()).
+					WithArgs(expectedDevice.ID, expectedDevice.Name, expectedDevice.Brand, expectedDevice.State.String(),
+						expectedDevice.SerialNumber, expectedDevice.IMEI, expectedDevice.ModelIdentifier, expectedDevice.OSVersion,
+						pq.Array(expectedDevice.Tags), `{"carrier":"vodafone","cost":120.5}`, expectedDevice.BrandID, expectedDevice.ModelID,
+						expectedDevice.PurchaseDate, expectedDevice.PurchasePrice, expectedDevice.PurchaseCurrency, expectedDevice.Supplier, expectedDevice.InvoiceReference, expectedDevice.WarrantyEndsOn).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at"}).
 						AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"), lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02")), nil, nil))
 			},
@@ -125,7 +153,7 @@ func Test_Get_Device_ByID(t *testing.T) {
 	assert := assert.New(t)
 
 	deviceGetByIdQuery := regexp.QuoteMeta(`
-	SELECT id, name, brand, state, created_at, updated_at, deleted_at
+	SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
 	FROM devices
 	WHERE id = $1 AND deleted_at IS NULL;`)
 
@@ -154,11 +182,26 @@ func Test_Get_Device_ByID(t *testing.T) {
 					WillBeClosed().
 					ExpectQuery().
 					WithArgs(testArgs.deviceID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "brand", "state", "created_at", "updated_at", "deleted_at"}).
+					WillReturnRows(sqlmock.NewRows(deviceRowColumns).
 						AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
 							"Galaxy S23 FE",
 							"Samsumg",
+							samsungBrandID,
+							nil,
+							nil,
 							"available",
+							"R5CW30ABCDE",
+							"490154203237518",
+							"SM-S711B",
+							"Android 14",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							"{lab,qa}",
+							[]byte(`{"carrier": "vodafone", "cost": 120.5}`),
 							lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02")), nil, nil))
 			},
 			args:         testArgs,
@@ -215,6 +258,159 @@ func Test_Get_Device_ByID(t *testing.T) {
 	}
 }
 
+func Test_Get_Device_For_Update(t *testing.T) {
+	assert := assert.New(t)
+
+	deviceForUpdateQuery := regexp.QuoteMeta(`
+	SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`)
+
+	expectedDevice := makeExpectedDeviceRecord()
+
+	db, mock, err := sqlmock.New()
+	assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(deviceForUpdateQuery).
+		WillBeClosed().
+		ExpectQuery().
+		WithArgs(expectedDevice.ID).
+		WillReturnRows(sqlmock.NewRows(deviceRowColumns).
+			AddRow(expectedDevice.ID, "Galaxy S23 FE", "Samsumg", samsungBrandID, nil, nil, "available", "R5CW30ABCDE", "490154203237518", "SM-S711B", "Android 14",
+				nil, nil, nil, nil, nil, nil, "{lab,qa}", []byte(`{"carrier": "vodafone", "cost": 120.5}`),
+				lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02")), nil, nil))
+	mock.ExpectCommit()
+
+	var device entity.Device
+	err = NewDeviceRepository(db).WithinTx(context.TODO(), sql.LevelRepeatableRead, func(repo DeviceRepository) error {
+		device, err = repo.GetDeviceForUpdate(context.TODO(), expectedDevice.ID)
+		return err
+	})
+
+	assert.NoError(err)
+	assert.Equal(expectedDevice, device)
+
+	mock.ExpectClose()
+
+	err = db.Close()
+	assert.NoErrorf(err, "db was not closed")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(err, "there were unfulfilled expectations")
+}
+
+func Test_Get_Device_BySerialNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	deviceGetBySerialNumberQuery := regexp.QuoteMeta(`
+	SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
+	FROM devices
+	WHERE serial_number = $1 AND deleted_at IS NULL;`)
+
+	expectedDevice := makeExpectedDeviceRecord()
+
+	type args struct {
+		context      context.Context
+		serialNumber string
+	}
+	testArgs := args{
+		context:      context.TODO(),
+		serialNumber: "R5CW30ABCDE",
+	}
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		args         args
+		wantedErr    error
+		wantedResult entity.Device
+	}{
+		{
+			name: "Get Device By Serial Number Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceGetBySerialNumberQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(testArgs.serialNumber).
+					WillReturnRows(sqlmock.NewRows(deviceRowColumns).
+						AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
+							"Galaxy S23 FE",
+							"Samsumg",
+							samsungBrandID,
+							nil,
+							nil,
+							"available",
+							"R5CW30ABCDE",
+							"490154203237518",
+							"SM-S711B",
+							"Android 14",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							"{lab,qa}",
+							[]byte(`{"carrier": "vodafone", "cost": 120.5}`),
+							lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02")), nil, nil))
+			},
+			args:         testArgs,
+			wantedErr:    nil,
+			wantedResult: expectedDevice,
+		},
+		{
+			name: "Get Device By Serial Number Not Found",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceGetBySerialNumberQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(testArgs.serialNumber).
+					WillReturnRows(sqlmock.NewRows(deviceRowColumns))
+			},
+			args:         testArgs,
+			wantedErr:    sql.ErrNoRows,
+			wantedResult: entity.Device{},
+		},
+		{
+			name: "Get Device By Serial Number Fails on Prepare Statement",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceGetBySerialNumberQuery).
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			args:         testArgs,
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: entity.Device{},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			deviceRepository := NewDeviceRepository(db)
+
+			tt.sqlMock(mock)
+
+			var device entity.Device
+			device, err = deviceRepository.GetDeviceBySerialNumber(tt.args.context, tt.args.serialNumber)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, device)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
 func Test_Fully_Update_Device(t *testing.T) {
 	assert := assert.New(t)
 	deviceUpdatedAt := lo.Must(time.Parse(time.DateTime, "2025-09-01 19:11:22"))
@@ -224,6 +420,20 @@ func Test_Fully_Update_Device(t *testing.T) {
 		name = $2,
 		brand = $3,
 		state = $4,
+		serial_number = $5,
+		imei = $6,
+		model_identifier = $7,
+		os_version = $8,
+		tags = $9,
+		attributes = $10,
+		brand_id = $11,
+		model_id = $12,
+		purchase_date = $13,
+		purchase_price = $14,
+		purchase_currency = $15,
+		supplier = $16,
+		invoice_reference = $17,
+		warranty_ends_on = $18,
 		updated_at = now()
 	WHERE id = $1 AND deleted_at IS NULL
 	RETURNING id, created_at, updated_at, deleted_at;`)
@@ -255,7 +465,10 @@ func Test_Fully_Update_Device(t *testing.T) {
 				mock.ExpectPrepare(updateDeviceQuery).
 					WillBeClosed().
 					ExpectQuery().
-					WithArgs(deviceToBeUpdated.ID, deviceToBeUpdated.Name, deviceToBeUpdated.Brand, deviceToBeUpdated.State.String()).
+					WithArgs(deviceToBeUpdated.ID, deviceToBeUpdated.Name, deviceToBeUpdated.Brand, deviceToBeUpdated.State.String(),
+						deviceToBeUpdated.SerialNumber, deviceToBeUpdated.IMEI, deviceToBeUpdated.ModelIdentifier, deviceToBeUpdated.OSVersion,
+						pq.Array(deviceToBeUpdated.Tags), `{"carrier":"vodafone","cost":120.5}`, deviceToBeUpdated.BrandID, deviceToBeUpdated.ModelID,
+						deviceToBeUpdated.PurchaseDate, deviceToBeUpdated.PurchasePrice, deviceToBeUpdated.PurchaseCurrency, deviceToBeUpdated.Supplier, deviceToBeUpdated.InvoiceReference, deviceToBeUpdated.WarrantyEndsOn).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at"}).
 						AddRow(updatedDevice.ID, updatedDevice.CreatedAt, deviceUpdatedAt, nil))
 			},
@@ -322,7 +535,7 @@ func Test_Update_Device_State(t *testing.T) {
 		state = $2,
 		updated_at = now()
 	WHERE id = $1 AND deleted_at IS NULL
-	RETURNING id, name, brand, state, created_at, updated_at, deleted_at;`)
+	RETURNING id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at;`)
 
 	updatedDevice := makeExpectedDeviceRecord()
 	updatedDevice.UpdatedAt = lo.ToPtr(deviceUpdatedAt)
@@ -353,11 +566,26 @@ func Test_Update_Device_State(t *testing.T) {
 					WillBeClosed().
 					ExpectQuery().
 					WithArgs(deviceToBeUpdated.ID, newStatus).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "brand", "state", "created_at", "updated_at", "deleted_at"}).
+					WillReturnRows(sqlmock.NewRows(deviceRowColumns).
 						AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
 							"Galaxy S23 FE",
 							"Samsumg",
+							samsungBrandID,
+							nil,
+							nil,
 							newStatus.String(),
+							"R5CW30ABCDE",
+							"490154203237518",
+							"SM-S711B",
+							"Android 14",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							"{lab,qa}",
+							[]byte(`{"carrier": "vodafone", "cost": 120.5}`),
 							lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02")),
 							deviceUpdatedAt,
 							nil))
@@ -419,7 +647,7 @@ func Test_Delete_Device(t *testing.T) {
 	assert := assert.New(t)
 	deletedDeviceID := uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
 
-	deleteDeviceQuery := regexp.QuoteMeta(`UPDATE devices SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL AND state <> 'in-use'`)
+	deleteDeviceQuery := regexp.QuoteMeta(`UPDATE devices SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL AND state NOT IN ('in-use', 'maintenance')`)
 
 	type args struct {
 		context  context.Context
@@ -522,39 +750,74 @@ func Test_List_Devices(t *testing.T) {
 	assert := assert.New(t)
 	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
 
-	deviceListQueryWithouFilter := regexp.QuoteMeta(`SELECT id, name, brand, state, created_at, updated_at, deleted_at
+	deviceListQueryWithouFilter := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
 	FROM devices
-	WHERE deleted_at IS NULL
+	WHERE deleted_at IS NULL AND state <> 'retired'
+	ORDER BY name;`)
+
+	deviceListQueryFilterBrand := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
+	FROM devices
+	WHERE deleted_at IS NULL AND lower(brand) = lower($1) AND state <> 'retired'
+	ORDER BY name;`)
+
+	deviceListQueryFilterBrandID := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
+	FROM devices
+	WHERE deleted_at IS NULL AND brand_id = $1 AND state <> 'retired'
 	ORDER BY name;`)
 
-	deviceListQueryFilterBrand := regexp.QuoteMeta(`SELECT id, name, brand, state, created_at, updated_at, deleted_at
+	deviceListQueryFilterModels := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
 	FROM devices
-	WHERE deleted_at IS NULL AND lower(brand) = $1
+	WHERE deleted_at IS NULL AND model_id = ANY($1::uuid[]) AND state <> 'retired'
 	ORDER BY name;`)
 
-	deviceListQueryFilterBrandAndState := regexp.QuoteMeta(`SELECT id, name, brand, state, created_at, updated_at, deleted_at
+	deviceListQueryFilterLocation := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
 	FROM devices
-	WHERE deleted_at IS NULL AND lower(brand) = $1 AND state = $2
+	WHERE deleted_at IS NULL AND location_id IN (
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM locations WHERE id = $1
+			UNION ALL
+			SELECT child.id FROM locations child JOIN subtree s ON child.parent_id = s.id
+		)
+		SELECT id FROM subtree) AND state <> 'retired'
 	ORDER BY name;`)
 
-	deviceListQueryFilterState := regexp.QuoteMeta(`SELECT id, name, brand, state, created_at, updated_at, deleted_at
+	googleBrandID := uuid.MustParse("1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704")
+	pixel7ModelID := uuid.MustParse("d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05")
+	siteLocationID := uuid.MustParse("5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401")
+	shelfLocationID := uuid.MustParse("7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603")
+
+	deviceListQueryFilterBrandAndState := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
+	FROM devices
+	WHERE deleted_at IS NULL AND lower(brand) = lower($1) AND state = ANY($2::device_state[])
+	ORDER BY name;`)
+
+	deviceListQueryFilterState := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
 	FROM devices
-	WHERE deleted_at IS NULL AND state = $1
+	WHERE deleted_at IS NULL AND state = ANY($1::device_state[])
 	ORDER BY name;`)
 
-	brandParam := "apple"
-	stateParam := "in-use"
+	deviceListQueryAllFiltersSorted := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
+	FROM devices
+	WHERE deleted_at IS NULL AND state = ANY($1::device_state[]) AND name ILIKE $2 AND created_at > $3 AND created_at < $4 AND updated_at >= $5 AND tags @> $6::text[] AND attributes @> $7::jsonb
+	ORDER BY created_at DESC, name;`)
+
+	deviceListQueryPaged := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at
+	FROM devices
+	WHERE deleted_at IS NULL AND lower(brand) = lower($1) AND state <> 'retired'
+	ORDER BY name, id LIMIT $2 OFFSET $3;`)
+
+	brandParam := "Apple"
+	stateParam := pq.Array([]string{"in-use"})
+	createdAfter := lo.Must(time.Parse(time.DateOnly, "2025-08-01"))
+	createdBefore := lo.Must(time.Parse(time.DateOnly, "2025-09-01"))
 
 	type args struct {
 		context context.Context
-		params  map[string]any
+		opts    entity.ListOptions
 	}
 	testArgs := args{
 		context: context.TODO(),
-		params: map[string]any{
-			"brand": nil,
-			"state": nil,
-		},
+		opts:    entity.ListOptions{},
 	}
 
 	testCases := []struct {
@@ -573,9 +836,9 @@ func Test_List_Devices(t *testing.T) {
 					WithArgs().
 					WillReturnRows(
 						sqlmock.
-							NewRows([]string{"id", "name", "brand", "state", "created_at", "updated_at", "deleted_at"}).
-							AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"), "Galaxy S23 FE", "Samsumg", entity.Available, createdAt, nil, nil).
-							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", entity.InUse, createdAt, createdAt, nil))
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"), "Galaxy S23 FE", "Samsumg", samsungBrandID, nil, nil, entity.Available, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, nil, nil).
+							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", appleBrandID, nil, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil))
 			},
 			args:      testArgs,
 			wantedErr: nil,
@@ -584,6 +847,7 @@ func Test_List_Devices(t *testing.T) {
 					ID:        uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
 					Name:      "Galaxy S23 FE",
 					Brand:     "Samsumg",
+					BrandID:   samsungBrandID,
 					State:     entity.Available,
 					CreatedAt: createdAt,
 				},
@@ -591,6 +855,7 @@ func Test_List_Devices(t *testing.T) {
 					ID:        uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"),
 					Name:      "IPhone 15",
 					Brand:     "Apple",
+					BrandID:   appleBrandID,
 					State:     entity.InUse,
 					CreatedAt: createdAt,
 					UpdatedAt: &createdAt,
@@ -606,14 +871,13 @@ func Test_List_Devices(t *testing.T) {
 					WithArgs(brandParam).
 					WillReturnRows(
 						sqlmock.
-							NewRows([]string{"id", "name", "brand", "state", "created_at", "updated_at", "deleted_at"}).
-							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", entity.InUse, createdAt, createdAt, nil))
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", appleBrandID, nil, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil))
 			},
 			args: args{
 				context: context.TODO(),
-				params: map[string]any{
-					"brand": "apple",
-					"state": nil,
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{Brand: lo.ToPtr("Apple")},
 				},
 			},
 			wantedErr: nil,
@@ -622,6 +886,102 @@ func Test_List_Devices(t *testing.T) {
 					ID:        uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"),
 					Name:      "IPhone 15",
 					Brand:     "Apple",
+					BrandID:   appleBrandID,
+					State:     entity.InUse,
+					CreatedAt: createdAt,
+					UpdatedAt: &createdAt,
+				},
+			},
+		},
+		{
+			name: "List Devices filtering Brand ID Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceListQueryFilterBrandID).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(appleBrandID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", appleBrandID, nil, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil))
+			},
+			args: args{
+				context: context.TODO(),
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{BrandID: lo.ToPtr(appleBrandID)},
+				},
+			},
+			wantedErr: nil,
+			wantedResult: []entity.Device{
+				{
+					ID:        uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"),
+					Name:      "IPhone 15",
+					Brand:     "Apple",
+					BrandID:   appleBrandID,
+					State:     entity.InUse,
+					CreatedAt: createdAt,
+					UpdatedAt: &createdAt,
+				},
+			},
+		},
+		{
+			name: "List Devices filtering Location Subtree Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceListQueryFilterLocation).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(siteLocationID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", appleBrandID, nil, shelfLocationID, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil))
+			},
+			args: args{
+				context: context.TODO(),
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{LocationID: &siteLocationID},
+				},
+			},
+			wantedErr: nil,
+			wantedResult: []entity.Device{
+				{
+					ID:         uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"),
+					Name:       "IPhone 15",
+					Brand:      "Apple",
+					BrandID:    appleBrandID,
+					LocationID: &shelfLocationID,
+					State:      entity.InUse,
+					CreatedAt:  createdAt,
+					UpdatedAt:  &createdAt,
+				},
+			},
+		},
+		{
+			name: "List Devices filtering Models Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceListQueryFilterModels).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(pq.Array([]string{pixel7ModelID.String()})).
+					WillReturnRows(
+						sqlmock.
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "Pixel 7 QA", "Google", googleBrandID, pixel7ModelID, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil))
+			},
+			args: args{
+				context: context.TODO(),
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{ModelIDs: []uuid.UUID{pixel7ModelID}},
+				},
+			},
+			wantedErr: nil,
+			wantedResult: []entity.Device{
+				{
+					ID:        uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"),
+					Name:      "Pixel 7 QA",
+					Brand:     "Google",
+					BrandID:   googleBrandID,
+					ModelID:   &pixel7ModelID,
 					State:     entity.InUse,
 					CreatedAt: createdAt,
 					UpdatedAt: &createdAt,
@@ -637,14 +997,13 @@ func Test_List_Devices(t *testing.T) {
 					WithArgs(stateParam).
 					WillReturnRows(
 						sqlmock.
-							NewRows([]string{"id", "name", "brand", "state", "created_at", "updated_at", "deleted_at"}).
-							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", entity.InUse, createdAt, createdAt, nil))
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", appleBrandID, nil, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil))
 			},
 			args: args{
 				context: context.TODO(),
-				params: map[string]any{
-					"brand": nil,
-					"state": "in-use",
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{States: []entity.DeviceState{entity.InUse}},
 				},
 			},
 			wantedErr: nil,
@@ -653,6 +1012,7 @@ func Test_List_Devices(t *testing.T) {
 					ID:        uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"),
 					Name:      "IPhone 15",
 					Brand:     "Apple",
+					BrandID:   appleBrandID,
 					State:     entity.InUse,
 					CreatedAt: createdAt,
 					UpdatedAt: &createdAt,
@@ -668,14 +1028,16 @@ func Test_List_Devices(t *testing.T) {
 					WithArgs(brandParam, stateParam).
 					WillReturnRows(
 						sqlmock.
-							NewRows([]string{"id", "name", "brand", "state", "created_at", "updated_at", "deleted_at"}).
-							AddRow(uuid.MustParse("a60dceb7-60c8-4d74-8d7c-cd34a0b4ce11"), "IPhone 16", "Apple", entity.InUse, createdAt, createdAt, nil))
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("a60dceb7-60c8-4d74-8d7c-cd34a0b4ce11"), "IPhone 16", "Apple", appleBrandID, nil, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil))
 			},
 			args: args{
 				context: context.TODO(),
-				params: map[string]any{
-					"brand": "apple",
-					"state": "in-use",
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{
+						Brand:  lo.ToPtr("Apple"),
+						States: []entity.DeviceState{entity.InUse},
+					},
 				},
 			},
 			wantedErr: nil,
@@ -684,12 +1046,70 @@ func Test_List_Devices(t *testing.T) {
 					ID:        uuid.MustParse("a60dceb7-60c8-4d74-8d7c-cd34a0b4ce11"),
 					Name:      "IPhone 16",
 					Brand:     "Apple",
+					BrandID:   appleBrandID,
 					State:     entity.InUse,
 					CreatedAt: createdAt,
 					UpdatedAt: &createdAt,
 				},
 			},
 		},
+		{
+			name: "List Devices with every filter and sorted Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceListQueryAllFiltersSorted).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(pq.Array([]string{"available", "in-use"}), `%100\%\_s%`, createdAfter, createdBefore, createdAfter, pq.Array([]string{"qa"}), `{"carrier":"vodafone"}`).
+					WillReturnRows(
+						sqlmock.
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("a60dceb7-60c8-4d74-8d7c-cd34a0b4ce11"), "100%_s", "Apple", appleBrandID, nil, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "{qa}", []byte(`{"carrier": "vodafone"}`), createdAt, createdAt, nil))
+			},
+			args: args{
+				context: context.TODO(),
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{
+						States:        []entity.DeviceState{entity.Available, entity.InUse},
+						NameContains:  lo.ToPtr("100%_s"),
+						CreatedAfter:  &createdAfter,
+						CreatedBefore: &createdBefore,
+						UpdatedSince:  &createdAfter,
+						Tags:          []string{"qa"},
+						Attributes:    entity.Attributes{"carrier": "vodafone"},
+					},
+					Sort: []entity.SortKey{
+						{Field: entity.SortByCreatedAt, Descending: true},
+						{Field: entity.SortByName},
+					},
+				},
+			},
+			wantedErr: nil,
+			wantedResult: []entity.Device{
+				{
+					ID:         uuid.MustParse("a60dceb7-60c8-4d74-8d7c-cd34a0b4ce11"),
+					Name:       "100%_s",
+					Brand:      "Apple",
+					BrandID:    appleBrandID,
+					State:      entity.InUse,
+					Tags:       []string{"qa"},
+					Attributes: entity.Attributes{"carrier": "vodafone"},
+					CreatedAt:  createdAt,
+					UpdatedAt:  &createdAt,
+				},
+			},
+		},
+		{
+			name:    "List Devices Fails on unsupported sort field",
+			sqlMock: func(mock sqlmock.Sqlmock) {},
+			args: args{
+				context: context.TODO(),
+				opts: entity.ListOptions{
+					Sort: []entity.SortKey{{Field: entity.SortField("id; DROP TABLE devices")}},
+				},
+			},
+			wantedErr:    fmt.Errorf("unsupported sort field %q", "id; DROP TABLE devices"),
+			wantedResult: nil,
+		},
 		{
 			name: "List Devices  Fails on Prepare Statement",
 			sqlMock: func(mock sqlmock.Sqlmock) {
@@ -713,6 +1133,38 @@ func Test_List_Devices(t *testing.T) {
 			wantedErr:    fmt.Errorf("some database error"),
 			wantedResult: nil,
 		},
+		{
+			name: "List Devices Paged Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceListQueryPaged).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(brandParam, 2, 4).
+					WillReturnRows(
+						sqlmock.
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", appleBrandID, nil, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, nil, nil))
+			},
+			args: args{
+				context: context.TODO(),
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{Brand: &brandParam},
+					Limit:  2,
+					Offset: 4,
+				},
+			},
+			wantedErr: nil,
+			wantedResult: []entity.Device{
+				{
+					ID:        uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"),
+					Name:      "IPhone 15",
+					Brand:     "Apple",
+					BrandID:   appleBrandID,
+					State:     entity.InUse,
+					CreatedAt: createdAt,
+				},
+			},
+		},
 		{
 			name: "List Devices Fails on Row Scan",
 			sqlMock: func(mock sqlmock.Sqlmock) {
@@ -726,7 +1178,7 @@ func Test_List_Devices(t *testing.T) {
 							AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")))
 			},
 			args:         testArgs,
-			wantedErr:    fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", 1, 7),
+			wantedErr:    fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", 1, 22),
 			wantedResult: nil,
 		},
 		{
@@ -738,9 +1190,9 @@ func Test_List_Devices(t *testing.T) {
 					WithArgs().
 					WillReturnRows(
 						sqlmock.
-							NewRows([]string{"id", "name", "brand", "state", "created_at", "updated_at", "deleted_at"}).
-							AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"), "Galaxy S23 FE", "Samsumg", entity.Available, createdAt, nil, nil).
-							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", entity.InUse, createdAt, createdAt, nil).
+							NewRows(deviceRowColumns).
+							AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"), "Galaxy S23 FE", "Samsumg", samsungBrandID, nil, nil, entity.Available, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, nil, nil).
+							AddRow(uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19"), "IPhone 15", "Apple", appleBrandID, nil, nil, entity.InUse, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil).
 							RowError(1, fmt.Errorf("some error")))
 			},
 			args:         testArgs,
@@ -758,7 +1210,7 @@ func Test_List_Devices(t *testing.T) {
 
 			tt.sqlMock(mock)
 
-			devices, err := deviceRepository.ListDevices(tt.args.context, tt.args.params)
+			devices, err := deviceRepository.ListDevices(tt.args.context, tt.args.opts)
 
 			assert.Equal(tt.wantedErr, err)
 			assert.Equal(tt.wantedResult, devices)
@@ -773,3 +1225,484 @@ func Test_List_Devices(t *testing.T) {
 		})
 	}
 }
+
+func Test_Search_Devices(t *testing.T) {
+	assert := assert.New(t)
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	searchColumns := append(append([]string{}, deviceRowColumns...), "rank", "name_highlight", "brand_highlight")
+
+	deviceSearchQuery := regexp.QuoteMeta(`SELECT id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at,
+		ts_rank(search_vector, query) + greatest(similarity(name, $2), similarity(brand, $2)) AS rank,
+		ts_headline('simple', name, query, '` + "StartSel=\uE000, StopSel=\uE001, HighlightAll=true" + `') AS name_highlight,
+		ts_headline('simple', brand, query, '` + "StartSel=\uE000, StopSel=\uE001, HighlightAll=true" + `') AS brand_highlight
+	FROM devices, to_tsquery('simple', $1) query
+	WHERE deleted_at IS NULL AND (search_vector @@ query OR name % $2 OR brand % $2) AND state <> 'retired'
+	ORDER BY rank DESC, name;`)
+
+	deviceSearchQueryFilterStateSorted := regexp.QuoteMeta(`FROM devices, to_tsquery('simple', $1) query
+	WHERE deleted_at IS NULL AND (search_vector @@ query OR name % $2 OR brand % $2) AND state = ANY($3::device_state[])
+	ORDER BY lower(brand), updated_at DESC;`)
+
+	type args struct {
+		context context.Context
+		term    string
+		opts    entity.ListOptions
+	}
+	testArgs := args{
+		context: context.TODO(),
+		term:    "Sony Eric",
+		opts:    entity.ListOptions{},
+	}
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		args         args
+		wantedErr    error
+		wantedResult []entity.DeviceSearchResult
+	}{
+		{
+			name: "Search Devices Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceSearchQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs("sony:* & eric:*", "sony eric").
+					WillReturnRows(
+						sqlmock.
+							NewRows(searchColumns).
+							AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"), "Xperia X10", "Sony Ericsson", sonyEricssonBrandID, nil, nil, entity.Available, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, nil, nil, 0.75, "Xperia X10", "\uE000Sony\uE001 \uE000Ericsson\uE001"))
+			},
+			args:      testArgs,
+			wantedErr: nil,
+			wantedResult: []entity.DeviceSearchResult{
+				{
+					Device: entity.Device{
+						ID:        uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
+						Name:      "Xperia X10",
+						Brand:     "Sony Ericsson",
+						BrandID:   sonyEricssonBrandID,
+						State:     entity.Available,
+						CreatedAt: createdAt,
+					},
+					Rank:           0.75,
+					NameHighlight:  "Xperia X10",
+					BrandHighlight: "<mark>Sony</mark> <mark>Ericsson</mark>",
+				},
+			},
+		},
+		{
+			name: "Search Devices Escaping Highlights Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceSearchQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs("sony:* & eric:*", "sony eric").
+					WillReturnRows(
+						sqlmock.
+							NewRows(searchColumns).
+							AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"), `<img src=x onerror="alert(1)">`, "Sony Ericsson", sonyEricssonBrandID, nil, nil, entity.Available, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, nil, nil, 0.75,
+								`<img src=x onerror="alert(1)">`, "\uE000Sony\uE001 & <mark>\uE000Ericsson\uE001</mark>"))
+			},
+			args:      testArgs,
+			wantedErr: nil,
+			wantedResult: []entity.DeviceSearchResult{
+				{
+					Device: entity.Device{
+						ID:        uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"),
+						Name:      `<img src=x onerror="alert(1)">`,
+						Brand:     "Sony Ericsson",
+						BrandID:   sonyEricssonBrandID,
+						State:     entity.Available,
+						CreatedAt: createdAt,
+					},
+					Rank:           0.75,
+					NameHighlight:  "&lt;img src=x onerror=&#34;alert(1)&#34;&gt;",
+					BrandHighlight: "<mark>Sony</mark> &amp; &lt;mark&gt;<mark>Ericsson</mark>&lt;/mark&gt;",
+				},
+			},
+		},
+		{
+			name: "Search Devices filtering State and sorted Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceSearchQueryFilterStateSorted).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs("htc:* & one:*", "htc-one", pq.Array([]string{"in-use"})).
+					WillReturnRows(sqlmock.NewRows(searchColumns))
+			},
+			args: args{
+				context: context.TODO(),
+				term:    "HTC-One",
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{States: []entity.DeviceState{entity.InUse}},
+					Sort: []entity.SortKey{
+						{Field: entity.SortByBrand},
+						{Field: entity.SortByUpdatedAt, Descending: true},
+					},
+				},
+			},
+			wantedErr:    nil,
+			wantedResult: nil,
+		},
+		{
+			name: "Search Devices Fails on Prepare Statement",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceSearchQuery).
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			args:         testArgs,
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: nil,
+		},
+		{
+			name: "Search Devices Fails when query",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceSearchQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			args:         testArgs,
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: nil,
+		},
+		{
+			name: "Search Devices Fails on Row Scan",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deviceSearchQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WillReturnRows(
+						sqlmock.
+							NewRows([]string{"id"}).
+							AddRow(uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")))
+			},
+			args:         testArgs,
+			wantedErr:    fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", 1, 25),
+			wantedResult: nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			deviceRepository := NewDeviceRepository(db)
+
+			tt.sqlMock(mock)
+
+			results, err := deviceRepository.SearchDevices(tt.args.context, tt.args.term, tt.args.opts)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, results)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_To_Prefix_TsQuery(t *testing.T) {
+	testCases := []struct {
+		term   string
+		wanted string
+	}{
+		{term: "iphone", wanted: "iphone:*"},
+		{term: "Sony Ericsson", wanted: "sony:* & ericsson:*"},
+		{term: "  HTC-One ", wanted: "htc:* & one:*"},
+		{term: "it's & (pixel | 7):*", wanted: "it:* & s:* & pixel:* & 7:*"},
+		{term: "--", wanted: ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.term, func(t *testing.T) {
+			assert.Equal(t, tt.wanted, toPrefixTsQuery(tt.term))
+		})
+	}
+}
+
+func Test_Move_Device(t *testing.T) {
+	assert := assert.New(t)
+
+	deviceID := uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+	fromLocationID := uuid.MustParse("5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401")
+	toLocationID := uuid.MustParse("6e2f3a40-5b6c-4d7e-9f80-a1b2c3d4e502")
+	createdAt := lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	lockQuery := regexp.QuoteMeta(`
+	SELECT location_id
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`)
+
+	moveQuery := regexp.QuoteMeta(`
+	UPDATE devices SET
+		location_id = $2,
+		updated_at = now()
+	WHERE id = $1
+	RETURNING id, name, brand, brand_id, model_id, location_id, state, serial_number, imei, model_identifier, os_version, purchase_date, purchase_price, purchase_currency, supplier, invoice_reference, warranty_ends_on, tags, attributes, created_at, updated_at, deleted_at;`)
+
+	historyQuery := regexp.QuoteMeta(`
+	INSERT INTO device_history (id, device_id, event, from_location_id, to_location_id, note)
+	VALUES ($1, $2, $3, $4, $5, $6);`)
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult entity.Device
+	}{
+		{
+			name: "Move Device Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"location_id"}).AddRow(fromLocationID))
+				mock.ExpectQuery(moveQuery).
+					WithArgs(deviceID, toLocationID).
+					WillReturnRows(sqlmock.NewRows(deviceRowColumns).
+						AddRow(deviceID, "Galaxy S23 FE", "Samsumg", samsungBrandID, nil, toLocationID, entity.Available, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, createdAt, createdAt, nil))
+				mock.ExpectExec(historyQuery).
+					WithArgs(sqlmock.AnyArg(), deviceID, "moved", &fromLocationID, toLocationID, lo.ToPtr("back to the lab")).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantedResult: entity.Device{
+				ID:         deviceID,
+				Name:       "Galaxy S23 FE",
+				Brand:      "Samsumg",
+				BrandID:    samsungBrandID,
+				LocationID: &toLocationID,
+				State:      entity.Available,
+				CreatedAt:  createdAt,
+				UpdatedAt:  &createdAt,
+			},
+		},
+		{
+			name: "Move Device Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"location_id"}))
+				mock.ExpectRollback()
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+		{
+			name: "Move Device Unknown Location Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"location_id"}).AddRow(nil))
+				mock.ExpectQuery(moveQuery).
+					WithArgs(deviceID, toLocationID).
+					WillReturnError(&pq.Error{Code: "23503", Constraint: "devices_location_id_fkey"})
+				mock.ExpectRollback()
+			},
+			wantedErr: &pq.Error{Code: "23503", Constraint: "devices_location_id_fkey"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewDeviceRepository(db)
+
+			tt.sqlMock(mock)
+
+			device, err := repository.MoveDevice(context.TODO(), deviceID, toLocationID, lo.ToPtr("back to the lab"))
+
+			assert.Equal(tt.wantedErr, err)
+			if tt.wantedErr == nil {
+				assert.Equal(tt.wantedResult, device)
+			}
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Has_Upcoming_Reservations(t *testing.T) {
+	assert := assert.New(t)
+
+	deviceID := uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+
+	query := regexp.QuoteMeta(`
+	SELECT EXISTS (
+		SELECT 1
+		FROM reservations
+		WHERE device_id = $1 AND status <> 'cancelled' AND upper(period) > now()
+	);`)
+
+	db, mock, err := sqlmock.New()
+	assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+	mock.ExpectPrepare(query).
+		WillBeClosed().
+		ExpectQuery().
+		WithArgs(deviceID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	repository := NewDeviceRepository(db)
+	upcoming, err := repository.HasUpcomingReservations(context.TODO(), deviceID)
+
+	assert.NoError(err)
+	assert.True(upcoming)
+
+	mock.ExpectClose()
+
+	err = db.Close()
+	assert.NoErrorf(err, "db was not closed")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(err, "there were unfulfilled expectations")
+}
+
+func Test_Get_Device_Stats(t *testing.T) {
+	assert := assert.New(t)
+
+	shelfID := uuid.MustParse("7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603")
+	from := lo.Must(time.Parse(time.DateOnly, "2025-09-01"))
+	to := lo.Must(time.Parse(time.DateOnly, "2025-09-02"))
+
+	stateQuery := regexp.QuoteMeta(`
+	SELECT state, count(*)
+	FROM devices
+	WHERE deleted_at IS NULL
+	GROUP BY state;`)
+
+	brandQuery := regexp.QuoteMeta(`
+	SELECT brand_id, brand, count(*) AS devices
+	FROM devices
+	WHERE deleted_at IS NULL
+	GROUP BY brand_id, brand
+	ORDER BY devices DESC, brand;`)
+
+	locationQuery := regexp.QuoteMeta(`
+	SELECT d.location_id, l.name, count(*) AS devices
+	FROM devices d
+	LEFT JOIN locations l ON l.id = d.location_id
+	WHERE d.deleted_at IS NULL
+	GROUP BY d.location_id, l.name
+	ORDER BY devices DESC, l.name NULLS LAST;`)
+
+	dailyQuery := regexp.QuoteMeta(`
+	WITH days AS (
+		SELECT generate_series($1::date, $2::date, interval '1 day')::date AS day
+	),
+	last_events AS (
+		SELECT DISTINCT ON (days.day, h.device_id) days.day, h.to_state
+		FROM days
+		JOIN device_history h ON h.event <> 'moved' AND h.created_at < days.day + 1
+		ORDER BY days.day, h.device_id, h.created_at DESC
+	)
+	SELECT day, to_state, count(*)
+	FROM last_events
+	WHERE to_state IS NOT NULL
+	GROUP BY day, to_state
+	ORDER BY day;`)
+
+	testCases := []struct {
+		name         string
+		byLocation   bool
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult entity.DeviceStats
+	}{
+		{
+			name:       "Get Device Stats By Location Case",
+			byLocation: true,
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(stateQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"state", "count"}).
+						AddRow("available", 2).
+						AddRow("in-use", 1))
+				mock.ExpectQuery(brandQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"brand_id", "brand", "devices"}).
+						AddRow(samsungBrandID, "Samsung", 2).
+						AddRow(appleBrandID, "Apple", 1))
+				mock.ExpectQuery(locationQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"location_id", "name", "devices"}).
+						AddRow(shelfID, "Shelf A", 2).
+						AddRow(nil, nil, 1))
+				mock.ExpectQuery(dailyQuery).
+					WithArgs(from, to).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "to_state", "count"}).
+						AddRow(from, "available", 3).
+						AddRow(to, "available", 2).
+						AddRow(to, "in-use", 1))
+				mock.ExpectCommit()
+			},
+			wantedResult: entity.DeviceStats{
+				Total:   3,
+				ByState: map[entity.DeviceState]int{entity.Available: 2, entity.InUse: 1},
+				ByBrand: []entity.BrandCount{
+					{BrandID: samsungBrandID, Brand: "Samsung", Devices: 2},
+					{BrandID: appleBrandID, Brand: "Apple", Devices: 1},
+				},
+				ByLocation: []entity.LocationCount{
+					{LocationID: &shelfID, Name: lo.ToPtr("Shelf A"), Devices: 2},
+					{Devices: 1},
+				},
+				Daily: []entity.DailyStateCount{
+					{Day: from, ByState: map[entity.DeviceState]int{entity.Available: 3}},
+					{Day: to, ByState: map[entity.DeviceState]int{entity.Available: 2, entity.InUse: 1}},
+				},
+			},
+		},
+		{
+			name: "Get Device Stats Query Error Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(stateQuery).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantedErr:    sql.ErrConnDone,
+			wantedResult: entity.DeviceStats{ByState: map[entity.DeviceState]int{}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			tt.sqlMock(mock)
+
+			repository := NewDeviceRepository(db)
+			stats, err := repository.GetDeviceStats(context.TODO(), entity.StatsOptions{ByLocation: tt.byLocation, From: from, To: to})
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, stats)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
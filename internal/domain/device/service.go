@@ -5,39 +5,99 @@ import (
 	"database/sql"
 	goerrors "errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/tenant"
 )
 
+const maxMoveNoteLength = 500
+
+// maxStatsDays bounds the daily series of the inventory stats.
+const maxStatsDays = 366
+
 type DeviceService interface {
-	List(ctx context.Context, params map[string]any) ([]entity.Device, error)
+	List(ctx context.Context, opts entity.ListOptions) ([]entity.Device, error)
+	Search(ctx context.Context, term string, opts entity.ListOptions) ([]entity.DeviceSearchResult, error)
 	GetByID(ctx context.Context, id uuid.UUID) (entity.Device, error)
+	GetBySerialNumber(ctx context.Context, serialNumber string) (entity.Device, error)
 	Create(ctx context.Context, device entity.Device) (entity.Device, error)
 	Update(ctx context.Context, device entity.Device) (entity.Device, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	Move(ctx context.Context, id uuid.UUID, locationID uuid.UUID, note *string) (entity.Device, error)
+	History(ctx context.Context, id uuid.UUID) ([]entity.HistoryEvent, error)
+	Stats(ctx context.Context, opts entity.StatsOptions) (entity.DeviceStats, error)
 }
 
 type deviceService struct {
-	repo repository.DeviceRepository
+	repo                 repository.DeviceRepository
+	attributeDefinitions repository.AttributeDefinitionRepository
+	brands               BrandResolver
+	models               ModelCatalogue
 }
 
-func NewDeviceService(repo repository.DeviceRepository) *deviceService {
-	return &deviceService{repo: repo}
+func NewDeviceService(repo repository.DeviceRepository, attributeDefinitions repository.AttributeDefinitionRepository, brands BrandResolver, models ModelCatalogue) *deviceService {
+	return &deviceService{repo: repo, attributeDefinitions: attributeDefinitions, brands: brands, models: models}
 }
 
-func (s *deviceService) List(ctx context.Context, params map[string]any) ([]entity.Device, error) {
-	devices, err := s.repo.ListDevices(ctx, params)
+func (s *deviceService) List(ctx context.Context, opts entity.ListOptions) ([]entity.Device, error) {
+	if err := s.prepareFilter(ctx, &opts.Filter); err != nil {
+		return nil, err
+	}
+
+	devices, err := s.repo.ListDevices(ctx, opts)
 	if err != nil {
 		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing devices", err)
 	}
 	return devices, nil
 }
 
+func (s *deviceService) Search(ctx context.Context, term string, opts entity.ListOptions) ([]entity.DeviceSearchResult, error) {
+	if strings.TrimSpace(term) == "" {
+		return nil, errors.NewDeviceError(errors.ErrInvalid, "search term must not be empty", nil)
+	}
+
+	if err := s.prepareFilter(ctx, &opts.Filter); err != nil {
+		return nil, err
+	}
+
+	results, err := s.repo.SearchDevices(ctx, term, opts)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while searching devices", err)
+	}
+	return results, nil
+}
+
 func (s *deviceService) GetByID(ctx context.Context, id uuid.UUID) (entity.Device, error) {
-	device, err := s.repo.GetDeviceByID(ctx, id)
+	return getByID(ctx, s.repo, id)
+}
+
+// getByID returns the device read by repo, mapping the errors as GetByID does.
+func getByID(ctx context.Context, repo repository.DeviceRepository, id uuid.UUID) (entity.Device, error) {
+	device, err := repo.GetDeviceByID(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Device{}, errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+		}
+
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving device", err)
+	}
+	return device, nil
+}
+
+func (s *deviceService) GetBySerialNumber(ctx context.Context, serialNumber string) (entity.Device, error) {
+	serialNumber = entity.NormalizeIdentifier(serialNumber)
+	if serialNumber == "" {
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInvalid, "serial number must not be empty", nil)
+	}
+
+	device, err := s.repo.GetDeviceBySerialNumber(ctx, serialNumber)
 	if err != nil {
 		if goerrors.Is(err, sql.ErrNoRows) {
 			return entity.Device{}, errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
@@ -50,19 +110,77 @@ func (s *deviceService) GetByID(ctx context.Context, id uuid.UUID) (entity.Devic
 
 func (s *deviceService) Create(ctx context.Context, device entity.Device) (entity.Device, error) {
 	device.ID = uuid.New()
+	if err := normalizeAndValidateIdentifiers(&device); err != nil {
+		return device, err
+	}
+
+	if err := normalizeAndValidateProcurement(&device); err != nil {
+		return device, err
+	}
+
+	if err := s.normalizeAndValidateCustomFields(ctx, &device); err != nil {
+		return device, err
+	}
+
+	if err := s.resolveBrandAndModel(ctx, &device); err != nil {
+		return device, err
+	}
+
 	err := s.repo.CreateDevice(ctx, &device)
 	if err != nil {
+		if conflictErr := uniqueViolationError(err); conflictErr != nil {
+			return device, conflictErr
+		}
 		return device, errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating device", err)
 	}
 	return device, nil
 }
 
+// Update reads the device, locking it, and writes it in a single transaction,
+// run again when the device is changed concurrently, so the checks made on the
+// device read still hold once it is written.
 func (s *deviceService) Update(ctx context.Context, device entity.Device) (entity.Device, error) {
-	//TODO: Refactor this method to avoid possible race conditions when 2 requests to update a same device are made simultaneously
+	if err := normalizeAndValidateIdentifiers(&device); err != nil {
+		return entity.Device{}, err
+	}
+
+	if err := normalizeAndValidateProcurement(&device); err != nil {
+		return entity.Device{}, err
+	}
+
+	var updated entity.Device
+	err := s.repo.WithinTx(ctx, sql.LevelRepeatableRead, func(repo repository.DeviceRepository) error {
+		var err error
+		updated, err = s.update(ctx, repo, device)
+		return err
+	})
+	if err != nil {
+		return entity.Device{}, txError(err, "something went wrong while updating device")
+	}
+	return updated, nil
+}
 
-	baseDevice, err := s.repo.GetDeviceByID(ctx, device.ID)
+func (s *deviceService) update(ctx context.Context, repo repository.DeviceRepository, device entity.Device) (entity.Device, error) {
+	baseDevice, err := repo.GetDeviceForUpdate(ctx, device.ID)
 	if err != nil {
-		return entity.Device{}, errors.NewDeviceError(errors.ErrNotFound, "something went wrong while retrieving device", err)
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Device{}, errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+		}
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving device", err)
+	}
+
+	if baseDevice.State == entity.Maintenance {
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInvalid, "device is under maintenance and cannot be updated, close its maintenance record first", nil)
+	}
+
+	if baseDevice.State == entity.Retired {
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInvalid, "device is retired and cannot be updated", nil)
+	}
+
+	if device.State == entity.Inactive && baseDevice.State != entity.Inactive {
+		if err := rejectUpcomingReservations(ctx, repo, device.ID, "made inactive"); err != nil {
+			return entity.Device{}, err
+		}
 	}
 
 	//If device is in use, only status can be updated
@@ -72,25 +190,461 @@ func (s *deviceService) Update(ctx context.Context, device entity.Device) (entit
 		}
 
 		//Update only status
-		device, err = s.repo.UpdateDeviceState(ctx, device.ID, device.State)
+		device, err = repo.UpdateDeviceState(ctx, device.ID, device.State)
 		if err != nil {
-			return entity.Device{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while update device state", fmt.Errorf("error updating device state: %v", err))
+			return entity.Device{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while update device state", fmt.Errorf("error updating device state: %w", err))
 		}
 		return device, nil
 	}
 
-	//Fully update
-	err = s.repo.FullyUpdateDevice(ctx, &device)
+	//Fully update, tags and attributes are only checked here as an in-use device keeps them
+	if err := s.normalizeAndValidateCustomFields(ctx, &device); err != nil {
+		return entity.Device{}, err
+	}
+
+	if err := s.resolveBrandAndModel(ctx, &device); err != nil {
+		return entity.Device{}, err
+	}
+
+	// the location only changes by moving the device
+	device.LocationID = baseDevice.LocationID
+
+	err = repo.FullyUpdateDevice(ctx, &device)
 	if err != nil {
+		if conflictErr := uniqueViolationError(err); conflictErr != nil {
+			return entity.Device{}, conflictErr
+		}
 		return entity.Device{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while fully update device", err)
 	}
 	return device, nil
 }
 
+// Delete locks the device, checks its state and reservations and deletes it in
+// a single transaction, so no reservation is left behind by a device deleted
+// meanwhile. Devices in use or under maintenance cannot be deleted.
 func (s *deviceService) Delete(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.DeleteDevice(ctx, id)
+	err := s.repo.WithinTx(ctx, sql.LevelRepeatableRead, func(repo repository.DeviceRepository) error {
+		device, err := repo.GetDeviceForUpdate(ctx, id)
+		if err != nil {
+			if goerrors.Is(err, sql.ErrNoRows) {
+				return errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+			}
+			return errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving device", err)
+		}
+
+		switch device.State {
+		case entity.InUse:
+			return errors.NewDeviceError(errors.ErrConflict, "device is in use and cannot be deleted", nil)
+		case entity.Maintenance:
+			return errors.NewDeviceError(errors.ErrConflict, "device is under maintenance and cannot be deleted, close its maintenance record first", nil)
+		}
+
+		if err := rejectUpcomingReservations(ctx, repo, id, "deleted"); err != nil {
+			return err
+		}
+
+		err = repo.DeleteDevice(ctx, id)
+		if err != nil {
+			if goerrors.Is(err, sql.ErrNoRows) {
+				return errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+			}
+			return errors.NewDeviceError(errors.ErrInternal, "something went wrong while delete device", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "something went wrong while delete device")
+	}
+	return nil
+}
+
+// Move takes the device to another location, whatever its state but retired, and
+// records the move in the device history, in a single transaction along with
+// the checks of the device.
+func (s *deviceService) Move(ctx context.Context, id uuid.UUID, locationID uuid.UUID, note *string) (entity.Device, error) {
+	var moved entity.Device
+	err := s.repo.WithinTx(ctx, sql.LevelRepeatableRead, func(repo repository.DeviceRepository) error {
+		var err error
+		moved, err = move(ctx, repo, id, locationID, note)
+		return err
+	})
+	if err != nil {
+		return entity.Device{}, txError(err, "something went wrong while moving device")
+	}
+	return moved, nil
+}
+
+func move(ctx context.Context, repo repository.DeviceRepository, id uuid.UUID, locationID uuid.UUID, note *string) (entity.Device, error) {
+	device, err := getByID(ctx, repo, id)
+	if err != nil {
+		return entity.Device{}, err
+	}
+
+	if device.State == entity.Retired {
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInvalid, "device is retired and cannot be moved", nil)
+	}
+
+	if device.LocationID != nil && *device.LocationID == locationID {
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("device is already at location %s", locationID), nil)
+	}
+
+	note = normalizeOptional(note, strings.TrimSpace)
+	if note != nil && len(*note) > maxMoveNoteLength {
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("note must have at most %d characters", maxMoveNoteLength), nil)
+	}
+
+	device, err = repo.MoveDevice(ctx, id, locationID, note)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Device{}, errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+		}
+		var pqErr *pq.Error
+		if goerrors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return entity.Device{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("location %s does not exist", locationID), err)
+		}
+		return entity.Device{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while moving device", err)
+	}
+	return device, nil
+}
+
+// History returns the events of the device, newest first.
+func (s *deviceService) History(ctx context.Context, id uuid.UUID) ([]entity.HistoryEvent, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.ListDeviceHistory(ctx, id)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving device history", err)
+	}
+	return events, nil
+}
+
+// Stats summarises the inventory. Every state is present in the counts, zero
+// when no device is in it, and the daily series has an entry for each day of
+// the requested range.
+func (s *deviceService) Stats(ctx context.Context, opts entity.StatsOptions) (entity.DeviceStats, error) {
+	opts.From = *normalizeDate(&opts.From)
+	opts.To = *normalizeDate(&opts.To)
+
+	if opts.To.Before(opts.From) {
+		return entity.DeviceStats{}, errors.NewDeviceError(errors.ErrInvalid, "the end of the stats period must not be before its start", nil)
+	}
+	days := int(opts.To.Sub(opts.From).Hours()/24) + 1
+	if days > maxStatsDays {
+		return entity.DeviceStats{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("the stats period must not be longer than %d days", maxStatsDays), nil)
+	}
+
+	stats, err := s.repo.GetDeviceStats(ctx, opts)
+	if err != nil {
+		return entity.DeviceStats{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while computing device stats", err)
+	}
+
+	if stats.ByState == nil {
+		stats.ByState = map[entity.DeviceState]int{}
+	}
+	fillStates(stats.ByState)
+
+	recorded := map[string]map[entity.DeviceState]int{}
+	for _, daily := range stats.Daily {
+		recorded[daily.Day.Format(time.DateOnly)] = daily.ByState
+	}
+
+	stats.Daily = make([]entity.DailyStateCount, 0, days)
+	for day := opts.From; !day.After(opts.To); day = day.AddDate(0, 0, 1) {
+		byState := recorded[day.Format(time.DateOnly)]
+		if byState == nil {
+			byState = map[entity.DeviceState]int{}
+		}
+		fillStates(byState)
+		stats.Daily = append(stats.Daily, entity.DailyStateCount{Day: day, ByState: byState})
+	}
+
+	return stats, nil
+}
+
+// fillStates adds the states missing from counts with zero devices.
+func fillStates(counts map[entity.DeviceState]int) {
+	for _, state := range entity.States {
+		if _, ok := counts[state]; !ok {
+			counts[state] = 0
+		}
+	}
+}
+
+// txError returns err, failing a transaction, as a device error, internal when
+// the transaction itself could not be begun or committed.
+func txError(err error, message string) error {
+	var deviceErr *errors.DeviceError
+	if goerrors.As(err, &deviceErr) {
+		return err
+	}
+	return errors.NewDeviceError(errors.ErrInternal, message, err)
+}
+
+// rejectUpcomingReservations returns a conflict error when the device is booked
+// from now on, as it could not be handed over to whoever booked it.
+func rejectUpcomingReservations(ctx context.Context, repo repository.DeviceRepository, id uuid.UUID, action string) error {
+	upcoming, err := repo.HasUpcomingReservations(ctx, id)
+	if err != nil {
+		return errors.NewDeviceError(errors.ErrInternal, "something went wrong while checking device reservations", err)
+	}
+	if upcoming {
+		return errors.NewDeviceError(errors.ErrConflict, fmt.Sprintf("device has upcoming reservations and cannot be %s", action), nil)
+	}
+	return nil
+}
+
+// normalizeAndValidateIdentifiers stores serial number and IMEI upper cased and
+// without surrounding spaces, blank identifiers are treated as not informed.
+func normalizeAndValidateIdentifiers(device *entity.Device) error {
+	device.SerialNumber = normalizeOptional(device.SerialNumber, entity.NormalizeIdentifier)
+	device.IMEI = normalizeOptional(device.IMEI, entity.NormalizeIdentifier)
+	device.ModelIdentifier = normalizeOptional(device.ModelIdentifier, strings.TrimSpace)
+	device.OSVersion = normalizeOptional(device.OSVersion, strings.TrimSpace)
+
+	if device.IMEI != nil && !entity.IsValidIMEI(*device.IMEI) && !entity.IsValidMEID(*device.IMEI) {
+		return errors.NewDeviceError(errors.ErrInvalid, "invalid imei, must be a 15 digit IMEI with a valid check digit or a 14 hexadecimal digit MEID", fmt.Errorf("invalid imei/meid: %s", *device.IMEI))
+	}
+
+	return nil
+}
+
+// maxProcurementTextLength bounds the supplier and invoice reference of a device.
+const maxProcurementTextLength = 100
+
+var currencyCodeRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// normalizeAndValidateProcurement keeps the procurement dates at midnight UTC
+// and checks that price and currency are informed together and consistent.
+func normalizeAndValidateProcurement(device *entity.Device) error {
+	device.PurchaseCurrency = normalizeOptional(device.PurchaseCurrency, func(v string) string {
+		return strings.ToUpper(strings.TrimSpace(v))
+	})
+	device.Supplier = normalizeOptional(device.Supplier, strings.TrimSpace)
+	device.InvoiceReference = normalizeOptional(device.InvoiceReference, strings.TrimSpace)
+	device.PurchaseDate = normalizeDate(device.PurchaseDate)
+	device.WarrantyEndsOn = normalizeDate(device.WarrantyEndsOn)
+
+	if (device.PurchasePrice == nil) != (device.PurchaseCurrency == nil) {
+		return errors.NewDeviceError(errors.ErrInvalid, "purchase price and purchase currency must be informed together", nil)
+	}
+	if device.PurchasePrice != nil && *device.PurchasePrice < 0 {
+		return errors.NewDeviceError(errors.ErrInvalid, "purchase price must not be negative", fmt.Errorf("invalid purchase price: %v", *device.PurchasePrice))
+	}
+	if device.PurchaseCurrency != nil && !currencyCodeRegexp.MatchString(*device.PurchaseCurrency) {
+		return errors.NewDeviceError(errors.ErrInvalid, "invalid purchase currency, must be a 3 letter ISO 4217 code", fmt.Errorf("invalid purchase currency: %s", *device.PurchaseCurrency))
+	}
+	if device.PurchaseDate != nil && device.PurchaseDate.After(time.Now().UTC()) {
+		return errors.NewDeviceError(errors.ErrInvalid, "purchase date must not be in the future", nil)
+	}
+	if device.PurchaseDate != nil && device.WarrantyEndsOn != nil && device.WarrantyEndsOn.Before(*device.PurchaseDate) {
+		return errors.NewDeviceError(errors.ErrInvalid, "warranty end must not be before the purchase date", nil)
+	}
+	if device.Supplier != nil && len(*device.Supplier) > maxProcurementTextLength {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("supplier must have at most %d characters", maxProcurementTextLength), nil)
+	}
+	if device.InvoiceReference != nil && len(*device.InvoiceReference) > maxProcurementTextLength {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invoice reference must have at most %d characters", maxProcurementTextLength), nil)
+	}
+
+	return nil
+}
+
+// normalizeDate drops the time of day of value, keeping its calendar date in UTC.
+func normalizeDate(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	y, m, d := value.UTC().Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+// normalizeAndValidateCustomFields normalizes the tags and validates the
+// attributes against the schema of the tenant in ctx, if any.
+func (s *deviceService) normalizeAndValidateCustomFields(ctx context.Context, device *entity.Device) error {
+	tags, err := normalizeTags(device.Tags)
+	if err != nil {
+		return err
+	}
+	device.Tags = tags
+
+	if device.Attributes == nil {
+		device.Attributes = entity.Attributes{}
+	}
+
+	definitions, err := s.tenantAttributeDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return validateAttributes(device.Attributes, definitions)
+}
+
+// prepareFilter resolves the brand and model filters through the catalogues, so
+// aliases and spelling variants match, normalizes the tag filter like stored tags and
+// types the attribute filter values after the schema of the tenant in ctx, if any.
+func (s *deviceService) prepareFilter(ctx context.Context, filter *entity.DeviceFilter) error {
+	if filter.Brand != nil {
+		brand, err := s.brands.Find(ctx, *filter.Brand)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		// an unknown brand matches no device, uuid.Nil is never a brand ID
+		filter.BrandID = &brand.ID
+		filter.Brand = nil
+	}
+
+	if filter.Model != nil {
+		modelIDs, err := s.modelIDs(ctx, *filter.Model, filter.BrandID)
+		if err != nil {
+			return err
+		}
+		filter.ModelIDs = modelIDs
+		filter.Model = nil
+	}
+
+	if len(filter.Tags) > 0 {
+		tags, err := normalizeTags(filter.Tags)
+		if err != nil {
+			return err
+		}
+		filter.Tags = tags
+	}
+
+	if len(filter.Attributes) == 0 {
+		return nil
+	}
+
+	definitions, err := s.tenantAttributeDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter.Attributes, err = typedAttributeFilter(filter.Attributes, definitions)
+	return err
+}
+
+// modelIDs resolves the model filter, either a model ID or a model name matched
+// case insensitively among the models of every brand or of brandID, if informed.
+func (s *deviceService) modelIDs(ctx context.Context, model string, brandID *uuid.UUID) ([]uuid.UUID, error) {
+	if modelID, err := uuid.Parse(model); err == nil {
+		return []uuid.UUID{modelID}, nil
+	}
+
+	models, err := s.models.FindByName(ctx, model, brandID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(models) == 0 {
+		// an unknown model matches no device, uuid.Nil is never a model ID
+		return []uuid.UUID{uuid.Nil}, nil
+	}
+
+	modelIDs := make([]uuid.UUID, 0, len(models))
+	for _, m := range models {
+		modelIDs = append(modelIDs, m.ID)
+	}
+	return modelIDs, nil
+}
+
+// tenantAttributeDefinitions returns the attribute schema of the tenant in ctx,
+// requests made without a tenant are not bound to any schema.
+func (s *deviceService) tenantAttributeDefinitions(ctx context.Context) ([]entity.AttributeDefinition, error) {
+	tenantID, ok := tenant.IDFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	definitions, err := s.attributeDefinitions.ListAttributeDefinitions(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving attribute definitions", err)
+	}
+	return definitions, nil
+}
+
+// resolveBrandAndModel links the device to its model, if informed, filling the
+// brand from the model when the device has none and otherwise checking the brand
+// informed is the brand of the model. Devices without a model have their brand
+// resolved by resolveBrand.
+func (s *deviceService) resolveBrandAndModel(ctx context.Context, device *entity.Device) error {
+	if device.ModelID == nil {
+		return s.resolveBrand(ctx, device)
+	}
+
+	model, err := s.models.GetByID(ctx, *device.ModelID)
+	if err != nil {
+		if isNotFound(err) {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("model %s does not exist", *device.ModelID), err)
+		}
+		return err
+	}
+
+	if strings.TrimSpace(device.Brand) != "" {
+		// Find rather than Resolve, a brand that does not exist cannot be the brand of the model
+		brand, err := s.brands.Find(ctx, device.Brand)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		if brand.ID != model.BrandID {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("brand %q does not match the brand of model %s, %s", device.Brand, model.Name, model.Brand), nil)
+		}
+	}
+
+	device.BrandID = model.BrandID
+	device.Brand = model.Brand
+	return nil
+}
+
+// resolveBrand links the device to the catalogue brand its brand name resolves
+// to, registering the brand when needed, and stores the catalogue spelling.
+func (s *deviceService) resolveBrand(ctx context.Context, device *entity.Device) error {
+	brand, err := s.brands.Resolve(ctx, device.Brand)
 	if err != nil {
-		return errors.NewDeviceError(errors.ErrInternal, "something went wrong while delete device", err)
+		return err
 	}
+
+	device.BrandID = brand.ID
+	device.Brand = brand.Name
 	return nil
 }
+
+func isNotFound(err error) bool {
+	var deviceErr *errors.DeviceError
+	return goerrors.As(err, &deviceErr) && deviceErr.Type == errors.ErrNotFound
+}
+
+func normalizeOptional(value *string, normalize func(string) string) *string {
+	if value == nil {
+		return nil
+	}
+	normalized := normalize(*value)
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+// uniqueIdentifierConstraints maps the partial unique indexes on devices to the
+// message returned when a request collides with an existing device.
+var uniqueIdentifierConstraints = map[string]string{
+	"uq_devices_serial_number": "a device with this serial number already exists",
+	"uq_devices_imei":          "a device with this imei already exists",
+}
+
+// uniqueViolationError returns a conflict error when err is a unique violation
+// (SQLSTATE 23505) raised by Postgres, or nil otherwise.
+func uniqueViolationError(err error) *errors.DeviceError {
+	var pqErr *pq.Error
+	if !goerrors.As(err, &pqErr) || pqErr.Code != "23505" {
+		return nil
+	}
+
+	message, ok := uniqueIdentifierConstraints[pqErr.Constraint]
+	if !ok {
+		message = "device conflicts with an existing device"
+	}
+	return errors.NewDeviceError(errors.ErrConflict, message, err)
+}
@@ -3,31 +3,70 @@ package device
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	brandentity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/repository"
 	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+	modelentity "github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/tenant"
 )
 
 var errDatabaseGeneric = fmt.Errorf("some database error")
 
+var errSerialNumberUniqueViolation = &pq.Error{Code: "23505", Constraint: "uq_devices_serial_number"}
+
+// expectWithinTx runs the operations given to WithinTx on mockRepo itself, as
+// a transaction of the isolation level.
+func expectWithinTx(mockRepo *mocks.MockDeviceRepository, isolation sql.IsolationLevel) {
+	mockRepo.
+		EXPECT().
+		WithinTx(gomock.Any(), isolation, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ sql.IsolationLevel, fn func(repo repository.DeviceRepository) error) error {
+			return fn(mockRepo)
+		}).
+		AnyTimes()
+}
+
+// catalogueBrandID derives a stable brand ID from the brand name.
+func catalogueBrandID(name string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name))
+}
+
+// catalogueBrands returns a brand resolver where every brand name is already
+// registered in the catalogue as it is spelled.
+func catalogueBrands(mockCtrl *gomock.Controller) *mocks.MockBrandResolver {
+	brands := mocks.NewMockBrandResolver(mockCtrl)
+	catalogued := func(_ context.Context, name string) (brandentity.Brand, error) {
+		return brandentity.Brand{ID: catalogueBrandID(name), Name: name}, nil
+	}
+
+	brands.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(catalogued).AnyTimes()
+	brands.EXPECT().Resolve(gomock.Any(), gomock.Any()).DoAndReturn(catalogued).AnyTimes()
+	return brands
+}
+
 func Test_List_Device(t *testing.T) {
 	type args struct {
 		context context.Context
-		params  map[string]any
+		opts    entity.ListOptions
 	}
 
 	testArgs := args{
 		context: context.TODO(),
-		params: map[string]any{
-			"brand": nil,
-			"state": nil,
-		},
+		opts:    entity.ListOptions{},
 	}
 
 	tests := []struct {
@@ -61,9 +100,8 @@ func Test_List_Device(t *testing.T) {
 			name: "List Success Filtering by Brand Case",
 			testArgs: args{
 				context: context.TODO(),
-				params: map[string]any{
-					"brand": "Samsung",
-					"state": nil,
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{Brand: lo.ToPtr("Samsung")},
 				},
 			},
 			wantRepositoryResult: []entity.Device{
@@ -81,9 +119,9 @@ func Test_List_Device(t *testing.T) {
 			name: "List Success Filtering by State Case",
 			testArgs: args{
 				context: context.TODO(),
-				params: map[string]any{
-					"brand": nil,
-					"state": "available",
+				opts: entity.ListOptions{
+					Filter: entity.DeviceFilter{States: []entity.DeviceState{entity.Available}},
+					Sort:   []entity.SortKey{{Field: entity.SortByCreatedAt, Descending: true}},
 				},
 			},
 			wantRepositoryResult: []entity.Device{
@@ -112,21 +150,127 @@ func Test_List_Device(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
-			service := NewDeviceService(mockRepo)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+
+			// the brand filter reaches the repository resolved to the catalogue brand
+			wantOpts := tt.testArgs.opts
+			if wantOpts.Filter.Brand != nil {
+				wantOpts.Filter.BrandID = lo.ToPtr(catalogueBrandID(*wantOpts.Filter.Brand))
+				wantOpts.Filter.Brand = nil
+			}
 
 			mockRepo.
 				EXPECT().
-				ListDevices(tt.testArgs.context, tt.testArgs.params).
+				ListDevices(tt.testArgs.context, wantOpts).
 				Return(tt.wantRepositoryResult, tt.wantRepositoryErr).
 				AnyTimes()
 
-			devices, err := service.List(tt.testArgs.context, tt.testArgs.params)
+			devices, err := service.List(tt.testArgs.context, tt.testArgs.opts)
 			assert.Equal(t, tt.wantErr, err)
 			assert.Equal(t, tt.wantRepositoryResult, devices)
 		})
 	}
 }
 
+func Test_Search_Device(t *testing.T) {
+	type args struct {
+		context context.Context
+		term    string
+		opts    entity.ListOptions
+	}
+
+	testArgs := args{
+		context: context.TODO(),
+		term:    "galaxy",
+		opts:    entity.ListOptions{},
+	}
+
+	tests := []struct {
+		name                 string
+		testArgs             args
+		callRepository       bool
+		wantRepositoryResult []entity.DeviceSearchResult
+		wantRepositoryErr    error
+		wantResult           []entity.DeviceSearchResult
+		wantErr              error
+	}{
+		{
+			name:           "Search Success Case",
+			testArgs:       testArgs,
+			callRepository: true,
+			wantRepositoryResult: []entity.DeviceSearchResult{
+				{
+					Device: entity.Device{
+						ID:    uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+						Name:  "Galaxy S21",
+						Brand: "Samsung",
+						State: entity.InUse,
+					},
+					Rank:           0.6,
+					NameHighlight:  "<mark>Galaxy</mark> S21",
+					BrandHighlight: "Samsung",
+				},
+			},
+			wantRepositoryErr: nil,
+			wantResult: []entity.DeviceSearchResult{
+				{
+					Device: entity.Device{
+						ID:    uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+						Name:  "Galaxy S21",
+						Brand: "Samsung",
+						State: entity.InUse,
+					},
+					Rank:           0.6,
+					NameHighlight:  "<mark>Galaxy</mark> S21",
+					BrandHighlight: "Samsung",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Search Empty Term Case",
+			testArgs: args{
+				context: context.TODO(),
+				term:    "   ",
+				opts:    testArgs.opts,
+			},
+			callRepository: false,
+			wantResult:     nil,
+			wantErr:        errors.NewDeviceError(errors.ErrInvalid, "search term must not be empty", nil),
+		},
+		{
+			name:                 "Search Repository Error Case",
+			testArgs:             testArgs,
+			callRepository:       true,
+			wantRepositoryResult: nil,
+			wantRepositoryErr:    errDatabaseGeneric,
+			wantResult:           nil,
+			wantErr:              errors.NewDeviceError(errors.ErrInternal, "something went wrong while searching devices", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+
+			if tt.callRepository {
+				mockRepo.
+					EXPECT().
+					SearchDevices(tt.testArgs.context, tt.testArgs.term, tt.testArgs.opts).
+					Return(tt.wantRepositoryResult, tt.wantRepositoryErr)
+			}
+
+			results, err := service.Search(tt.testArgs.context, tt.testArgs.term, tt.testArgs.opts)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantResult, results)
+		})
+	}
+}
+
 func Test_GetByID_Device(t *testing.T) {
 	type args struct {
 		context  context.Context
@@ -179,7 +323,7 @@ func Test_GetByID_Device(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
-			service := NewDeviceService(mockRepo)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
 
 			mockRepo.
 				EXPECT().
@@ -194,43 +338,64 @@ func Test_GetByID_Device(t *testing.T) {
 	}
 }
 
-func Test_Create_Device(t *testing.T) {
+func Test_GetBySerialNumber_Device(t *testing.T) {
 	type args struct {
-		context context.Context
+		context      context.Context
+		serialNumber string
 	}
 
 	testArgs := args{
-		context: context.TODO(),
+		context:      context.TODO(),
+		serialNumber: " r58r12abcde",
 	}
 
 	tests := []struct {
-		name                string
-		testArgs            args
-		device              entity.Device
-		wantedRepositoryErr error
-		wantErr             error
+		name                 string
+		testArgs             args
+		wantRepositoryCalls  int
+		wantRepositoryResult entity.Device
+		wantRepositoryErr    error
+		wantErr              error
 	}{
 		{
-			name:     "Create Device Success Case",
-			testArgs: testArgs,
-			device: entity.Device{
-				Name:  "Galaxy S21",
-				Brand: "Samsung",
-				State: entity.InUse,
+			name:                "GetBySerialNumber Success Case",
+			testArgs:            testArgs,
+			wantRepositoryCalls: 1,
+			wantRepositoryResult: entity.Device{
+				ID:           uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+				Name:         "Galaxy S21",
+				Brand:        "Samsung",
+				State:        entity.InUse,
+				SerialNumber: lo.ToPtr("R58R12ABCDE"),
 			},
-			wantedRepositoryErr: nil,
-			wantErr:             nil,
+			wantRepositoryErr: nil,
+			wantErr:           nil,
 		},
 		{
-			name:     "Create Device Repository Error Case",
-			testArgs: testArgs,
-			device: entity.Device{
-				Name:  "iPhone 13",
-				Brand: "Apple",
-				State: entity.Available,
+			name:                 "GetBySerialNumber Device Not Found Case",
+			testArgs:             testArgs,
+			wantRepositoryCalls:  1,
+			wantRepositoryResult: entity.Device{},
+			wantRepositoryErr:    sql.ErrNoRows,
+			wantErr:              errors.NewDeviceError(errors.ErrNotFound, "device not found", sql.ErrNoRows),
+		},
+		{
+			name:                 "GetBySerialNumber Device Repository Error Case",
+			testArgs:             testArgs,
+			wantRepositoryCalls:  1,
+			wantRepositoryResult: entity.Device{},
+			wantRepositoryErr:    errDatabaseGeneric,
+			wantErr:              errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving device", errDatabaseGeneric),
+		},
+		{
+			name: "GetBySerialNumber Empty Serial Number Case",
+			testArgs: args{
+				context:      context.TODO(),
+				serialNumber: "   ",
 			},
-			wantedRepositoryErr: errDatabaseGeneric,
-			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating device", errDatabaseGeneric),
+			wantRepositoryCalls:  0,
+			wantRepositoryResult: entity.Device{},
+			wantErr:              errors.NewDeviceError(errors.ErrInvalid, "serial number must not be empty", nil),
 		},
 	}
 
@@ -240,25 +405,22 @@ func Test_Create_Device(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
-			service := NewDeviceService(mockRepo)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
 
 			mockRepo.
 				EXPECT().
-				CreateDevice(tt.testArgs.context, gomock.Any()).
-				Return(tt.wantedRepositoryErr).
-				AnyTimes()
+				GetDeviceBySerialNumber(tt.testArgs.context, "R58R12ABCDE").
+				Return(tt.wantRepositoryResult, tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
 
-			device, err := service.Create(tt.testArgs.context, tt.device)
+			device, err := service.GetBySerialNumber(tt.testArgs.context, tt.testArgs.serialNumber)
 			assert.Equal(t, tt.wantErr, err)
-			assert.Equal(t, tt.device.Name, device.Name)
-			assert.Equal(t, tt.device.Brand, device.Brand)
-			assert.Equal(t, tt.device.State, device.State)
-			assert.NotEqual(t, uuid.Nil, device.ID)
+			assert.Equal(t, tt.wantRepositoryResult, device)
 		})
 	}
 }
 
-func Test_Update_Device(t *testing.T) {
+func Test_Create_Device(t *testing.T) {
 	type args struct {
 		context context.Context
 	}
@@ -268,129 +430,74 @@ func Test_Update_Device(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                    string
-		testArgs                args
-		device                  entity.Device
-		updateOnlyStatus        bool
-		wantedRepoGetByIdResult entity.Device
-		wantedRepoGetByIdError  error
-		wantedRepoUpdateErr     error
-		wantErr                 error
+		name                string
+		testArgs            args
+		device              entity.Device
+		wantSerialNumber    *string
+		wantedRepositoryErr error
+		wantErr             error
 	}{
 		{
-			name:     "Update Device Success Case",
+			name:     "Create Device Success Case",
 			testArgs: testArgs,
 			device: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
-				State: entity.InUse,
-			},
-			updateOnlyStatus: false,
-			wantedRepoGetByIdResult: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
 				Name:  "Galaxy S21",
 				Brand: "Samsung",
-				State: entity.Available,
-			},
-			wantedRepoGetByIdError: nil,
-			wantedRepoUpdateErr:    nil,
-			wantErr:                nil,
-		},
-		{
-			name:     "Update Device Error Retrieving Device Case",
-			testArgs: testArgs,
-			device: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
 				State: entity.InUse,
 			},
-			updateOnlyStatus:        false,
-			wantedRepoGetByIdResult: entity.Device{},
-			wantedRepoGetByIdError:  errDatabaseGeneric,
-			wantedRepoUpdateErr:     nil,
-			wantErr:                 errors.NewDeviceError(errors.ErrNotFound, "something went wrong while retrieving device", errDatabaseGeneric),
+			wantedRepositoryErr: nil,
+			wantErr:             nil,
 		},
 		{
-			name:     "Update Only Device Not Occur When In Use Case",
+			name:     "Create Device Repository Error Case",
 			testArgs: testArgs,
 			device: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
-				State: entity.InUse,
-			},
-			updateOnlyStatus: true,
-			wantedRepoGetByIdResult: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
-				State: entity.InUse,
+				Name:  "iPhone 13",
+				Brand: "Apple",
+				State: entity.Available,
 			},
-			wantedRepoGetByIdError: nil,
-			wantedRepoUpdateErr:    nil,
-			wantErr:                errors.NewDeviceError(errors.ErrInvalid, "device is in use and cannot be updated", fmt.Errorf("device state is the same: %s", entity.InUse)),
+			wantedRepositoryErr: errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating device", errDatabaseGeneric),
 		},
 		{
-			name:     "Update Only Device State with Success",
+			name:     "Create Device Normalizes Identifiers Case",
 			testArgs: testArgs,
 			device: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
-				State: entity.Available,
-			},
-			updateOnlyStatus: true,
-			wantedRepoGetByIdResult: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
-				State: entity.InUse,
+				Name:         "Galaxy S21",
+				Brand:        "Samsung",
+				State:        entity.Available,
+				SerialNumber: lo.ToPtr(" r58r12abcde "),
+				IMEI:         lo.ToPtr("490154203237518"),
+				OSVersion:    lo.ToPtr("  "),
 			},
-			wantedRepoGetByIdError: nil,
-			wantedRepoUpdateErr:    nil,
-			wantErr:                nil,
+			wantSerialNumber:    lo.ToPtr("R58R12ABCDE"),
+			wantedRepositoryErr: nil,
+			wantErr:             nil,
 		},
 		{
-			name:     "Update Only Device State with Expected Error",
+			name:     "Create Device Invalid IMEI Case",
 			testArgs: testArgs,
 			device: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
+				Name:  "Galaxy S21",
 				Brand: "Samsung",
 				State: entity.Available,
+				IMEI:  lo.ToPtr("490154203237519"),
 			},
-			updateOnlyStatus: true,
-			wantedRepoGetByIdResult: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
-				State: entity.InUse,
-			},
-			wantedRepoGetByIdError: nil,
-			wantedRepoUpdateErr:    errDatabaseGeneric,
-			wantErr:                errors.NewDeviceError(errors.ErrInternal, "something went wrong while update device state", fmt.Errorf("error updating device state: %v", errDatabaseGeneric)),
+			wantedRepositoryErr: nil,
+			wantErr:             errors.NewDeviceError(errors.ErrInvalid, "invalid imei, must be a 15 digit IMEI with a valid check digit or a 14 hexadecimal digit MEID", fmt.Errorf("invalid imei/meid: 490154203237519")),
 		},
 		{
-			name:     "Update Only Device State with Expected Error",
+			name:     "Create Device Serial Number Conflict Case",
 			testArgs: testArgs,
 			device: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
-				State: entity.InUse,
-			},
-			updateOnlyStatus: false,
-			wantedRepoGetByIdResult: entity.Device{
-				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
-				Name:  "Galaxy S21 Updated",
-				Brand: "Samsung",
-				State: entity.Available,
+				Name:         "Galaxy S21",
+				Brand:        "Samsung",
+				State:        entity.Available,
+				SerialNumber: lo.ToPtr("R58R12ABCDE"),
 			},
-			wantedRepoGetByIdError: nil,
-			wantedRepoUpdateErr:    errDatabaseGeneric,
-			wantErr:                errors.NewDeviceError(errors.ErrInternal, "something went wrong while fully update device", errDatabaseGeneric),
+			wantSerialNumber:    lo.ToPtr("R58R12ABCDE"),
+			wantedRepositoryErr: errSerialNumberUniqueViolation,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "a device with this serial number already exists", errSerialNumberUniqueViolation),
 		},
 	}
 
@@ -400,33 +507,832 @@ func Test_Update_Device(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
-			service := NewDeviceService(mockRepo)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
 
 			mockRepo.
 				EXPECT().
-				GetDeviceByID(tt.testArgs.context, gomock.Any()).
-				Return(tt.wantedRepoGetByIdResult, tt.wantedRepoGetByIdError).
+				CreateDevice(tt.testArgs.context, gomock.Any()).
+				Return(tt.wantedRepositoryErr).
 				AnyTimes()
 
-			if tt.updateOnlyStatus {
-				mockRepo.EXPECT().
-					UpdateDeviceState(tt.testArgs.context, tt.device.ID, tt.device.State).
-					Return(tt.device, tt.wantedRepoUpdateErr).
-					AnyTimes()
-			} else {
-				mockRepo.EXPECT().
-					FullyUpdateDevice(tt.testArgs.context, gomock.Any()).
-					Return(tt.wantedRepoUpdateErr).
-					AnyTimes()
-			}
-
-			updatedDevice, err := service.Update(tt.testArgs.context, tt.device)
+			device, err := service.Create(tt.testArgs.context, tt.device)
 			assert.Equal(t, tt.wantErr, err)
-			if err == nil {
-				assert.Equal(t, tt.device.Name, updatedDevice.Name)
-				assert.Equal(t, tt.device.Brand, updatedDevice.Brand)
-				assert.Equal(t, tt.device.State, updatedDevice.State)
-			}
+			assert.Equal(t, tt.device.Name, device.Name)
+			assert.Equal(t, tt.device.Brand, device.Brand)
+			assert.Equal(t, tt.device.State, device.State)
+			assert.Equal(t, tt.wantSerialNumber, device.SerialNumber)
+			assert.NotEqual(t, uuid.Nil, device.ID)
+		})
+	}
+}
+
+func Test_Create_Device_Brand(t *testing.T) {
+	hp := brandentity.Brand{ID: uuid.MustParse("3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"), Name: "Hewlett-Packard"}
+	errEmptyBrand := errors.NewDeviceError(errors.ErrInvalid, "brand must not be empty", nil)
+
+	tests := []struct {
+		name                string
+		brand               string
+		wantResolveResult   brandentity.Brand
+		wantResolveErr      error
+		wantRepositoryCalls int
+		wantBrand           string
+		wantBrandID         uuid.UUID
+		wantErr             error
+	}{
+		{
+			name:                "Create Device Uses Catalogue Brand Case",
+			brand:               "HP Inc.",
+			wantResolveResult:   hp,
+			wantRepositoryCalls: 1,
+			wantBrand:           "Hewlett-Packard",
+			wantBrandID:         hp.ID,
+		},
+		{
+			name:           "Create Device Brand Not Resolved Case",
+			brand:          " ",
+			wantResolveErr: errEmptyBrand,
+			wantErr:        errEmptyBrand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			mockBrands := mocks.NewMockBrandResolver(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), mockBrands, mocks.NewMockModelCatalogue(mockCtrl))
+
+			mockBrands.
+				EXPECT().
+				Resolve(context.TODO(), tt.brand).
+				Return(tt.wantResolveResult, tt.wantResolveErr)
+
+			mockRepo.
+				EXPECT().
+				CreateDevice(context.TODO(), gomock.Any()).
+				Return(nil).
+				Times(tt.wantRepositoryCalls)
+
+			device, err := service.Create(context.TODO(), entity.Device{Name: "EliteBook 840", Brand: tt.brand, State: entity.Available})
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantBrand, device.Brand)
+				assert.Equal(t, tt.wantBrandID, device.BrandID)
+			}
+		})
+	}
+}
+
+func Test_Create_Device_Procurement(t *testing.T) {
+	purchasedAt := time.Date(2025, 3, 10, 15, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	tests := []struct {
+		name                string
+		device              entity.Device
+		wantRepositoryCalls int
+		wantPurchaseDate    *time.Time
+		wantCurrency        *string
+		wantErr             error
+	}{
+		{
+			name: "Create Device Normalizes Procurement Case",
+			device: entity.Device{
+				PurchaseDate:     &purchasedAt,
+				PurchasePrice:    lo.ToPtr(1299.9),
+				PurchaseCurrency: lo.ToPtr(" eur "),
+				Supplier:         lo.ToPtr(" ACME Distribution "),
+			},
+			wantRepositoryCalls: 1,
+			wantPurchaseDate:    lo.ToPtr(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)),
+			wantCurrency:        lo.ToPtr("EUR"),
+		},
+		{
+			name:    "Create Device Price Without Currency Case",
+			device:  entity.Device{PurchasePrice: lo.ToPtr(100.0)},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "purchase price and purchase currency must be informed together", nil),
+		},
+		{
+			name:    "Create Device Invalid Currency Case",
+			device:  entity.Device{PurchasePrice: lo.ToPtr(100.0), PurchaseCurrency: lo.ToPtr("euro")},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "invalid purchase currency, must be a 3 letter ISO 4217 code", fmt.Errorf("invalid purchase currency: EURO")),
+		},
+		{
+			name:    "Create Device Negative Price Case",
+			device:  entity.Device{PurchasePrice: lo.ToPtr(-1.0), PurchaseCurrency: lo.ToPtr("USD")},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "purchase price must not be negative", fmt.Errorf("invalid purchase price: -1")),
+		},
+		{
+			name:    "Create Device Purchase Date In The Future Case",
+			device:  entity.Device{PurchaseDate: lo.ToPtr(time.Now().AddDate(0, 0, 2))},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "purchase date must not be in the future", nil),
+		},
+		{
+			name:    "Create Device Warranty Ends Before Purchase Case",
+			device:  entity.Device{PurchaseDate: &purchasedAt, WarrantyEndsOn: lo.ToPtr(purchasedAt.AddDate(0, 0, -1))},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "warranty end must not be before the purchase date", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+
+			mockRepo.
+				EXPECT().
+				CreateDevice(context.TODO(), gomock.Any()).
+				Return(nil).
+				Times(tt.wantRepositoryCalls)
+
+			tt.device.Name = "ThinkPad T14"
+			tt.device.Brand = "Lenovo"
+			tt.device.State = entity.Available
+
+			device, err := service.Create(context.TODO(), tt.device)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantPurchaseDate, device.PurchaseDate)
+				assert.Equal(t, tt.wantCurrency, device.PurchaseCurrency)
+				assert.Equal(t, lo.ToPtr("ACME Distribution"), device.Supplier)
+			}
+		})
+	}
+}
+
+func Test_Create_Device_Custom_Fields(t *testing.T) {
+	tenantContext := tenant.WithID(context.TODO(), "acme")
+
+	definitions := []entity.AttributeDefinition{
+		{TenantID: "acme", Name: "carrier", Type: entity.AttributeString, Required: true, Enum: []string{"vodafone", "orange"}},
+		{TenantID: "acme", Name: "cost", Type: entity.AttributeNumber},
+	}
+
+	tests := []struct {
+		name                string
+		context             context.Context
+		device              entity.Device
+		wantDefinitionCalls int
+		wantRepositoryCalls int
+		wantTags            []string
+		wantErr             error
+	}{
+		{
+			name:    "Create Device Normalizes Tags Without Tenant Case",
+			context: context.TODO(),
+			device: entity.Device{
+				Tags:       []string{" QA", "lab", "qa"},
+				Attributes: entity.Attributes{"colour": "red"},
+			},
+			wantDefinitionCalls: 0,
+			wantRepositoryCalls: 1,
+			wantTags:            []string{"lab", "qa"},
+		},
+		{
+			name:    "Create Device Invalid Tag Case",
+			context: context.TODO(),
+			device: entity.Device{
+				Tags: []string{"qa team"},
+			},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, `invalid tag "qa team", `+invalidTagHint, nil),
+		},
+		{
+			name:    "Create Device Nested Attribute Case",
+			context: context.TODO(),
+			device: entity.Device{
+				Attributes: entity.Attributes{"owner": map[string]any{"team": "qa"}},
+			},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "invalid attribute owner, values must be a string, a number or a boolean", nil),
+		},
+		{
+			name:    "Create Device Valid Against Tenant Schema Case",
+			context: tenantContext,
+			device: entity.Device{
+				Attributes: entity.Attributes{"carrier": "orange", "cost": 120.5, "colour": "red"},
+			},
+			wantDefinitionCalls: 1,
+			wantRepositoryCalls: 1,
+			wantTags:            []string{},
+		},
+		{
+			name:    "Create Device Missing Required Attribute Case",
+			context: tenantContext,
+			device: entity.Device{
+				Attributes: entity.Attributes{"cost": 120.5},
+			},
+			wantDefinitionCalls: 1,
+			wantErr:             errors.NewDeviceError(errors.ErrInvalid, "attribute carrier is required", nil),
+		},
+		{
+			name:    "Create Device Attribute Outside Enum Case",
+			context: tenantContext,
+			device: entity.Device{
+				Attributes: entity.Attributes{"carrier": "o2"},
+			},
+			wantDefinitionCalls: 1,
+			wantErr:             errors.NewDeviceError(errors.ErrInvalid, `invalid attribute carrier value "o2", must be one of: vodafone, orange`, nil),
+		},
+		{
+			name:    "Create Device Attribute With Wrong Type Case",
+			context: tenantContext,
+			device: entity.Device{
+				Attributes: entity.Attributes{"carrier": "vodafone", "cost": "cheap"},
+			},
+			wantDefinitionCalls: 1,
+			wantErr:             errors.NewDeviceError(errors.ErrInvalid, "invalid attribute cost, must be a number", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			mockAttributeRepo := mocks.NewMockAttributeDefinitionRepository(mockCtrl)
+			service := NewDeviceService(mockRepo, mockAttributeRepo, catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+
+			mockAttributeRepo.
+				EXPECT().
+				ListAttributeDefinitions(tt.context, "acme").
+				Return(definitions, nil).
+				Times(tt.wantDefinitionCalls)
+
+			mockRepo.
+				EXPECT().
+				CreateDevice(tt.context, gomock.Any()).
+				Return(nil).
+				Times(tt.wantRepositoryCalls)
+
+			device, err := service.Create(tt.context, tt.device)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantTags, device.Tags)
+			}
+		})
+	}
+}
+
+func Test_List_Device_Attribute_Filter(t *testing.T) {
+	tenantContext := tenant.WithID(context.TODO(), "acme")
+
+	definitions := []entity.AttributeDefinition{
+		{TenantID: "acme", Name: "cost", Type: entity.AttributeNumber},
+		{TenantID: "acme", Name: "leased", Type: entity.AttributeBoolean},
+	}
+
+	tests := []struct {
+		name                string
+		context             context.Context
+		filter              entity.DeviceFilter
+		wantDefinitionCalls int
+		wantFilter          entity.DeviceFilter
+		wantErr             error
+	}{
+		{
+			name:       "List Filters by Text Attributes Without Tenant Case",
+			context:    context.TODO(),
+			filter:     entity.DeviceFilter{Tags: []string{"QA"}, Attributes: entity.Attributes{"cost": "120"}},
+			wantFilter: entity.DeviceFilter{Tags: []string{"qa"}, Attributes: entity.Attributes{"cost": "120"}},
+		},
+		{
+			name:                "List Types Attribute Filter After Tenant Schema Case",
+			context:             tenantContext,
+			filter:              entity.DeviceFilter{Attributes: entity.Attributes{"cost": "120", "leased": "true", "carrier": "vodafone"}},
+			wantDefinitionCalls: 1,
+			wantFilter:          entity.DeviceFilter{Attributes: entity.Attributes{"cost": 120.0, "leased": true, "carrier": "vodafone"}},
+		},
+		{
+			name:                "List Invalid Number Attribute Filter Case",
+			context:             tenantContext,
+			filter:              entity.DeviceFilter{Attributes: entity.Attributes{"cost": "cheap"}},
+			wantDefinitionCalls: 1,
+			wantErr:             errors.NewDeviceError(errors.ErrInvalid, `invalid attr.cost value "cheap", must be a number`, nil),
+		},
+		{
+			name:    "List Invalid Attribute Name Filter Case",
+			context: context.TODO(),
+			filter:  entity.DeviceFilter{Attributes: entity.Attributes{"Cost": "120"}},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, `invalid attribute name "Cost", `+invalidAttributeNameHint, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			mockAttributeRepo := mocks.NewMockAttributeDefinitionRepository(mockCtrl)
+			service := NewDeviceService(mockRepo, mockAttributeRepo, catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+
+			mockAttributeRepo.
+				EXPECT().
+				ListAttributeDefinitions(tt.context, "acme").
+				Return(definitions, nil).
+				Times(tt.wantDefinitionCalls)
+
+			if tt.wantErr == nil {
+				mockRepo.
+					EXPECT().
+					ListDevices(tt.context, entity.ListOptions{Filter: tt.wantFilter}).
+					Return(nil, nil)
+			}
+
+			_, err := service.List(tt.context, entity.ListOptions{Filter: tt.filter})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_Create_Device_Model(t *testing.T) {
+	pixel7 := modelentity.Model{
+		ID:      uuid.MustParse("d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"),
+		BrandID: uuid.MustParse("1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704"),
+		Brand:   "Google",
+		Name:    "Pixel 7",
+	}
+	google := brandentity.Brand{ID: pixel7.BrandID, Name: "Google"}
+	samsung := brandentity.Brand{ID: uuid.MustParse("0b9e0d3c-5f41-4c57-9a52-2d2f0e6f1a01"), Name: "Samsung"}
+	errModelNotFound := errors.NewDeviceError(errors.ErrNotFound, "model not found", sql.ErrNoRows)
+	errBrandNotFound := errors.NewDeviceError(errors.ErrNotFound, "brand not found", sql.ErrNoRows)
+
+	tests := []struct {
+		name                string
+		brand               string
+		wantGetModelErr     error
+		wantFindCalls       int
+		wantFindResult      brandentity.Brand
+		wantFindErr         error
+		wantRepositoryCalls int
+		wantErr             error
+	}{
+		{
+			name:                "Create Device Fills Brand From Model Case",
+			brand:               "",
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:                "Create Device Brand Matches Model Case",
+			brand:               "google llc",
+			wantFindCalls:       1,
+			wantFindResult:      google,
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:           "Create Device Brand Does Not Match Model Case",
+			brand:          "Samsung",
+			wantFindCalls:  1,
+			wantFindResult: samsung,
+			wantErr:        errors.NewDeviceError(errors.ErrInvalid, `brand "Samsung" does not match the brand of model Pixel 7, Google`, nil),
+		},
+		{
+			name:          "Create Device Unknown Brand Does Not Match Model Case",
+			brand:         "Googel",
+			wantFindCalls: 1,
+			wantFindErr:   errBrandNotFound,
+			wantErr:       errors.NewDeviceError(errors.ErrInvalid, `brand "Googel" does not match the brand of model Pixel 7, Google`, nil),
+		},
+		{
+			name:            "Create Device Model Not Found Case",
+			wantGetModelErr: errModelNotFound,
+			wantErr:         errors.NewDeviceError(errors.ErrInvalid, "model d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05 does not exist", errModelNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			mockBrands := mocks.NewMockBrandResolver(mockCtrl)
+			mockModels := mocks.NewMockModelCatalogue(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), mockBrands, mockModels)
+
+			mockModels.
+				EXPECT().
+				GetByID(context.TODO(), pixel7.ID).
+				Return(pixel7, tt.wantGetModelErr)
+
+			mockBrands.
+				EXPECT().
+				Find(context.TODO(), tt.brand).
+				Return(tt.wantFindResult, tt.wantFindErr).
+				Times(tt.wantFindCalls)
+
+			mockRepo.
+				EXPECT().
+				CreateDevice(context.TODO(), gomock.Any()).
+				Return(nil).
+				Times(tt.wantRepositoryCalls)
+
+			device, err := service.Create(context.TODO(), entity.Device{Name: "Pixel 7 QA", Brand: tt.brand, ModelID: &pixel7.ID, State: entity.Available})
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, "Google", device.Brand)
+				assert.Equal(t, pixel7.BrandID, device.BrandID)
+				assert.Equal(t, &pixel7.ID, device.ModelID)
+			}
+		})
+	}
+}
+
+func Test_List_Device_Model_Filter(t *testing.T) {
+	pixel7ID := uuid.MustParse("d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05")
+	pixel7ProID := uuid.MustParse("e3b5a2f1-8c4d-4d9f-a072-6b1c3d4e5f06")
+
+	tests := []struct {
+		name           string
+		model          string
+		wantFindCalls  int
+		wantFindResult []modelentity.Model
+		wantFilter     entity.DeviceFilter
+	}{
+		{
+			name:       "List Filters by Model ID Case",
+			model:      pixel7ID.String(),
+			wantFilter: entity.DeviceFilter{ModelIDs: []uuid.UUID{pixel7ID}},
+		},
+		{
+			name:           "List Filters by Model Name Case",
+			model:          "pixel 7",
+			wantFindCalls:  1,
+			wantFindResult: []modelentity.Model{{ID: pixel7ID}, {ID: pixel7ProID}},
+			wantFilter:     entity.DeviceFilter{ModelIDs: []uuid.UUID{pixel7ID, pixel7ProID}},
+		},
+		{
+			name:          "List Unknown Model Matches No Device Case",
+			model:         "pixel 99",
+			wantFindCalls: 1,
+			wantFilter:    entity.DeviceFilter{ModelIDs: []uuid.UUID{uuid.Nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			mockModels := mocks.NewMockModelCatalogue(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mockModels)
+
+			mockModels.
+				EXPECT().
+				FindByName(context.TODO(), tt.model, nil).
+				Return(tt.wantFindResult, nil).
+				Times(tt.wantFindCalls)
+
+			mockRepo.
+				EXPECT().
+				ListDevices(context.TODO(), entity.ListOptions{Filter: tt.wantFilter}).
+				Return(nil, nil)
+
+			_, err := service.List(context.TODO(), entity.ListOptions{Filter: entity.DeviceFilter{Model: &tt.model}})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_List_Device_Brand_Filter(t *testing.T) {
+	hp := brandentity.Brand{ID: uuid.MustParse("3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"), Name: "Hewlett-Packard"}
+	errBrandNotFound := errors.NewDeviceError(errors.ErrNotFound, "brand not found", sql.ErrNoRows)
+	errBrandLookup := errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving brand", errDatabaseGeneric)
+
+	tests := []struct {
+		name           string
+		wantFindResult brandentity.Brand
+		wantFindErr    error
+		wantFilter     entity.DeviceFilter
+		wantErr        error
+	}{
+		{
+			name:           "List Filters by Catalogue Brand Case",
+			wantFindResult: hp,
+			wantFilter:     entity.DeviceFilter{BrandID: lo.ToPtr(hp.ID)},
+		},
+		{
+			name:        "List Unknown Brand Matches No Device Case",
+			wantFindErr: errBrandNotFound,
+			wantFilter:  entity.DeviceFilter{BrandID: lo.ToPtr(uuid.Nil)},
+		},
+		{
+			name:        "List Brand Lookup Error Case",
+			wantFindErr: errBrandLookup,
+			wantErr:     errBrandLookup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			mockBrands := mocks.NewMockBrandResolver(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), mockBrands, mocks.NewMockModelCatalogue(mockCtrl))
+
+			mockBrands.
+				EXPECT().
+				Find(context.TODO(), "hp").
+				Return(tt.wantFindResult, tt.wantFindErr)
+
+			if tt.wantErr == nil {
+				mockRepo.
+					EXPECT().
+					ListDevices(context.TODO(), entity.ListOptions{Filter: tt.wantFilter}).
+					Return(nil, nil)
+			}
+
+			_, err := service.List(context.TODO(), entity.ListOptions{Filter: entity.DeviceFilter{Brand: lo.ToPtr("hp")}})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_Update_Device(t *testing.T) {
+	type args struct {
+		context context.Context
+	}
+
+	testArgs := args{
+		context: context.TODO(),
+	}
+
+	tests := []struct {
+		name                    string
+		testArgs                args
+		device                  entity.Device
+		updateOnlyStatus        bool
+		wantedRepoGetByIdResult entity.Device
+		wantedRepoGetByIdError  error
+		wantedRepoUpdateErr     error
+		upcomingReservations    bool
+		wantErr                 error
+	}{
+		{
+			name:     "Update Device Success Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			updateOnlyStatus: false,
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21",
+				Brand: "Samsung",
+				State: entity.Available,
+			},
+			wantedRepoGetByIdError: nil,
+			wantedRepoUpdateErr:    nil,
+			wantErr:                nil,
+		},
+		{
+			name:     "Update Device Error Retrieving Device Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			updateOnlyStatus:        false,
+			wantedRepoGetByIdResult: entity.Device{},
+			wantedRepoGetByIdError:  errDatabaseGeneric,
+			wantedRepoUpdateErr:     nil,
+			wantErr:                 errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving device", errDatabaseGeneric),
+		},
+		{
+			name:     "Update Device Database Unavailable Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			updateOnlyStatus:        false,
+			wantedRepoGetByIdResult: entity.Device{},
+			wantedRepoGetByIdError:  driver.ErrBadConn,
+			wantedRepoUpdateErr:     nil,
+			wantErr:                 errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving device", driver.ErrBadConn),
+		},
+		{
+			name:     "Update Device Not Found Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			updateOnlyStatus:        false,
+			wantedRepoGetByIdResult: entity.Device{},
+			wantedRepoGetByIdError:  sql.ErrNoRows,
+			wantedRepoUpdateErr:     nil,
+			wantErr:                 errors.NewDeviceError(errors.ErrNotFound, "device not found", sql.ErrNoRows),
+		},
+		{
+			name:     "Update Only Device Not Occur When In Use Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			updateOnlyStatus: true,
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			wantedRepoGetByIdError: nil,
+			wantedRepoUpdateErr:    nil,
+			wantErr:                errors.NewDeviceError(errors.ErrInvalid, "device is in use and cannot be updated", fmt.Errorf("device state is the same: %s", entity.InUse)),
+		},
+		{
+			name:     "Update Only Device State with Success",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.Available,
+			},
+			updateOnlyStatus: true,
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			wantedRepoGetByIdError: nil,
+			wantedRepoUpdateErr:    nil,
+			wantErr:                nil,
+		},
+		{
+			name:     "Update Only Device State with Expected Error",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.Available,
+			},
+			updateOnlyStatus: true,
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			wantedRepoGetByIdError: nil,
+			wantedRepoUpdateErr:    errDatabaseGeneric,
+			wantErr:                errors.NewDeviceError(errors.ErrInternal, "something went wrong while update device state", fmt.Errorf("error updating device state: %w", errDatabaseGeneric)),
+		},
+		{
+			name:     "Update Only Device State with Expected Error",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			updateOnlyStatus: false,
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.Available,
+			},
+			wantedRepoGetByIdError: nil,
+			wantedRepoUpdateErr:    errDatabaseGeneric,
+			wantErr:                errors.NewDeviceError(errors.ErrInternal, "something went wrong while fully update device", errDatabaseGeneric),
+		},
+		{
+			name:     "Update Device Under Maintenance Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.Available,
+			},
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21",
+				Brand: "Samsung",
+				State: entity.Maintenance,
+			},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "device is under maintenance and cannot be updated, close its maintenance record first", nil),
+		},
+		{
+			name:     "Update Retired Device Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21 Updated",
+				Brand: "Samsung",
+				State: entity.Available,
+			},
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21",
+				Brand: "Samsung",
+				State: entity.Retired,
+			},
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "device is retired and cannot be updated", nil),
+		},
+		{
+			name:     "Update Device To Inactive With Upcoming Reservations Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21",
+				Brand: "Samsung",
+				State: entity.Inactive,
+			},
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21",
+				Brand: "Samsung",
+				State: entity.Available,
+			},
+			upcomingReservations: true,
+			wantErr:              errors.NewDeviceError(errors.ErrConflict, "device has upcoming reservations and cannot be made inactive", nil),
+		},
+		{
+			name:     "Update Device To Inactive Without Upcoming Reservations Case",
+			testArgs: testArgs,
+			device: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21",
+				Brand: "Samsung",
+				State: entity.Inactive,
+			},
+			updateOnlyStatus: true,
+			wantedRepoGetByIdResult: entity.Device{
+				ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+				Name:  "Galaxy S21",
+				Brand: "Samsung",
+				State: entity.InUse,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+			expectWithinTx(mockRepo, sql.LevelRepeatableRead)
+
+			mockRepo.
+				EXPECT().
+				GetDeviceForUpdate(tt.testArgs.context, gomock.Any()).
+				Return(tt.wantedRepoGetByIdResult, tt.wantedRepoGetByIdError).
+				AnyTimes()
+
+			mockRepo.
+				EXPECT().
+				HasUpcomingReservations(tt.testArgs.context, tt.device.ID).
+				Return(tt.upcomingReservations, nil).
+				AnyTimes()
+
+			if tt.updateOnlyStatus {
+				mockRepo.EXPECT().
+					UpdateDeviceState(tt.testArgs.context, tt.device.ID, tt.device.State).
+					Return(tt.device, tt.wantedRepoUpdateErr).
+					AnyTimes()
+			} else {
+				mockRepo.EXPECT().
+					FullyUpdateDevice(tt.testArgs.context, gomock.Any()).
+					Return(tt.wantedRepoUpdateErr).
+					AnyTimes()
+			}
+
+			updatedDevice, err := service.Update(tt.testArgs.context, tt.device)
+			assert.Equal(t, tt.wantErr, err)
+			if err == nil {
+				assert.Equal(t, tt.device.Name, updatedDevice.Name)
+				assert.Equal(t, tt.device.Brand, updatedDevice.Brand)
+				assert.Equal(t, tt.device.State, updatedDevice.State)
+			}
 		})
 	}
 }
@@ -441,11 +1347,14 @@ func Test_Delete_Device(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                string
-		testArgs            args
-		deviceId            uuid.UUID
-		wantedRepositoryErr error
-		wantErr             error
+		name                 string
+		testArgs             args
+		deviceId             uuid.UUID
+		deviceState          entity.DeviceState
+		wantedRepoGetErr     error
+		upcomingReservations bool
+		wantedRepositoryErr  error
+		wantErr              error
 	}{
 		{
 			name:                "Delete Device Success Case",
@@ -461,6 +1370,41 @@ func Test_Delete_Device(t *testing.T) {
 			wantedRepositoryErr: errDatabaseGeneric,
 			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while delete device", errDatabaseGeneric),
 		},
+		{
+			name:             "Delete Device Not Found Case",
+			testArgs:         testArgs,
+			deviceId:         uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+			wantedRepoGetErr: sql.ErrNoRows,
+			wantErr:          errors.NewDeviceError(errors.ErrNotFound, "device not found", sql.ErrNoRows),
+		},
+		{
+			name:        "Delete Device In Use Case",
+			testArgs:    testArgs,
+			deviceId:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+			deviceState: entity.InUse,
+			wantErr:     errors.NewDeviceError(errors.ErrConflict, "device is in use and cannot be deleted", nil),
+		},
+		{
+			name:        "Delete Device Under Maintenance Case",
+			testArgs:    testArgs,
+			deviceId:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+			deviceState: entity.Maintenance,
+			wantErr:     errors.NewDeviceError(errors.ErrConflict, "device is under maintenance and cannot be deleted, close its maintenance record first", nil),
+		},
+		{
+			name:                "Delete Device Deleted Meanwhile Case",
+			testArgs:            testArgs,
+			deviceId:            uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+			wantedRepositoryErr: sql.ErrNoRows,
+			wantErr:             errors.NewDeviceError(errors.ErrNotFound, "device not found", sql.ErrNoRows),
+		},
+		{
+			name:                 "Delete Device With Upcoming Reservations Case",
+			testArgs:             testArgs,
+			deviceId:             uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+			upcomingReservations: true,
+			wantErr:              errors.NewDeviceError(errors.ErrConflict, "device has upcoming reservations and cannot be deleted", nil),
+		},
 	}
 
 	for _, tt := range tests {
@@ -469,7 +1413,19 @@ func Test_Delete_Device(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
-			service := NewDeviceService(mockRepo)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+			expectWithinTx(mockRepo, sql.LevelRepeatableRead)
+
+			mockRepo.
+				EXPECT().
+				GetDeviceForUpdate(tt.testArgs.context, tt.deviceId).
+				Return(entity.Device{ID: tt.deviceId, State: lo.CoalesceOrEmpty(tt.deviceState, entity.Available)}, tt.wantedRepoGetErr)
+
+			mockRepo.
+				EXPECT().
+				HasUpcomingReservations(tt.testArgs.context, tt.deviceId).
+				Return(tt.upcomingReservations, nil).
+				AnyTimes()
 
 			mockRepo.
 				EXPECT().
@@ -482,3 +1438,251 @@ func Test_Delete_Device(t *testing.T) {
 		})
 	}
 }
+
+func Test_Update_Device_Transaction(t *testing.T) {
+	device := entity.Device{
+		ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+		Name:  "Galaxy S21",
+		Brand: "Samsung",
+		State: entity.Available,
+	}
+	errSerializationFailure := &pq.Error{Code: "40001"}
+
+	tests := []struct {
+		name    string
+		txErr   error
+		wantErr error
+	}{
+		{
+			name:    "Update Device Commit Error Case",
+			txErr:   errDatabaseGeneric,
+			wantErr: errors.NewDeviceError(errors.ErrInternal, "something went wrong while updating device", errDatabaseGeneric),
+		},
+		{
+			name:    "Update Device Serialization Failure Case",
+			txErr:   errSerializationFailure,
+			wantErr: errors.NewDeviceError(errors.ErrInternal, "something went wrong while updating device", errSerializationFailure),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+
+			mockRepo.
+				EXPECT().
+				WithinTx(context.TODO(), sql.LevelRepeatableRead, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ sql.IsolationLevel, fn func(repo repository.DeviceRepository) error) error {
+					if err := fn(mockRepo); err != nil {
+						return err
+					}
+					return tt.txErr
+				})
+			mockRepo.EXPECT().GetDeviceForUpdate(context.TODO(), device.ID).Return(device, nil)
+			mockRepo.EXPECT().FullyUpdateDevice(context.TODO(), gomock.Any()).Return(nil)
+
+			_, err := service.Update(context.TODO(), device)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_Update_Device_State_Serialization_Failure(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	device := entity.Device{
+		ID:    uuid.MustParse("215f759c-aa0f-494f-84ba-0d706dd6d59a"),
+		Name:  "Galaxy S21",
+		Brand: "Samsung",
+		State: entity.Available,
+	}
+
+	mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+	service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), catalogueBrands(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+	expectWithinTx(mockRepo, sql.LevelRepeatableRead)
+	mockRepo.EXPECT().GetDeviceForUpdate(context.TODO(), device.ID).Return(entity.Device{ID: device.ID, State: entity.InUse}, nil)
+	mockRepo.EXPECT().UpdateDeviceState(context.TODO(), device.ID, device.State).Return(entity.Device{}, &pq.Error{Code: "40001"})
+
+	_, err := service.Update(context.TODO(), device)
+
+	// the failure must reach the transaction retries and the error handlers
+	assert.True(t, database.IsSerializationFailure(err))
+}
+
+func Test_Move_Device(t *testing.T) {
+	deviceID := uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+	roomID := uuid.MustParse("5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401")
+	shelfID := uuid.MustParse("7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603")
+	errLocationNotFound := &pq.Error{Code: "23503", Constraint: "devices_location_id_fkey"}
+
+	tests := []struct {
+		name            string
+		currentLocation *uuid.UUID
+		currentState    entity.DeviceState
+		locationID      uuid.UUID
+		note            *string
+		wantGetErr      error
+		wantMoveCalls   int
+		wantMoveErr     error
+		wantNote        *string
+		wantErr         error
+	}{
+		{
+			name:          "Move Device Without Location Case",
+			locationID:    shelfID,
+			note:          lo.ToPtr("  unboxed  "),
+			wantMoveCalls: 1,
+			wantNote:      lo.ToPtr("unboxed"),
+		},
+		{
+			name:            "Move Device To Another Location Case",
+			currentLocation: &roomID,
+			locationID:      shelfID,
+			note:            lo.ToPtr(" "),
+			wantMoveCalls:   1,
+		},
+		{
+			name:            "Move Device To Its Own Location Case",
+			currentLocation: &shelfID,
+			locationID:      shelfID,
+			wantErr:         errors.NewDeviceError(errors.ErrInvalid, "device is already at location 7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603", nil),
+		},
+		{
+			name:         "Move Retired Device Case",
+			currentState: entity.Retired,
+			locationID:   shelfID,
+			wantErr:      errors.NewDeviceError(errors.ErrInvalid, "device is retired and cannot be moved", nil),
+		},
+		{
+			name:       "Move Device Note Too Long Case",
+			locationID: shelfID,
+			note:       lo.ToPtr(strings.Repeat("a", 501)),
+			wantErr:    errors.NewDeviceError(errors.ErrInvalid, "note must have at most 500 characters", nil),
+		},
+		{
+			name:       "Move Device Not Found Case",
+			locationID: shelfID,
+			wantGetErr: sql.ErrNoRows,
+			wantErr:    errors.NewDeviceError(errors.ErrNotFound, "device not found", sql.ErrNoRows),
+		},
+		{
+			name:          "Move Device Unknown Location Case",
+			locationID:    shelfID,
+			wantMoveCalls: 1,
+			wantMoveErr:   errLocationNotFound,
+			wantErr:       errors.NewDeviceError(errors.ErrInvalid, "location 7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603 does not exist", errLocationNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), mocks.NewMockBrandResolver(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+			expectWithinTx(mockRepo, sql.LevelRepeatableRead)
+
+			mockRepo.
+				EXPECT().
+				GetDeviceByID(context.TODO(), deviceID).
+				Return(entity.Device{ID: deviceID, LocationID: tt.currentLocation, State: tt.currentState}, tt.wantGetErr)
+
+			mockRepo.
+				EXPECT().
+				MoveDevice(context.TODO(), deviceID, tt.locationID, tt.wantNote).
+				Return(entity.Device{ID: deviceID, LocationID: &tt.locationID}, tt.wantMoveErr).
+				Times(tt.wantMoveCalls)
+
+			device, err := service.Move(context.TODO(), deviceID, tt.locationID, tt.note)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, &tt.locationID, device.LocationID)
+			}
+		})
+	}
+}
+
+func Test_Stats_Device(t *testing.T) {
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	allStates := func(available, inUse, inactive, maintenance int) map[entity.DeviceState]int {
+		return map[entity.DeviceState]int{entity.Available: available, entity.InUse: inUse, entity.Inactive: inactive, entity.Maintenance: maintenance, entity.Retired: 0}
+	}
+
+	tests := []struct {
+		name                string
+		to                  time.Time
+		wantRepositoryCalls int
+		wantRepositoryStats entity.DeviceStats
+		wantRepositoryErr   error
+		wantStats           entity.DeviceStats
+		wantErr             error
+	}{
+		{
+			name:                "Stats Fills Missing States And Days Case",
+			to:                  from.AddDate(0, 0, 2).Add(13 * time.Hour),
+			wantRepositoryCalls: 1,
+			wantRepositoryStats: entity.DeviceStats{
+				Total:   3,
+				ByState: map[entity.DeviceState]int{entity.Available: 2, entity.InUse: 1},
+				Daily: []entity.DailyStateCount{
+					{Day: from.AddDate(0, 0, 1), ByState: map[entity.DeviceState]int{entity.Available: 3}},
+					{Day: from.AddDate(0, 0, 2), ByState: map[entity.DeviceState]int{entity.Available: 2, entity.InUse: 1}},
+				},
+			},
+			wantStats: entity.DeviceStats{
+				Total:   3,
+				ByState: allStates(2, 1, 0, 0),
+				Daily: []entity.DailyStateCount{
+					{Day: from, ByState: allStates(0, 0, 0, 0)},
+					{Day: from.AddDate(0, 0, 1), ByState: allStates(3, 0, 0, 0)},
+					{Day: from.AddDate(0, 0, 2), ByState: allStates(2, 1, 0, 0)},
+				},
+			},
+		},
+		{
+			name:    "Stats Period Ends Before Start Case",
+			to:      from.AddDate(0, 0, -1),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "the end of the stats period must not be before its start", nil),
+		},
+		{
+			name:    "Stats Period Too Long Case",
+			to:      from.AddDate(0, 0, 366),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "the stats period must not be longer than 366 days", nil),
+		},
+		{
+			name:                "Stats Repository Error Case",
+			to:                  from,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while computing device stats", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDeviceRepository(mockCtrl)
+			service := NewDeviceService(mockRepo, mocks.NewMockAttributeDefinitionRepository(mockCtrl), mocks.NewMockBrandResolver(mockCtrl), mocks.NewMockModelCatalogue(mockCtrl))
+
+			mockRepo.
+				EXPECT().
+				GetDeviceStats(context.TODO(), entity.StatsOptions{From: from, To: *normalizeDate(&tt.to)}).
+				Return(tt.wantRepositoryStats, tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			stats, err := service.Stats(context.TODO(), entity.StatsOptions{From: from, To: tt.to})
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantStats, stats)
+			}
+		})
+	}
+}
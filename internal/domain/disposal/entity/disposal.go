@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DisposalReason string
+
+const (
+	Lost     DisposalReason = "lost"
+	Stolen   DisposalReason = "stolen"
+	Recycled DisposalReason = "recycled"
+	Sold     DisposalReason = "sold"
+)
+
+// DisposalReasons lists every reason a device can be retired for.
+var DisposalReasons = []DisposalReason{Lost, Stolen, Recycled, Sold}
+
+func (dr DisposalReason) String() string {
+	return string(dr)
+}
+
+func (dr DisposalReason) IsValid() bool {
+	for _, reason := range DisposalReasons {
+		if dr == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresDataWipe reports whether the device leaves the company working, so
+// its data must have been wiped before.
+func (dr DisposalReason) RequiresDataWipe() bool {
+	return dr == Recycled || dr == Sold
+}
+
+// RetiresFrom reports whether a device in state can be retired for the reason.
+// Available and inactive devices can always be retired, a device in use only
+// when it is lost or stolen.
+func (dr DisposalReason) RetiresFrom(state string) bool {
+	switch state {
+	case "available", "inactive":
+		return true
+	case "in-use":
+		return dr == Lost || dr == Stolen
+	}
+	return false
+}
+
+// DisposalRecord tells how a device left the inventory, the device is retired
+// from DisposedAt on.
+type DisposalRecord struct {
+	ID                   uuid.UUID      `json:"id"`
+	DeviceID             uuid.UUID      `json:"device_id"`
+	Reason               DisposalReason `json:"reason"`
+	DataWiped            bool           `json:"data_wiped"`
+	CertificateReference *string        `json:"certificate_reference"`
+	Note                 *string        `json:"note"`
+	DisposedAt           time.Time      `json:"disposed_at"`
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal/entity"
+)
+
+//go:generate mockgen -source=disposal_repository.go -destination=../../mocks/disposal_repository_mock.go -package=mocks
+
+// ErrDeviceNotRetirable is returned when retiring a device whose state does
+// not allow it for the disposal reason.
+var ErrDeviceNotRetirable = errors.New("device state does not allow retiring it for this reason")
+
+type DisposalRepository interface {
+	RetireDevice(ctx context.Context, record *entity.DisposalRecord) error
+	GetDisposalByDeviceID(ctx context.Context, deviceID uuid.UUID) (entity.DisposalRecord, error)
+}
+
+// disposalColumns is the column list read into an entity.DisposalRecord by disposalScanFields, in the same order.
+const disposalColumns = `id, device_id, reason, data_wiped, certificate_reference, note, disposed_at`
+
+func disposalScanFields(record *entity.DisposalRecord) []any {
+	return []any{
+		&record.ID,
+		&record.DeviceID,
+		&record.Reason,
+		&record.DataWiped,
+		&record.CertificateReference,
+		&record.Note,
+		&record.DisposedAt,
+	}
+}
+
+type postgresDisposalRepository struct {
+	db *sql.DB
+}
+
+func NewDisposalRepository(db *sql.DB) *postgresDisposalRepository {
+	return &postgresDisposalRepository{db: db}
+}
+
+// RetireDevice retires the device, cancels its bookings that have not ended
+// and records the disposal, in a single transaction.
+// ErrDeviceNotRetirable is returned when the device state does not allow the
+// disposal reason, sql.ErrNoRows when the device does not exist.
+func (r *postgresDisposalRepository) RetireDevice(ctx context.Context, record *entity.DisposalRecord) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	return database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		var state string
+		err := tx.QueryRowContext(ctx, `
+	SELECT state
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`, record.DeviceID).Scan(&state)
+		if err != nil {
+			return err
+		}
+
+		if !record.Reason.RetiresFrom(state) {
+			return ErrDeviceNotRetirable
+		}
+
+		_, err = tx.ExecContext(ctx, `
+	UPDATE devices SET
+		state = 'retired',
+		updated_at = now()
+	WHERE id = $1;`, record.DeviceID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+	UPDATE reservations SET
+		status = 'cancelled',
+		updated_at = now()
+	WHERE device_id = $1 AND status = 'booked' AND upper(period) > now();`, record.DeviceID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+	INSERT INTO disposal_records (id, device_id, reason, data_wiped, certificate_reference, note)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING disposed_at;`,
+			record.ID,
+			record.DeviceID,
+			record.Reason.String(),
+			record.DataWiped,
+			record.CertificateReference,
+			record.Note,
+		).Scan(&record.DisposedAt)
+		return err
+	})
+}
+
+func (r *postgresDisposalRepository) GetDisposalByDeviceID(ctx context.Context, deviceID uuid.UUID) (entity.DisposalRecord, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var record entity.DisposalRecord
+
+	query := `
+	SELECT ` + disposalColumns + `
+	FROM disposal_records
+	WHERE device_id = $1;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return record, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, deviceID).Scan(disposalScanFields(&record)...)
+	if err != nil {
+		return record, err
+	}
+
+	return record, nil
+}
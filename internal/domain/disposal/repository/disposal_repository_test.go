@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal/entity"
+)
+
+var disposalRowColumns = []string{"id", "device_id", "reason", "data_wiped", "certificate_reference", "note", "disposed_at"}
+
+var (
+	recordID   = uuid.MustParse("2d3e4f50-6a7b-4c8d-9e0f-1a2b3c4d5e06")
+	deviceID   = uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+	disposedAt = lo.Must(time.Parse(time.DateTime, "2025-09-10 16:00:00"))
+)
+
+func Test_Retire_Device(t *testing.T) {
+	assert := assert.New(t)
+
+	lockQuery := regexp.QuoteMeta(`
+	SELECT state
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`)
+
+	stateQuery := regexp.QuoteMeta(`
+	UPDATE devices SET
+		state = 'retired',
+		updated_at = now()
+	WHERE id = $1;`)
+
+	cancelQuery := regexp.QuoteMeta(`
+	UPDATE reservations SET
+		status = 'cancelled',
+		updated_at = now()
+	WHERE device_id = $1 AND status = 'booked' AND upper(period) > now();`)
+
+	insertQuery := regexp.QuoteMeta(`
+	INSERT INTO disposal_records (id, device_id, reason, data_wiped, certificate_reference, note)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING disposed_at;`)
+
+	testCases := []struct {
+		name      string
+		reason    entity.DisposalReason
+		sqlMock   func(mock sqlmock.Sqlmock)
+		wantedErr error
+	}{
+		{
+			name:   "Retire Device Success Case",
+			reason: entity.Recycled,
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("inactive"))
+				mock.ExpectExec(stateQuery).
+					WithArgs(deviceID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(cancelQuery).
+					WithArgs(deviceID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery(insertQuery).
+					WithArgs(recordID, deviceID, "recycled", true, lo.ToPtr("WEEE-2025-0193"), nil).
+					WillReturnRows(sqlmock.NewRows([]string{"disposed_at"}).AddRow(disposedAt))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Retire Device In Use Lost Case",
+			reason: entity.Lost,
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("in-use"))
+				mock.ExpectExec(stateQuery).
+					WithArgs(deviceID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(cancelQuery).
+					WithArgs(deviceID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(insertQuery).
+					WithArgs(recordID, deviceID, "lost", true, lo.ToPtr("WEEE-2025-0193"), nil).
+					WillReturnRows(sqlmock.NewRows([]string{"disposed_at"}).AddRow(disposedAt))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Retire Device In Use Recycled Case",
+			reason: entity.Recycled,
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("in-use"))
+				mock.ExpectRollback()
+			},
+			wantedErr: ErrDeviceNotRetirable,
+		},
+		{
+			name:   "Retire Device Under Maintenance Case",
+			reason: entity.Stolen,
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("maintenance"))
+				mock.ExpectRollback()
+			},
+			wantedErr: ErrDeviceNotRetirable,
+		},
+		{
+			name:   "Retire Device Not Found Case",
+			reason: entity.Recycled,
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			tt.sqlMock(mock)
+
+			repository := NewDisposalRepository(db)
+			record := entity.DisposalRecord{
+				ID:                   recordID,
+				DeviceID:             deviceID,
+				Reason:               tt.reason,
+				DataWiped:            true,
+				CertificateReference: lo.ToPtr("WEEE-2025-0193"),
+			}
+			err = repository.RetireDevice(context.TODO(), &record)
+
+			assert.Equal(tt.wantedErr, err)
+			if tt.wantedErr == nil {
+				assert.Equal(disposedAt, record.DisposedAt)
+			}
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Get_Disposal_By_Device_ID(t *testing.T) {
+	assert := assert.New(t)
+
+	getQuery := regexp.QuoteMeta(`
+	SELECT id, device_id, reason, data_wiped, certificate_reference, note, disposed_at
+	FROM disposal_records
+	WHERE device_id = $1;`)
+
+	db, mock, err := sqlmock.New()
+	assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+	mock.ExpectPrepare(getQuery).
+		WillBeClosed().
+		ExpectQuery().
+		WithArgs(deviceID).
+		WillReturnRows(sqlmock.NewRows(disposalRowColumns).
+			AddRow(recordID, deviceID, "stolen", false, nil, "reported to the police", disposedAt))
+
+	repository := NewDisposalRepository(db)
+	record, err := repository.GetDisposalByDeviceID(context.TODO(), deviceID)
+
+	assert.NoError(err)
+	assert.Equal(entity.DisposalRecord{
+		ID:         recordID,
+		DeviceID:   deviceID,
+		Reason:     entity.Stolen,
+		DataWiped:  false,
+		Note:       lo.ToPtr("reported to the police"),
+		DisposedAt: disposedAt,
+	}, record)
+
+	mock.ExpectClose()
+
+	err = db.Close()
+	assert.NoErrorf(err, "db was not closed")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(err, "there were unfulfilled expectations")
+}
@@ -0,0 +1,90 @@
+package disposal
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/text"
+)
+
+const (
+	maxCertificateReferenceLength = 100
+	maxNoteLength                 = 500
+)
+
+type DisposalService interface {
+	Retire(ctx context.Context, record entity.DisposalRecord) (entity.DisposalRecord, error)
+	GetByDeviceID(ctx context.Context, deviceID uuid.UUID) (entity.DisposalRecord, error)
+}
+
+type disposalService struct {
+	repo repository.DisposalRepository
+}
+
+func NewDisposalService(repo repository.DisposalRepository) *disposalService {
+	return &disposalService{repo: repo}
+}
+
+// Retire disposes of a device, which becomes retired for good. Recycled and
+// sold devices must have had their data wiped.
+func (s *disposalService) Retire(ctx context.Context, record entity.DisposalRecord) (entity.DisposalRecord, error) {
+	record.ID = uuid.New()
+	if err := normalizeAndValidate(&record); err != nil {
+		return record, err
+	}
+
+	err := s.repo.RetireDevice(ctx, &record)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return record, errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+		}
+		if goerrors.Is(err, repository.ErrDeviceNotRetirable) {
+			return record, errors.NewDeviceError(errors.ErrConflict, "only available or inactive devices can be retired, devices in use only when lost or stolen", err)
+		}
+		var pqErr *pq.Error
+		if goerrors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return record, errors.NewDeviceError(errors.ErrConflict, "device is already retired", err)
+		}
+		return record, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retiring device", err)
+	}
+	return record, nil
+}
+
+func (s *disposalService) GetByDeviceID(ctx context.Context, deviceID uuid.UUID) (entity.DisposalRecord, error) {
+	record, err := s.repo.GetDisposalByDeviceID(ctx, deviceID)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.DisposalRecord{}, errors.NewDeviceError(errors.ErrNotFound, "device has no disposal record", err)
+		}
+		return entity.DisposalRecord{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving disposal record", err)
+	}
+	return record, nil
+}
+
+func normalizeAndValidate(record *entity.DisposalRecord) error {
+	if !record.Reason.IsValid() {
+		return errors.NewDeviceError(errors.ErrInvalid, "invalid disposal reason, must be one of: lost, stolen, recycled, sold", fmt.Errorf("invalid disposal reason: %s", record.Reason))
+	}
+	if record.Reason.RequiresDataWipe() && !record.DataWiped {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("data wipe must be confirmed before a device is %s", record.Reason), nil)
+	}
+
+	var err error
+	record.CertificateReference, err = text.Normalize(record.CertificateReference, "certificate reference", maxCertificateReferenceLength)
+	if err != nil {
+		return err
+	}
+	record.Note, err = text.Normalize(record.Note, "note", maxNoteLength)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package disposal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+)
+
+var errDatabaseGeneric = fmt.Errorf("some database error")
+
+var deviceID = uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+
+func Test_Retire_Device(t *testing.T) {
+	recycling := entity.DisposalRecord{
+		DeviceID:             deviceID,
+		Reason:               entity.Recycled,
+		DataWiped:            true,
+		CertificateReference: lo.ToPtr("  WEEE-2025-0193 "),
+		Note:                 lo.ToPtr(" "),
+	}
+
+	withChange := func(change func(r *entity.DisposalRecord)) entity.DisposalRecord {
+		record := recycling
+		change(&record)
+		return record
+	}
+
+	tests := []struct {
+		name                string
+		record              entity.DisposalRecord
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantErr             error
+	}{
+		{
+			name:                "Retire Device Success Case",
+			record:              recycling,
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:                "Retire Stolen Device Without Data Wipe Case",
+			record:              withChange(func(r *entity.DisposalRecord) { r.Reason = entity.Stolen; r.DataWiped = false }),
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:    "Retire Device Invalid Reason Case",
+			record:  withChange(func(r *entity.DisposalRecord) { r.Reason = "donated" }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "invalid disposal reason, must be one of: lost, stolen, recycled, sold", fmt.Errorf("invalid disposal reason: donated")),
+		},
+		{
+			name:    "Retire Sold Device Without Data Wipe Case",
+			record:  withChange(func(r *entity.DisposalRecord) { r.Reason = entity.Sold; r.DataWiped = false }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "data wipe must be confirmed before a device is sold", nil),
+		},
+		{
+			name:    "Retire Device Certificate Reference Too Long Case",
+			record:  withChange(func(r *entity.DisposalRecord) { r.CertificateReference = lo.ToPtr(strings.Repeat("a", 101)) }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "certificate reference must have at most 100 characters", nil),
+		},
+		{
+			name:                "Retire Device Not Found Case",
+			record:              recycling,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   sql.ErrNoRows,
+			wantErr:             errors.NewDeviceError(errors.ErrNotFound, "device not found", sql.ErrNoRows),
+		},
+		{
+			name:                "Retire Device Not Retirable Case",
+			record:              recycling,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   repository.ErrDeviceNotRetirable,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "only available or inactive devices can be retired, devices in use only when lost or stolen", repository.ErrDeviceNotRetirable),
+		},
+		{
+			name:                "Retire Device Repository Error Case",
+			record:              recycling,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while retiring device", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDisposalRepository(mockCtrl)
+			service := NewDisposalService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				RetireDevice(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, record *entity.DisposalRecord) error {
+					record.DisposedAt = time.Now()
+					return tt.wantRepositoryErr
+				}).
+				Times(tt.wantRepositoryCalls)
+
+			record, err := service.Retire(context.TODO(), tt.record)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEqual(t, uuid.Nil, record.ID)
+				assert.False(t, record.DisposedAt.IsZero())
+				assert.Nil(t, record.Note)
+				if tt.record.Reason == entity.Recycled {
+					assert.Equal(t, lo.ToPtr("WEEE-2025-0193"), record.CertificateReference)
+				}
+			}
+		})
+	}
+}
+
+func Test_Get_Disposal_By_Device_ID(t *testing.T) {
+	tests := []struct {
+		name              string
+		wantRepositoryErr error
+		wantErr           error
+	}{
+		{
+			name: "Get Disposal Success Case",
+		},
+		{
+			name:              "Get Disposal Not Found Case",
+			wantRepositoryErr: sql.ErrNoRows,
+			wantErr:           errors.NewDeviceError(errors.ErrNotFound, "device has no disposal record", sql.ErrNoRows),
+		},
+		{
+			name:              "Get Disposal Repository Error Case",
+			wantRepositoryErr: errDatabaseGeneric,
+			wantErr:           errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving disposal record", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockDisposalRepository(mockCtrl)
+			service := NewDisposalService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				GetDisposalByDeviceID(context.TODO(), deviceID).
+				Return(entity.DisposalRecord{DeviceID: deviceID, Reason: entity.Lost}, tt.wantRepositoryErr)
+
+			record, err := service.GetByDeviceID(context.TODO(), deviceID)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, entity.Lost, record.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LocationKind string
+
+const (
+	Site  LocationKind = "site"
+	Room  LocationKind = "room"
+	Shelf LocationKind = "shelf"
+)
+
+func (lk LocationKind) String() string {
+	return string(lk)
+}
+
+// LocationKinds lists every location kind, from the top of the hierarchy down.
+var LocationKinds = []LocationKind{Site, Room, Shelf}
+
+// ParentKind is the kind the parent of a location of this kind must have, sites
+// have no parent.
+func (lk LocationKind) ParentKind() (LocationKind, bool) {
+	switch lk {
+	case Room:
+		return Site, true
+	case Shelf:
+		return Room, true
+	default:
+		return "", false
+	}
+}
+
+type Location struct {
+	ID       uuid.UUID    `json:"id"`
+	ParentID *uuid.UUID   `json:"parent_id"`
+	Kind     LocationKind `json:"kind"`
+	Name     string       `json:"name"`
+	// DeviceCounts holds the number of devices per state kept at the location or
+	// any location below it, states without devices are left out.
+	DeviceCounts map[string]int `json:"device_counts"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    *time.Time     `json:"updated_at"`
+}
+
+// LocationFilter narrows a location listing, nil criteria are not applied.
+type LocationFilter struct {
+	// IDs matches any of the locations, when informed.
+	IDs      []uuid.UUID
+	ParentID *uuid.UUID
+	Kind     *LocationKind
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location/entity"
+)
+
+//go:generate mockgen -source=location_repository.go -destination=../../mocks/location_repository_mock.go -package=mocks
+
+type LocationRepository interface {
+	CreateLocation(ctx context.Context, location *entity.Location) error
+	GetLocationByID(ctx context.Context, id uuid.UUID) (entity.Location, error)
+	ListLocations(ctx context.Context, filter entity.LocationFilter) ([]entity.Location, error)
+	UpdateLocation(ctx context.Context, location *entity.Location) error
+	DeleteLocation(ctx context.Context, id uuid.UUID) error
+}
+
+// locationQuery reads the locations matching where, each with the device counts
+// of its subtree. The recursive CTE walks down from every matching location,
+// pairing it (root_id) with itself and each location below it.
+func locationQuery(where string) string {
+	return `
+	WITH RECURSIVE subtree AS (
+		SELECT l.id AS root_id, l.id
+		FROM locations l` + where + `
+		UNION ALL
+		SELECT s.root_id, child.id
+		FROM subtree s
+		JOIN locations child ON child.parent_id = s.id
+	),
+	device_counts AS (
+		SELECT s.root_id, d.state, count(*) AS devices
+		FROM subtree s
+		JOIN devices d ON d.location_id = s.id AND d.deleted_at IS NULL
+		GROUP BY s.root_id, d.state
+	)
+	SELECT l.id, l.parent_id, l.kind, l.name, l.created_at, l.updated_at,
+		COALESCE((SELECT jsonb_object_agg(c.state, c.devices) FROM device_counts c WHERE c.root_id = l.id), '{}')
+	FROM locations l` + where + `
+	ORDER BY l.name;`
+}
+
+func locationScanFields(location *entity.Location) []any {
+	return []any{
+		&location.ID,
+		&location.ParentID,
+		&location.Kind,
+		&location.Name,
+		&location.CreatedAt,
+		&location.UpdatedAt,
+		&deviceCountsColumn{dest: &location.DeviceCounts},
+	}
+}
+
+// deviceCountsColumn scans the JSON object of device counts per state.
+type deviceCountsColumn struct {
+	dest *map[string]int
+}
+
+func (dc *deviceCountsColumn) Scan(src any) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, dc.dest)
+	case string:
+		return json.Unmarshal([]byte(value), dc.dest)
+	default:
+		return fmt.Errorf("unsupported device counts value of type %T", src)
+	}
+}
+
+type postgresLocationRepository struct {
+	db *sql.DB
+}
+
+func NewLocationRepository(db *sql.DB) *postgresLocationRepository {
+	return &postgresLocationRepository{db: db}
+}
+
+func (r *postgresLocationRepository) CreateLocation(ctx context.Context, location *entity.Location) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	const query = `
+	INSERT INTO locations (id, parent_id, kind, name)
+	VALUES ($1, $2, $3, $4)
+	RETURNING created_at, updated_at;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	return stmt.QueryRowContext(ctx,
+		location.ID,
+		location.ParentID,
+		location.Kind.String(),
+		location.Name,
+	).Scan(&location.CreatedAt, &location.UpdatedAt)
+}
+
+func (r *postgresLocationRepository) GetLocationByID(ctx context.Context, id uuid.UUID) (entity.Location, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var location entity.Location
+
+	stmt, err := r.db.PrepareContext(ctx, locationQuery("\n\t\tWHERE l.id = $1"))
+	if err != nil {
+		return location, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, id).Scan(locationScanFields(&location)...)
+	if err != nil {
+		return location, err
+	}
+
+	return location, nil
+}
+
+func (r *postgresLocationRepository) ListLocations(ctx context.Context, filter entity.LocationFilter) ([]entity.Location, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var locations []entity.Location
+
+	conds := []string{}
+	params := []any{}
+	if len(filter.IDs) > 0 {
+		params = append(params, pq.Array(filter.IDs))
+		conds = append(conds, fmt.Sprintf("l.id = ANY($%d::uuid[])", len(params)))
+	}
+	if filter.ParentID != nil {
+		params = append(params, *filter.ParentID)
+		conds = append(conds, fmt.Sprintf("l.parent_id = $%d", len(params)))
+	}
+	if filter.Kind != nil {
+		params = append(params, filter.Kind.String())
+		conds = append(conds, fmt.Sprintf("l.kind = $%d", len(params)))
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "\n\t\tWHERE " + strings.Join(conds, " AND ")
+	}
+
+	stmt, err := r.db.PrepareContext(ctx, locationQuery(where))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l entity.Location
+		err = rows.Scan(locationScanFields(&l)...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		locations = append(locations, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (r *postgresLocationRepository) UpdateLocation(ctx context.Context, location *entity.Location) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	const query = `
+	UPDATE locations SET
+		parent_id = $2,
+		name = $3,
+		updated_at = now()
+	WHERE id = $1
+	RETURNING created_at, updated_at;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	return stmt.QueryRowContext(ctx,
+		location.ID,
+		location.ParentID,
+		location.Name,
+	).Scan(&location.CreatedAt, &location.UpdatedAt)
+}
+
+// DeleteLocation fails with a foreign key violation while locations or devices
+// reference the location.
+func (r *postgresLocationRepository) DeleteLocation(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	query := `DELETE FROM locations WHERE id = $1;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount <= 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location/entity"
+)
+
+var locationRowColumns = []string{"id", "parent_id", "kind", "name", "created_at", "updated_at", "device_counts"}
+
+var (
+	lisbonSiteID = uuid.MustParse("4c0d1e20-3f4a-4b5c-9d6e-7f8091a2b300")
+	labRoomID    = uuid.MustParse("5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401")
+	createdAt    = lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+)
+
+func Test_Get_Location_By_ID(t *testing.T) {
+	assert := assert.New(t)
+
+	getQuery := regexp.QuoteMeta(`
+	WITH RECURSIVE subtree AS (
+		SELECT l.id AS root_id, l.id
+		FROM locations l
+		WHERE l.id = $1
+		UNION ALL
+		SELECT s.root_id, child.id
+		FROM subtree s
+		JOIN locations child ON child.parent_id = s.id
+	),
+	device_counts AS (
+		SELECT s.root_id, d.state, count(*) AS devices
+		FROM subtree s
+		JOIN devices d ON d.location_id = s.id AND d.deleted_at IS NULL
+		GROUP BY s.root_id, d.state
+	)
+	SELECT l.id, l.parent_id, l.kind, l.name, l.created_at, l.updated_at,
+		COALESCE((SELECT jsonb_object_agg(c.state, c.devices) FROM device_counts c WHERE c.root_id = l.id), '{}')
+	FROM locations l
+		WHERE l.id = $1
+	ORDER BY l.name;`)
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult entity.Location
+	}{
+		{
+			name: "Get Location By ID Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(getQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(labRoomID).
+					WillReturnRows(sqlmock.NewRows(locationRowColumns).
+						AddRow(labRoomID, lisbonSiteID, "room", "QA Lab", createdAt, nil, []byte(`{"available": 3, "in-use": 2}`)))
+			},
+			wantedResult: entity.Location{
+				ID:           labRoomID,
+				ParentID:     &lisbonSiteID,
+				Kind:         entity.Room,
+				Name:         "QA Lab",
+				DeviceCounts: map[string]int{"available": 3, "in-use": 2},
+				CreatedAt:    createdAt,
+			},
+		},
+		{
+			name: "Get Location By ID Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(getQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(labRoomID).
+					WillReturnRows(sqlmock.NewRows(locationRowColumns))
+			},
+			wantedErr:    sql.ErrNoRows,
+			wantedResult: entity.Location{},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewLocationRepository(db)
+
+			tt.sqlMock(mock)
+
+			location, err := repository.GetLocationByID(context.TODO(), labRoomID)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, location)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_List_Locations(t *testing.T) {
+	assert := assert.New(t)
+
+	listQuery := regexp.QuoteMeta(`
+	WITH RECURSIVE subtree AS (
+		SELECT l.id AS root_id, l.id
+		FROM locations l
+		WHERE l.parent_id = $1 AND l.kind = $2
+		UNION ALL
+		SELECT s.root_id, child.id
+		FROM subtree s
+		JOIN locations child ON child.parent_id = s.id
+	),
+	device_counts AS (
+		SELECT s.root_id, d.state, count(*) AS devices
+		FROM subtree s
+		JOIN devices d ON d.location_id = s.id AND d.deleted_at IS NULL
+		GROUP BY s.root_id, d.state
+	)
+	SELECT l.id, l.parent_id, l.kind, l.name, l.created_at, l.updated_at,
+		COALESCE((SELECT jsonb_object_agg(c.state, c.devices) FROM device_counts c WHERE c.root_id = l.id), '{}')
+	FROM locations l
+		WHERE l.parent_id = $1 AND l.kind = $2
+	ORDER BY l.name;`)
+
+	db, mock, err := sqlmock.New()
+	assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+	mock.ExpectPrepare(listQuery).
+		WillBeClosed().
+		ExpectQuery().
+		WithArgs(lisbonSiteID, "room").
+		WillReturnRows(sqlmock.NewRows(locationRowColumns).
+			AddRow(labRoomID, lisbonSiteID, "room", "QA Lab", createdAt, nil, "{}"))
+
+	repository := NewLocationRepository(db)
+	locations, err := repository.ListLocations(context.TODO(), entity.LocationFilter{ParentID: &lisbonSiteID, Kind: lo.ToPtr(entity.Room)})
+
+	assert.NoError(err)
+	assert.Equal([]entity.Location{{
+		ID:           labRoomID,
+		ParentID:     &lisbonSiteID,
+		Kind:         entity.Room,
+		Name:         "QA Lab",
+		DeviceCounts: map[string]int{},
+		CreatedAt:    createdAt,
+	}}, locations)
+
+	mock.ExpectClose()
+
+	err = db.Close()
+	assert.NoErrorf(err, "db was not closed")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(err, "there were unfulfilled expectations")
+}
@@ -0,0 +1,175 @@
+package location
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location/repository"
+)
+
+const maxLocationNameLength = 100
+
+type LocationService interface {
+	List(ctx context.Context, filter entity.LocationFilter) ([]entity.Location, error)
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Location, error)
+	Create(ctx context.Context, location entity.Location) (entity.Location, error)
+	Update(ctx context.Context, location entity.Location) (entity.Location, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type locationService struct {
+	repo repository.LocationRepository
+}
+
+func NewLocationService(repo repository.LocationRepository) *locationService {
+	return &locationService{repo: repo}
+}
+
+func (s *locationService) List(ctx context.Context, filter entity.LocationFilter) ([]entity.Location, error) {
+	locations, err := s.repo.ListLocations(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing locations", err)
+	}
+	return locations, nil
+}
+
+func (s *locationService) GetByID(ctx context.Context, id uuid.UUID) (entity.Location, error) {
+	location, err := s.repo.GetLocationByID(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Location{}, errors.NewDeviceError(errors.ErrNotFound, "location not found", err)
+		}
+		return entity.Location{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving location", err)
+	}
+	return location, nil
+}
+
+func (s *locationService) Create(ctx context.Context, location entity.Location) (entity.Location, error) {
+	location.ID = uuid.New()
+	if err := s.normalizeAndValidate(ctx, &location); err != nil {
+		return location, err
+	}
+
+	err := s.repo.CreateLocation(ctx, &location)
+	if err != nil {
+		if conflictErr := locationConflictError(err); conflictErr != nil {
+			return location, conflictErr
+		}
+		return location, errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating location", err)
+	}
+
+	location.DeviceCounts = map[string]int{}
+	return location, nil
+}
+
+// Update renames a location or moves it, with everything inside it, to another
+// parent of the same kind. The kind of a location never changes.
+func (s *locationService) Update(ctx context.Context, location entity.Location) (entity.Location, error) {
+	existing, err := s.GetByID(ctx, location.ID)
+	if err != nil {
+		return entity.Location{}, err
+	}
+
+	if location.Kind != existing.Kind {
+		return entity.Location{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("the kind of a location cannot be changed, %s is a %s", existing.Name, existing.Kind), nil)
+	}
+
+	if err := s.normalizeAndValidate(ctx, &location); err != nil {
+		return entity.Location{}, err
+	}
+
+	err = s.repo.UpdateLocation(ctx, &location)
+	if err != nil {
+		if conflictErr := locationConflictError(err); conflictErr != nil {
+			return entity.Location{}, conflictErr
+		}
+		return entity.Location{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while updating location", err)
+	}
+
+	// the subtree moves along with the location, so its devices are the same
+	location.DeviceCounts = existing.DeviceCounts
+	return location, nil
+}
+
+func (s *locationService) Delete(ctx context.Context, id uuid.UUID) error {
+	err := s.repo.DeleteLocation(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.NewDeviceError(errors.ErrNotFound, "location not found", err)
+		}
+		var pqErr *pq.Error
+		if goerrors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return errors.NewDeviceError(errors.ErrConflict, "location has locations or devices inside and cannot be deleted", err)
+		}
+		return errors.NewDeviceError(errors.ErrInternal, "something went wrong while deleting location", err)
+	}
+	return nil
+}
+
+// normalizeAndValidate cleans the name and checks the location sits where its
+// kind belongs: sites at the top, rooms inside sites and shelves inside rooms.
+func (s *locationService) normalizeAndValidate(ctx context.Context, location *entity.Location) error {
+	location.Name = strings.Join(strings.Fields(location.Name), " ")
+	if location.Name == "" {
+		return errors.NewDeviceError(errors.ErrInvalid, "location name must not be empty", nil)
+	}
+	if len(location.Name) > maxLocationNameLength {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("location name must have at most %d characters", maxLocationNameLength), nil)
+	}
+
+	if !slices.Contains(entity.LocationKinds, location.Kind) {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid location kind %q, must be one of: site, room, shelf", location.Kind), nil)
+	}
+
+	parentKind, hasParent := location.Kind.ParentKind()
+	if !hasParent {
+		if location.ParentID != nil {
+			return errors.NewDeviceError(errors.ErrInvalid, "a site cannot be inside another location", nil)
+		}
+		return nil
+	}
+
+	if location.ParentID == nil {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("a %s must be inside a %s, parent_id is required", location.Kind, parentKind), nil)
+	}
+
+	parent, err := s.repo.GetLocationByID(ctx, *location.ParentID)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("parent location %s does not exist", *location.ParentID), err)
+		}
+		return errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving location", err)
+	}
+	if parent.Kind != parentKind {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("a %s must be inside a %s, %s is a %s", location.Kind, parentKind, parent.Name, parent.Kind), nil)
+	}
+
+	return nil
+}
+
+// locationConflictError maps the unique violation (SQLSTATE 23505) of a name
+// repeated within a parent and the foreign key violation (SQLSTATE 23503) of a
+// parent deleted meanwhile to conflict errors, or returns nil for any other error.
+func locationConflictError(err error) *errors.DeviceError {
+	var pqErr *pq.Error
+	if !goerrors.As(err, &pqErr) {
+		return nil
+	}
+
+	switch pqErr.Code {
+	case "23505":
+		return errors.NewDeviceError(errors.ErrConflict, "a location with this name already exists in the parent location", err)
+	case "23503":
+		return errors.NewDeviceError(errors.ErrConflict, "the parent location was deleted", err)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,217 @@
+package location
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+)
+
+var (
+	lisbonSite = entity.Location{ID: uuid.MustParse("4c0d1e20-3f4a-4b5c-9d6e-7f8091a2b300"), Kind: entity.Site, Name: "Lisbon Office"}
+	labRoom    = entity.Location{ID: uuid.MustParse("5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401"), ParentID: &lisbonSite.ID, Kind: entity.Room, Name: "QA Lab", DeviceCounts: map[string]int{"in-use": 2}}
+)
+
+func Test_Create_Location(t *testing.T) {
+	errNameUniqueViolation := &pq.Error{Code: "23505", Constraint: "uq_locations_parent_name"}
+	missingID := uuid.MustParse("9a0b1c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d")
+
+	tests := []struct {
+		name                string
+		location            entity.Location
+		parents             map[uuid.UUID]entity.Location
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantName            string
+		wantErr             error
+	}{
+		{
+			name:                "Create Site Case",
+			location:            entity.Location{Kind: entity.Site, Name: "  Porto   Office "},
+			wantRepositoryCalls: 1,
+			wantName:            "Porto Office",
+		},
+		{
+			name:                "Create Shelf Inside Room Case",
+			location:            entity.Location{Kind: entity.Shelf, Name: "Shelf A", ParentID: &labRoom.ID},
+			parents:             map[uuid.UUID]entity.Location{labRoom.ID: labRoom},
+			wantRepositoryCalls: 1,
+			wantName:            "Shelf A",
+		},
+		{
+			name:     "Create Location Empty Name Case",
+			location: entity.Location{Kind: entity.Site, Name: " "},
+			wantErr:  errors.NewDeviceError(errors.ErrInvalid, "location name must not be empty", nil),
+		},
+		{
+			name:     "Create Location Invalid Kind Case",
+			location: entity.Location{Kind: "locker", Name: "Locker 1"},
+			wantErr:  errors.NewDeviceError(errors.ErrInvalid, `invalid location kind "locker", must be one of: site, room, shelf`, nil),
+		},
+		{
+			name:     "Create Site Inside Another Location Case",
+			location: entity.Location{Kind: entity.Site, Name: "Annex", ParentID: &lisbonSite.ID},
+			wantErr:  errors.NewDeviceError(errors.ErrInvalid, "a site cannot be inside another location", nil),
+		},
+		{
+			name:     "Create Room Without Parent Case",
+			location: entity.Location{Kind: entity.Room, Name: "QA Lab"},
+			wantErr:  errors.NewDeviceError(errors.ErrInvalid, "a room must be inside a site, parent_id is required", nil),
+		},
+		{
+			name:     "Create Shelf Inside Site Case",
+			location: entity.Location{Kind: entity.Shelf, Name: "Shelf A", ParentID: &lisbonSite.ID},
+			parents:  map[uuid.UUID]entity.Location{lisbonSite.ID: lisbonSite},
+			wantErr:  errors.NewDeviceError(errors.ErrInvalid, "a shelf must be inside a room, Lisbon Office is a site", nil),
+		},
+		{
+			name:     "Create Room Inside Unknown Parent Case",
+			location: entity.Location{Kind: entity.Room, Name: "QA Lab", ParentID: &missingID},
+			wantErr:  errors.NewDeviceError(errors.ErrInvalid, "parent location 9a0b1c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d does not exist", sql.ErrNoRows),
+		},
+		{
+			name:                "Create Location Name Already Exists Case",
+			location:            entity.Location{Kind: entity.Room, Name: "qa lab", ParentID: &lisbonSite.ID},
+			parents:             map[uuid.UUID]entity.Location{lisbonSite.ID: lisbonSite},
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errNameUniqueViolation,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "a location with this name already exists in the parent location", errNameUniqueViolation),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockLocationRepository(mockCtrl)
+			service := NewLocationService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				GetLocationByID(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, id uuid.UUID) (entity.Location, error) {
+					if parent, ok := tt.parents[id]; ok {
+						return parent, nil
+					}
+					return entity.Location{}, sql.ErrNoRows
+				}).
+				AnyTimes()
+
+			mockRepo.
+				EXPECT().
+				CreateLocation(context.TODO(), gomock.Any()).
+				Return(tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			location, err := service.Create(context.TODO(), tt.location)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEqual(t, uuid.Nil, location.ID)
+				assert.Equal(t, tt.wantName, location.Name)
+				assert.Equal(t, map[string]int{}, location.DeviceCounts)
+			}
+		})
+	}
+}
+
+func Test_Update_Location(t *testing.T) {
+	tests := []struct {
+		name                string
+		location            entity.Location
+		wantRepositoryCalls int
+		wantErr             error
+	}{
+		{
+			name:                "Update Location Keeps Device Counts Case",
+			location:            entity.Location{ID: labRoom.ID, Kind: entity.Room, Name: "QA Lab 2", ParentID: &lisbonSite.ID},
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:     "Update Location Kind Case",
+			location: entity.Location{ID: labRoom.ID, Kind: entity.Shelf, Name: "QA Lab", ParentID: &lisbonSite.ID},
+			wantErr:  errors.NewDeviceError(errors.ErrInvalid, "the kind of a location cannot be changed, QA Lab is a room", nil),
+		},
+	}
+
+	locations := map[uuid.UUID]entity.Location{lisbonSite.ID: lisbonSite, labRoom.ID: labRoom}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockLocationRepository(mockCtrl)
+			service := NewLocationService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				GetLocationByID(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, id uuid.UUID) (entity.Location, error) {
+					return locations[id], nil
+				}).
+				AnyTimes()
+
+			mockRepo.
+				EXPECT().
+				UpdateLocation(context.TODO(), gomock.Any()).
+				Return(nil).
+				Times(tt.wantRepositoryCalls)
+
+			location, err := service.Update(context.TODO(), tt.location)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, labRoom.DeviceCounts, location.DeviceCounts)
+			}
+		})
+	}
+}
+
+func Test_Delete_Location(t *testing.T) {
+	errLocationInUse := &pq.Error{Code: "23503", Constraint: "devices_location_id_fkey"}
+
+	tests := []struct {
+		name              string
+		wantRepositoryErr error
+		wantErr           error
+	}{
+		{
+			name: "Delete Location Success Case",
+		},
+		{
+			name:              "Delete Location Not Found Case",
+			wantRepositoryErr: sql.ErrNoRows,
+			wantErr:           errors.NewDeviceError(errors.ErrNotFound, "location not found", sql.ErrNoRows),
+		},
+		{
+			name:              "Delete Location With Devices Case",
+			wantRepositoryErr: errLocationInUse,
+			wantErr:           errors.NewDeviceError(errors.ErrConflict, "location has locations or devices inside and cannot be deleted", errLocationInUse),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockLocationRepository(mockCtrl)
+			service := NewLocationService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				DeleteLocation(context.TODO(), labRoom.ID).
+				Return(tt.wantRepositoryErr)
+
+			err := service.Delete(context.TODO(), labRoom.ID)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
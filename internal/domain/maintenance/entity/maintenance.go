@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceRecord tracks a device sent to repair. The device is under
+// maintenance from OpenedAt until the record is closed.
+type MaintenanceRecord struct {
+	ID       uuid.UUID `json:"id"`
+	DeviceID uuid.UUID `json:"device_id"`
+	Reason   string    `json:"reason"`
+	Vendor   *string   `json:"vendor"`
+	Cost     *float64  `json:"cost"`
+	// ExpectedReturnDate is a date, its time of day is always midnight UTC.
+	ExpectedReturnDate *time.Time `json:"expected_return_date"`
+	Resolution         *string    `json:"resolution"`
+	// Overdue is set on open records past their expected return date.
+	Overdue  bool       `json:"overdue"`
+	OpenedAt time.Time  `json:"opened_at"`
+	ClosedAt *time.Time `json:"closed_at"`
+}
+
+func (mr MaintenanceRecord) IsOpen() bool {
+	return mr.ClosedAt == nil
+}
+
+// MaintenanceFilter narrows a maintenance listing, nil criteria are not applied.
+type MaintenanceFilter struct {
+	DeviceID *uuid.UUID
+	Vendor   *string
+	Open     *bool
+	Overdue  *bool
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance/entity"
+)
+
+//go:generate mockgen -source=maintenance_repository.go -destination=../../mocks/maintenance_repository_mock.go -package=mocks
+
+// ErrDeviceNotMaintainable is returned when opening a maintenance record for a
+// device that is no longer available nor inactive.
+var ErrDeviceNotMaintainable = errors.New("device is neither available nor inactive")
+
+type MaintenanceRepository interface {
+	OpenMaintenance(ctx context.Context, record *entity.MaintenanceRecord) error
+	GetMaintenanceByID(ctx context.Context, id uuid.UUID) (entity.MaintenanceRecord, error)
+	ListMaintenance(ctx context.Context, filter entity.MaintenanceFilter) ([]entity.MaintenanceRecord, error)
+	CloseMaintenance(ctx context.Context, id uuid.UUID, resolution *string, cost *float64) (entity.MaintenanceRecord, error)
+}
+
+// maintenanceColumns is the column list read into an entity.MaintenanceRecord by maintenanceScanFields, in the same order.
+const maintenanceColumns = `id, device_id, reason, vendor, cost, expected_return_date, resolution,
+		COALESCE(closed_at IS NULL AND expected_return_date < CURRENT_DATE, false) AS overdue, opened_at, closed_at`
+
+func maintenanceScanFields(record *entity.MaintenanceRecord) []any {
+	return []any{
+		&record.ID,
+		&record.DeviceID,
+		&record.Reason,
+		&record.Vendor,
+		&record.Cost,
+		&record.ExpectedReturnDate,
+		&record.Resolution,
+		&record.Overdue,
+		&record.OpenedAt,
+		&record.ClosedAt,
+	}
+}
+
+type postgresMaintenanceRepository struct {
+	db *sql.DB
+}
+
+func NewMaintenanceRepository(db *sql.DB) *postgresMaintenanceRepository {
+	return &postgresMaintenanceRepository{db: db}
+}
+
+// OpenMaintenance puts the device under maintenance and records why, in a single
+// transaction. ErrDeviceNotMaintainable is returned when the device is not
+// available nor inactive, sql.ErrNoRows when it does not exist.
+func (r *postgresMaintenanceRepository) OpenMaintenance(ctx context.Context, record *entity.MaintenanceRecord) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	return database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		var state string
+		err := tx.QueryRowContext(ctx, `
+	SELECT state
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`, record.DeviceID).Scan(&state)
+		if err != nil {
+			return err
+		}
+
+		if state != "available" && state != "inactive" {
+			return ErrDeviceNotMaintainable
+		}
+
+		_, err = tx.ExecContext(ctx, `
+	UPDATE devices SET
+		state = 'maintenance',
+		updated_at = now()
+	WHERE id = $1;`, record.DeviceID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+	INSERT INTO maintenance_records (id, device_id, reason, vendor, cost, expected_return_date)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING opened_at;`,
+			record.ID,
+			record.DeviceID,
+			record.Reason,
+			record.Vendor,
+			record.Cost,
+			record.ExpectedReturnDate,
+		).Scan(&record.OpenedAt)
+		return err
+	})
+}
+
+func (r *postgresMaintenanceRepository) GetMaintenanceByID(ctx context.Context, id uuid.UUID) (entity.MaintenanceRecord, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var record entity.MaintenanceRecord
+
+	query := `
+	SELECT ` + maintenanceColumns + `
+	FROM maintenance_records
+	WHERE id = $1;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return record, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, id).Scan(maintenanceScanFields(&record)...)
+	if err != nil {
+		return record, err
+	}
+
+	return record, nil
+}
+
+func (r *postgresMaintenanceRepository) ListMaintenance(ctx context.Context, filter entity.MaintenanceFilter) ([]entity.MaintenanceRecord, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var records []entity.MaintenanceRecord
+
+	conds := []string{}
+	params := []any{}
+	if filter.DeviceID != nil {
+		params = append(params, *filter.DeviceID)
+		conds = append(conds, fmt.Sprintf("device_id = $%d", len(params)))
+	}
+	if filter.Vendor != nil {
+		params = append(params, *filter.Vendor)
+		conds = append(conds, fmt.Sprintf("lower(vendor) = lower($%d)", len(params)))
+	}
+	if filter.Open != nil {
+		if *filter.Open {
+			conds = append(conds, "closed_at IS NULL")
+		} else {
+			conds = append(conds, "closed_at IS NOT NULL")
+		}
+	}
+	if filter.Overdue != nil {
+		if *filter.Overdue {
+			conds = append(conds, "closed_at IS NULL AND expected_return_date < CURRENT_DATE")
+		} else {
+			conds = append(conds, "(closed_at IS NULL AND expected_return_date < CURRENT_DATE) IS NOT TRUE")
+		}
+	}
+
+	query := `
+	SELECT ` + maintenanceColumns + `
+	FROM maintenance_records`
+	if len(conds) > 0 {
+		query += `
+	WHERE ` + strings.Join(conds, " AND ")
+	}
+	query += `
+	ORDER BY opened_at DESC;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record entity.MaintenanceRecord
+		err = rows.Scan(maintenanceScanFields(&record)...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// CloseMaintenance closes an open maintenance record and makes its device
+// available again, in a single transaction. A nil cost keeps the estimated one.
+// sql.ErrNoRows is returned when there is no open record with the id.
+func (r *postgresMaintenanceRepository) CloseMaintenance(ctx context.Context, id uuid.UUID, resolution *string, cost *float64) (entity.MaintenanceRecord, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var record entity.MaintenanceRecord
+
+	err := database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+	UPDATE maintenance_records SET
+		resolution = $2,
+		cost = COALESCE($3, cost),
+		closed_at = now()
+	WHERE id = $1 AND closed_at IS NULL
+	RETURNING `+maintenanceColumns+`;`, id, resolution, cost).Scan(maintenanceScanFields(&record)...)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+	UPDATE devices SET
+		state = 'available',
+		updated_at = now()
+	WHERE id = $1 AND state = 'maintenance';`, record.DeviceID)
+		return err
+	})
+
+	return record, err
+}
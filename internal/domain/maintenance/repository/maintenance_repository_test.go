@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance/entity"
+)
+
+var maintenanceRowColumns = []string{"id", "device_id", "reason", "vendor", "cost", "expected_return_date", "resolution", "overdue", "opened_at", "closed_at"}
+
+var (
+	recordID           = uuid.MustParse("9c8b7a6d-5e4f-4a3b-9c2d-1e0f9a8b7c05")
+	deviceID           = uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+	expectedReturnDate = lo.Must(time.Parse(time.DateOnly, "2025-09-15"))
+	openedAt           = lo.Must(time.Parse(time.DateTime, "2025-09-01 10:00:00"))
+)
+
+func Test_Open_Maintenance(t *testing.T) {
+	assert := assert.New(t)
+
+	lockQuery := regexp.QuoteMeta(`
+	SELECT state
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`)
+
+	stateQuery := regexp.QuoteMeta(`
+	UPDATE devices SET
+		state = 'maintenance',
+		updated_at = now()
+	WHERE id = $1;`)
+
+	insertQuery := regexp.QuoteMeta(`
+	INSERT INTO maintenance_records (id, device_id, reason, vendor, cost, expected_return_date)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING opened_at;`)
+
+	testCases := []struct {
+		name      string
+		sqlMock   func(mock sqlmock.Sqlmock)
+		wantedErr error
+	}{
+		{
+			name: "Open Maintenance Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("inactive"))
+				mock.ExpectExec(stateQuery).
+					WithArgs(deviceID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(insertQuery).
+					WithArgs(recordID, deviceID, "cracked screen", lo.ToPtr("iFix Lisboa"), lo.ToPtr(89.9), &expectedReturnDate).
+					WillReturnRows(sqlmock.NewRows([]string{"opened_at"}).AddRow(openedAt))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Open Maintenance Device In Use Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("in-use"))
+				mock.ExpectRollback()
+			},
+			wantedErr: ErrDeviceNotMaintainable,
+		},
+		{
+			name: "Open Maintenance Device Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}))
+				mock.ExpectRollback()
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewMaintenanceRepository(db)
+
+			tt.sqlMock(mock)
+
+			record := entity.MaintenanceRecord{
+				ID:                 recordID,
+				DeviceID:           deviceID,
+				Reason:             "cracked screen",
+				Vendor:             lo.ToPtr("iFix Lisboa"),
+				Cost:               lo.ToPtr(89.9),
+				ExpectedReturnDate: &expectedReturnDate,
+			}
+			err = repository.OpenMaintenance(context.TODO(), &record)
+
+			assert.Equal(tt.wantedErr, err)
+			if tt.wantedErr == nil {
+				assert.Equal(openedAt, record.OpenedAt)
+			}
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_List_Overdue_Maintenance(t *testing.T) {
+	assert := assert.New(t)
+
+	listQuery := regexp.QuoteMeta(`
+	SELECT id, device_id, reason, vendor, cost, expected_return_date, resolution,
+		COALESCE(closed_at IS NULL AND expected_return_date < CURRENT_DATE, false) AS overdue, opened_at, closed_at
+	FROM maintenance_records
+	WHERE lower(vendor) = lower($1) AND closed_at IS NULL AND expected_return_date < CURRENT_DATE
+	ORDER BY opened_at DESC;`)
+
+	db, mock, err := sqlmock.New()
+	assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+	mock.ExpectPrepare(listQuery).
+		WillBeClosed().
+		ExpectQuery().
+		WithArgs("ifix lisboa").
+		WillReturnRows(sqlmock.NewRows(maintenanceRowColumns).
+			AddRow(recordID, deviceID, "cracked screen", "iFix Lisboa", []byte("89.90"), expectedReturnDate, nil, true, openedAt, nil))
+
+	repository := NewMaintenanceRepository(db)
+	records, err := repository.ListMaintenance(context.TODO(), entity.MaintenanceFilter{
+		Vendor:  lo.ToPtr("ifix lisboa"),
+		Overdue: lo.ToPtr(true),
+	})
+
+	assert.NoError(err)
+	assert.Equal([]entity.MaintenanceRecord{{
+		ID:                 recordID,
+		DeviceID:           deviceID,
+		Reason:             "cracked screen",
+		Vendor:             lo.ToPtr("iFix Lisboa"),
+		Cost:               lo.ToPtr(89.9),
+		ExpectedReturnDate: &expectedReturnDate,
+		Overdue:            true,
+		OpenedAt:           openedAt,
+	}}, records)
+
+	mock.ExpectClose()
+
+	err = db.Close()
+	assert.NoErrorf(err, "db was not closed")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(err, "there were unfulfilled expectations")
+}
@@ -0,0 +1,152 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/text"
+)
+
+const (
+	maxReasonLength     = 500
+	maxVendorLength     = 100
+	maxResolutionLength = 500
+)
+
+type MaintenanceService interface {
+	List(ctx context.Context, filter entity.MaintenanceFilter) ([]entity.MaintenanceRecord, error)
+	GetByID(ctx context.Context, id uuid.UUID) (entity.MaintenanceRecord, error)
+	Open(ctx context.Context, record entity.MaintenanceRecord) (entity.MaintenanceRecord, error)
+	Close(ctx context.Context, id uuid.UUID, resolution *string, cost *float64) (entity.MaintenanceRecord, error)
+}
+
+type maintenanceService struct {
+	repo repository.MaintenanceRepository
+}
+
+func NewMaintenanceService(repo repository.MaintenanceRepository) *maintenanceService {
+	return &maintenanceService{repo: repo}
+}
+
+func (s *maintenanceService) List(ctx context.Context, filter entity.MaintenanceFilter) ([]entity.MaintenanceRecord, error) {
+	records, err := s.repo.ListMaintenance(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing maintenance records", err)
+	}
+	return records, nil
+}
+
+func (s *maintenanceService) GetByID(ctx context.Context, id uuid.UUID) (entity.MaintenanceRecord, error) {
+	record, err := s.repo.GetMaintenanceByID(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.MaintenanceRecord{}, errors.NewDeviceError(errors.ErrNotFound, "maintenance record not found", err)
+		}
+		return entity.MaintenanceRecord{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving maintenance record", err)
+	}
+	return record, nil
+}
+
+// Open sends an available or inactive device to maintenance, the device stays
+// under maintenance until the record is closed.
+func (s *maintenanceService) Open(ctx context.Context, record entity.MaintenanceRecord) (entity.MaintenanceRecord, error) {
+	record.ID = uuid.New()
+	if err := normalizeAndValidate(&record); err != nil {
+		return record, err
+	}
+
+	err := s.repo.OpenMaintenance(ctx, &record)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return record, errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+		}
+		if goerrors.Is(err, repository.ErrDeviceNotMaintainable) {
+			return record, errors.NewDeviceError(errors.ErrConflict, "only available or inactive devices can go to maintenance", err)
+		}
+		var pqErr *pq.Error
+		if goerrors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return record, errors.NewDeviceError(errors.ErrConflict, "device is already under maintenance", err)
+		}
+		return record, errors.NewDeviceError(errors.ErrInternal, "something went wrong while opening maintenance record", err)
+	}
+
+	record.Overdue = false
+	return record, nil
+}
+
+// Close ends the maintenance of a device, which becomes available again. The
+// final cost replaces the estimated one when informed.
+func (s *maintenanceService) Close(ctx context.Context, id uuid.UUID, resolution *string, cost *float64) (entity.MaintenanceRecord, error) {
+	record, err := s.GetByID(ctx, id)
+	if err != nil {
+		return entity.MaintenanceRecord{}, err
+	}
+
+	if !record.IsOpen() {
+		return entity.MaintenanceRecord{}, errors.NewDeviceError(errors.ErrInvalid, "maintenance record is already closed", nil)
+	}
+
+	resolution, err = text.Normalize(resolution, "resolution", maxResolutionLength)
+	if err != nil {
+		return entity.MaintenanceRecord{}, err
+	}
+	if err := validateCost(cost); err != nil {
+		return entity.MaintenanceRecord{}, err
+	}
+
+	record, err = s.repo.CloseMaintenance(ctx, id, resolution, cost)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.MaintenanceRecord{}, errors.NewDeviceError(errors.ErrConflict, "maintenance record was closed meanwhile", err)
+		}
+		return entity.MaintenanceRecord{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while closing maintenance record", err)
+	}
+	return record, nil
+}
+
+func normalizeAndValidate(record *entity.MaintenanceRecord) error {
+	reason, err := text.Normalize(&record.Reason, "reason", maxReasonLength)
+	if err != nil {
+		return err
+	}
+	if reason == nil {
+		return errors.NewDeviceError(errors.ErrInvalid, "reason must not be empty", nil)
+	}
+	record.Reason = *reason
+
+	record.Vendor, err = text.Normalize(record.Vendor, "vendor", maxVendorLength)
+	if err != nil {
+		return err
+	}
+
+	if err := validateCost(record.Cost); err != nil {
+		return err
+	}
+
+	if record.ExpectedReturnDate != nil {
+		y, m, d := record.ExpectedReturnDate.Date()
+		date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+		y, m, d = time.Now().UTC().Date()
+		if date.Before(time.Date(y, m, d, 0, 0, 0, 0, time.UTC)) {
+			return errors.NewDeviceError(errors.ErrInvalid, "expected return date must not be in the past", nil)
+		}
+		record.ExpectedReturnDate = &date
+	}
+
+	return nil
+}
+
+func validateCost(cost *float64) error {
+	if cost != nil && *cost < 0 {
+		return errors.NewDeviceError(errors.ErrInvalid, "cost must not be negative", nil)
+	}
+	return nil
+}
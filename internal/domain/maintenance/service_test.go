@@ -0,0 +1,195 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance/repository"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+)
+
+var errDatabaseGeneric = fmt.Errorf("some database error")
+
+var deviceID = uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+
+func Test_Open_Maintenance(t *testing.T) {
+	errAlreadyOpen := &pq.Error{Code: "23505", Constraint: "uq_maintenance_records_open_device"}
+	nextWeek := time.Now().Add(7 * 24 * time.Hour)
+
+	repair := entity.MaintenanceRecord{
+		DeviceID:           deviceID,
+		Reason:             "  cracked screen ",
+		Vendor:             lo.ToPtr(" iFix Lisboa "),
+		Cost:               lo.ToPtr(89.9),
+		ExpectedReturnDate: &nextWeek,
+	}
+
+	withChange := func(change func(r *entity.MaintenanceRecord)) entity.MaintenanceRecord {
+		record := repair
+		change(&record)
+		return record
+	}
+
+	tests := []struct {
+		name                string
+		record              entity.MaintenanceRecord
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantErr             error
+	}{
+		{
+			name:                "Open Maintenance Success Case",
+			record:              repair,
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:    "Open Maintenance Without Reason Case",
+			record:  withChange(func(r *entity.MaintenanceRecord) { r.Reason = " " }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "reason must not be empty", nil),
+		},
+		{
+			name:    "Open Maintenance Negative Cost Case",
+			record:  withChange(func(r *entity.MaintenanceRecord) { r.Cost = lo.ToPtr(-1.0) }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "cost must not be negative", nil),
+		},
+		{
+			name:    "Open Maintenance Expected Return In The Past Case",
+			record:  withChange(func(r *entity.MaintenanceRecord) { r.ExpectedReturnDate = lo.ToPtr(time.Now().Add(-48 * time.Hour)) }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "expected return date must not be in the past", nil),
+		},
+		{
+			name:                "Open Maintenance Device Not Found Case",
+			record:              repair,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   sql.ErrNoRows,
+			wantErr:             errors.NewDeviceError(errors.ErrNotFound, "device not found", sql.ErrNoRows),
+		},
+		{
+			name:                "Open Maintenance Device In Use Case",
+			record:              repair,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   repository.ErrDeviceNotMaintainable,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "only available or inactive devices can go to maintenance", repository.ErrDeviceNotMaintainable),
+		},
+		{
+			name:                "Open Maintenance Already Open Case",
+			record:              repair,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errAlreadyOpen,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "device is already under maintenance", errAlreadyOpen),
+		},
+		{
+			name:                "Open Maintenance Repository Error Case",
+			record:              repair,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while opening maintenance record", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockMaintenanceRepository(mockCtrl)
+			service := NewMaintenanceService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				OpenMaintenance(context.TODO(), gomock.Any()).
+				Return(tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			record, err := service.Open(context.TODO(), tt.record)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEqual(t, uuid.Nil, record.ID)
+				assert.Equal(t, "cracked screen", record.Reason)
+				assert.Equal(t, lo.ToPtr("iFix Lisboa"), record.Vendor)
+				assert.Equal(t, time.UTC, record.ExpectedReturnDate.Location())
+				assert.Zero(t, record.ExpectedReturnDate.Hour())
+			}
+		})
+	}
+}
+
+func Test_Close_Maintenance(t *testing.T) {
+	recordID := uuid.MustParse("9c8b7a6d-5e4f-4a3b-9c2d-1e0f9a8b7c05")
+	closedAt := time.Now()
+
+	open := entity.MaintenanceRecord{ID: recordID, DeviceID: deviceID, Reason: "cracked screen"}
+	closed := open
+	closed.ClosedAt = &closedAt
+
+	tests := []struct {
+		name                string
+		record              entity.MaintenanceRecord
+		cost                *float64
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantErr             error
+	}{
+		{
+			name:                "Close Maintenance Success Case",
+			record:              open,
+			cost:                lo.ToPtr(120.0),
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:    "Close Maintenance Already Closed Case",
+			record:  closed,
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "maintenance record is already closed", nil),
+		},
+		{
+			name:    "Close Maintenance Negative Cost Case",
+			record:  open,
+			cost:    lo.ToPtr(-5.0),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "cost must not be negative", nil),
+		},
+		{
+			name:                "Close Maintenance Closed Meanwhile Case",
+			record:              open,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   sql.ErrNoRows,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "maintenance record was closed meanwhile", sql.ErrNoRows),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockMaintenanceRepository(mockCtrl)
+			service := NewMaintenanceService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				GetMaintenanceByID(context.TODO(), recordID).
+				Return(tt.record, nil)
+
+			mockRepo.
+				EXPECT().
+				CloseMaintenance(context.TODO(), recordID, lo.ToPtr("screen replaced"), tt.cost).
+				Return(closed, tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			record, err := service.Close(context.TODO(), recordID, lo.ToPtr(" screen replaced "), tt.cost)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.False(t, record.IsOpen())
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attribute_definition_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+// MockAttributeDefinitionRepository is a mock of AttributeDefinitionRepository interface.
+type MockAttributeDefinitionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttributeDefinitionRepositoryMockRecorder
+}
+
+// MockAttributeDefinitionRepositoryMockRecorder is the mock recorder for MockAttributeDefinitionRepository.
+type MockAttributeDefinitionRepositoryMockRecorder struct {
+	mock *MockAttributeDefinitionRepository
+}
+
+// NewMockAttributeDefinitionRepository creates a new mock instance.
+func NewMockAttributeDefinitionRepository(ctrl *gomock.Controller) *MockAttributeDefinitionRepository {
+	mock := &MockAttributeDefinitionRepository{ctrl: ctrl}
+	mock.recorder = &MockAttributeDefinitionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttributeDefinitionRepository) EXPECT() *MockAttributeDefinitionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttributeDefinition mocks base method.
+func (m *MockAttributeDefinitionRepository) DeleteAttributeDefinition(ctx context.Context, tenantID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttributeDefinition", ctx, tenantID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttributeDefinition indicates an expected call of DeleteAttributeDefinition.
+func (mr *MockAttributeDefinitionRepositoryMockRecorder) DeleteAttributeDefinition(ctx, tenantID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttributeDefinition", reflect.TypeOf((*MockAttributeDefinitionRepository)(nil).DeleteAttributeDefinition), ctx, tenantID, name)
+}
+
+// ListAttributeDefinitions mocks base method.
+func (m *MockAttributeDefinitionRepository) ListAttributeDefinitions(ctx context.Context, tenantID string) ([]entity.AttributeDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttributeDefinitions", ctx, tenantID)
+	ret0, _ := ret[0].([]entity.AttributeDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttributeDefinitions indicates an expected call of ListAttributeDefinitions.
+func (mr *MockAttributeDefinitionRepositoryMockRecorder) ListAttributeDefinitions(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttributeDefinitions", reflect.TypeOf((*MockAttributeDefinitionRepository)(nil).ListAttributeDefinitions), ctx, tenantID)
+}
+
+// UpsertAttributeDefinition mocks base method.
+func (m *MockAttributeDefinitionRepository) UpsertAttributeDefinition(ctx context.Context, definition *entity.AttributeDefinition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertAttributeDefinition", ctx, definition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertAttributeDefinition indicates an expected call of UpsertAttributeDefinition.
+func (mr *MockAttributeDefinitionRepositoryMockRecorder) UpsertAttributeDefinition(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAttributeDefinition", reflect.TypeOf((*MockAttributeDefinitionRepository)(nil).UpsertAttributeDefinition), ctx, definition)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: brands.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+)
+
+// MockBrandLookup is a mock of BrandLookup interface.
+type MockBrandLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrandLookupMockRecorder
+}
+
+// MockBrandLookupMockRecorder is the mock recorder for MockBrandLookup.
+type MockBrandLookupMockRecorder struct {
+	mock *MockBrandLookup
+}
+
+// NewMockBrandLookup creates a new mock instance.
+func NewMockBrandLookup(ctrl *gomock.Controller) *MockBrandLookup {
+	mock := &MockBrandLookup{ctrl: ctrl}
+	mock.recorder = &MockBrandLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrandLookup) EXPECT() *MockBrandLookupMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockBrandLookup) GetByID(ctx context.Context, id uuid.UUID) (entity.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(entity.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBrandLookupMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBrandLookup)(nil).GetByID), ctx, id)
+}
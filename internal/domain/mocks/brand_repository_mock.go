@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: brand_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+)
+
+// MockBrandRepository is a mock of BrandRepository interface.
+type MockBrandRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrandRepositoryMockRecorder
+}
+
+// MockBrandRepositoryMockRecorder is the mock recorder for MockBrandRepository.
+type MockBrandRepositoryMockRecorder struct {
+	mock *MockBrandRepository
+}
+
+// NewMockBrandRepository creates a new mock instance.
+func NewMockBrandRepository(ctrl *gomock.Controller) *MockBrandRepository {
+	mock := &MockBrandRepository{ctrl: ctrl}
+	mock.recorder = &MockBrandRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrandRepository) EXPECT() *MockBrandRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBrand mocks base method.
+func (m *MockBrandRepository) CreateBrand(ctx context.Context, brand *entity.Brand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", ctx, brand)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockBrandRepositoryMockRecorder) CreateBrand(ctx, brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockBrandRepository)(nil).CreateBrand), ctx, brand)
+}
+
+// DeleteBrand mocks base method.
+func (m *MockBrandRepository) DeleteBrand(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBrand", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBrand indicates an expected call of DeleteBrand.
+func (mr *MockBrandRepositoryMockRecorder) DeleteBrand(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrand", reflect.TypeOf((*MockBrandRepository)(nil).DeleteBrand), ctx, id)
+}
+
+// FindBrandByName mocks base method.
+func (m *MockBrandRepository) FindBrandByName(ctx context.Context, normalizedName string) (entity.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBrandByName", ctx, normalizedName)
+	ret0, _ := ret[0].(entity.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBrandByName indicates an expected call of FindBrandByName.
+func (mr *MockBrandRepositoryMockRecorder) FindBrandByName(ctx, normalizedName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBrandByName", reflect.TypeOf((*MockBrandRepository)(nil).FindBrandByName), ctx, normalizedName)
+}
+
+// GetBrandByID mocks base method.
+func (m *MockBrandRepository) GetBrandByID(ctx context.Context, id uuid.UUID) (entity.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrandByID", ctx, id)
+	ret0, _ := ret[0].(entity.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrandByID indicates an expected call of GetBrandByID.
+func (mr *MockBrandRepositoryMockRecorder) GetBrandByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrandByID", reflect.TypeOf((*MockBrandRepository)(nil).GetBrandByID), ctx, id)
+}
+
+// ListBrands mocks base method.
+func (m *MockBrandRepository) ListBrands(ctx context.Context) ([]entity.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrands", ctx)
+	ret0, _ := ret[0].([]entity.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrands indicates an expected call of ListBrands.
+func (mr *MockBrandRepositoryMockRecorder) ListBrands(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrands", reflect.TypeOf((*MockBrandRepository)(nil).ListBrands), ctx)
+}
+
+// ListBrandsByIDs mocks base method.
+func (m *MockBrandRepository) ListBrandsByIDs(ctx context.Context, ids []uuid.UUID) ([]entity.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrandsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]entity.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrandsByIDs indicates an expected call of ListBrandsByIDs.
+func (mr *MockBrandRepositoryMockRecorder) ListBrandsByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrandsByIDs", reflect.TypeOf((*MockBrandRepository)(nil).ListBrandsByIDs), ctx, ids)
+}
+
+// UpdateBrand mocks base method.
+func (m *MockBrandRepository) UpdateBrand(ctx context.Context, brand *entity.Brand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBrand", ctx, brand)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBrand indicates an expected call of UpdateBrand.
+func (mr *MockBrandRepositoryMockRecorder) UpdateBrand(ctx, brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrand", reflect.TypeOf((*MockBrandRepository)(nil).UpdateBrand), ctx, brand)
+}
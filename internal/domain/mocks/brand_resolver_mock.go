@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: brands.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+)
+
+// MockBrandResolver is a mock of BrandResolver interface.
+type MockBrandResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrandResolverMockRecorder
+}
+
+// MockBrandResolverMockRecorder is the mock recorder for MockBrandResolver.
+type MockBrandResolverMockRecorder struct {
+	mock *MockBrandResolver
+}
+
+// NewMockBrandResolver creates a new mock instance.
+func NewMockBrandResolver(ctrl *gomock.Controller) *MockBrandResolver {
+	mock := &MockBrandResolver{ctrl: ctrl}
+	mock.recorder = &MockBrandResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrandResolver) EXPECT() *MockBrandResolverMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockBrandResolver) Find(ctx context.Context, name string) (entity.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, name)
+	ret0, _ := ret[0].(entity.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockBrandResolverMockRecorder) Find(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockBrandResolver)(nil).Find), ctx, name)
+}
+
+// Resolve mocks base method.
+func (m *MockBrandResolver) Resolve(ctx context.Context, name string) (entity.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, name)
+	ret0, _ := ret[0].(entity.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockBrandResolverMockRecorder) Resolve(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockBrandResolver)(nil).Resolve), ctx, name)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: devices.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+// MockDeviceLookup is a mock of DeviceLookup interface.
+type MockDeviceLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceLookupMockRecorder
+}
+
+// MockDeviceLookupMockRecorder is the mock recorder for MockDeviceLookup.
+type MockDeviceLookupMockRecorder struct {
+	mock *MockDeviceLookup
+}
+
+// NewMockDeviceLookup creates a new mock instance.
+func NewMockDeviceLookup(ctrl *gomock.Controller) *MockDeviceLookup {
+	mock := &MockDeviceLookup{ctrl: ctrl}
+	mock.recorder = &MockDeviceLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceLookup) EXPECT() *MockDeviceLookupMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockDeviceLookup) GetByID(ctx context.Context, id uuid.UUID) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDeviceLookupMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDeviceLookup)(nil).GetByID), ctx, id)
+}
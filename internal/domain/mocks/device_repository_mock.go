@@ -6,11 +6,13 @@ package mocks
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 	entity "github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	repository "github.com/tiagos4ntos/device-manager/internal/domain/device/repository"
 )
 
 // MockDeviceRepository is a mock of DeviceRepository interface.
@@ -93,19 +95,124 @@ func (mr *MockDeviceRepositoryMockRecorder) GetDeviceByID(ctx, id interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceByID", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeviceByID), ctx, id)
 }
 
+// GetDeviceBySerialNumber mocks base method.
+func (m *MockDeviceRepository) GetDeviceBySerialNumber(ctx context.Context, serialNumber string) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceBySerialNumber", ctx, serialNumber)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceBySerialNumber indicates an expected call of GetDeviceBySerialNumber.
+func (mr *MockDeviceRepositoryMockRecorder) GetDeviceBySerialNumber(ctx, serialNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceBySerialNumber", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeviceBySerialNumber), ctx, serialNumber)
+}
+
+// GetDeviceForUpdate mocks base method.
+func (m *MockDeviceRepository) GetDeviceForUpdate(ctx context.Context, id uuid.UUID) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceForUpdate", ctx, id)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceForUpdate indicates an expected call of GetDeviceForUpdate.
+func (mr *MockDeviceRepositoryMockRecorder) GetDeviceForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceForUpdate", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeviceForUpdate), ctx, id)
+}
+
+// GetDeviceStats mocks base method.
+func (m *MockDeviceRepository) GetDeviceStats(ctx context.Context, opts entity.StatsOptions) (entity.DeviceStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceStats", ctx, opts)
+	ret0, _ := ret[0].(entity.DeviceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceStats indicates an expected call of GetDeviceStats.
+func (mr *MockDeviceRepositoryMockRecorder) GetDeviceStats(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceStats", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeviceStats), ctx, opts)
+}
+
+// HasUpcomingReservations mocks base method.
+func (m *MockDeviceRepository) HasUpcomingReservations(ctx context.Context, deviceID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUpcomingReservations", ctx, deviceID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUpcomingReservations indicates an expected call of HasUpcomingReservations.
+func (mr *MockDeviceRepositoryMockRecorder) HasUpcomingReservations(ctx, deviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUpcomingReservations", reflect.TypeOf((*MockDeviceRepository)(nil).HasUpcomingReservations), ctx, deviceID)
+}
+
+// ListDeviceHistory mocks base method.
+func (m *MockDeviceRepository) ListDeviceHistory(ctx context.Context, deviceID uuid.UUID) ([]entity.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeviceHistory", ctx, deviceID)
+	ret0, _ := ret[0].([]entity.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeviceHistory indicates an expected call of ListDeviceHistory.
+func (mr *MockDeviceRepositoryMockRecorder) ListDeviceHistory(ctx, deviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeviceHistory", reflect.TypeOf((*MockDeviceRepository)(nil).ListDeviceHistory), ctx, deviceID)
+}
+
 // ListDevices mocks base method.
-func (m *MockDeviceRepository) ListDevices(ctx context.Context, params map[string]any) ([]entity.Device, error) {
+func (m *MockDeviceRepository) ListDevices(ctx context.Context, opts entity.ListOptions) ([]entity.Device, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDevices", ctx, params)
+	ret := m.ctrl.Call(m, "ListDevices", ctx, opts)
 	ret0, _ := ret[0].([]entity.Device)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListDevices indicates an expected call of ListDevices.
-func (mr *MockDeviceRepositoryMockRecorder) ListDevices(ctx, params interface{}) *gomock.Call {
+func (mr *MockDeviceRepositoryMockRecorder) ListDevices(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDevices", reflect.TypeOf((*MockDeviceRepository)(nil).ListDevices), ctx, opts)
+}
+
+// MoveDevice mocks base method.
+func (m *MockDeviceRepository) MoveDevice(ctx context.Context, deviceID, locationID uuid.UUID, note *string) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveDevice", ctx, deviceID, locationID, note)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveDevice indicates an expected call of MoveDevice.
+func (mr *MockDeviceRepositoryMockRecorder) MoveDevice(ctx, deviceID, locationID, note interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDevices", reflect.TypeOf((*MockDeviceRepository)(nil).ListDevices), ctx, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveDevice", reflect.TypeOf((*MockDeviceRepository)(nil).MoveDevice), ctx, deviceID, locationID, note)
+}
+
+// SearchDevices mocks base method.
+func (m *MockDeviceRepository) SearchDevices(ctx context.Context, term string, opts entity.ListOptions) ([]entity.DeviceSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchDevices", ctx, term, opts)
+	ret0, _ := ret[0].([]entity.DeviceSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchDevices indicates an expected call of SearchDevices.
+func (mr *MockDeviceRepositoryMockRecorder) SearchDevices(ctx, term, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDevices", reflect.TypeOf((*MockDeviceRepository)(nil).SearchDevices), ctx, term, opts)
 }
 
 // UpdateDeviceState mocks base method.
@@ -122,3 +229,17 @@ func (mr *MockDeviceRepositoryMockRecorder) UpdateDeviceState(ctx, deviceID, new
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeviceState", reflect.TypeOf((*MockDeviceRepository)(nil).UpdateDeviceState), ctx, deviceID, newState)
 }
+
+// WithinTx mocks base method.
+func (m *MockDeviceRepository) WithinTx(ctx context.Context, isolation sql.IsolationLevel, fn func(repository.DeviceRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, isolation, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockDeviceRepositoryMockRecorder) WithinTx(ctx, isolation, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockDeviceRepository)(nil).WithinTx), ctx, isolation, fn)
+}
@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+// MockDeviceService is a mock of DeviceService interface.
+type MockDeviceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceServiceMockRecorder
+}
+
+// MockDeviceServiceMockRecorder is the mock recorder for MockDeviceService.
+type MockDeviceServiceMockRecorder struct {
+	mock *MockDeviceService
+}
+
+// NewMockDeviceService creates a new mock instance.
+func NewMockDeviceService(ctrl *gomock.Controller) *MockDeviceService {
+	mock := &MockDeviceService{ctrl: ctrl}
+	mock.recorder = &MockDeviceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceService) EXPECT() *MockDeviceServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDeviceService) Create(ctx context.Context, device entity.Device) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, device)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeviceServiceMockRecorder) Create(ctx, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeviceService)(nil).Create), ctx, device)
+}
+
+// Delete mocks base method.
+func (m *MockDeviceService) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeviceServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeviceService)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockDeviceService) GetByID(ctx context.Context, id uuid.UUID) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDeviceServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDeviceService)(nil).GetByID), ctx, id)
+}
+
+// GetBySerialNumber mocks base method.
+func (m *MockDeviceService) GetBySerialNumber(ctx context.Context, serialNumber string) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySerialNumber", ctx, serialNumber)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySerialNumber indicates an expected call of GetBySerialNumber.
+func (mr *MockDeviceServiceMockRecorder) GetBySerialNumber(ctx, serialNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySerialNumber", reflect.TypeOf((*MockDeviceService)(nil).GetBySerialNumber), ctx, serialNumber)
+}
+
+// History mocks base method.
+func (m *MockDeviceService) History(ctx context.Context, id uuid.UUID) ([]entity.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, id)
+	ret0, _ := ret[0].([]entity.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockDeviceServiceMockRecorder) History(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockDeviceService)(nil).History), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockDeviceService) List(ctx context.Context, opts entity.ListOptions) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeviceServiceMockRecorder) List(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeviceService)(nil).List), ctx, opts)
+}
+
+// Move mocks base method.
+func (m *MockDeviceService) Move(ctx context.Context, id, locationID uuid.UUID, note *string) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, id, locationID, note)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockDeviceServiceMockRecorder) Move(ctx, id, locationID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockDeviceService)(nil).Move), ctx, id, locationID, note)
+}
+
+// Search mocks base method.
+func (m *MockDeviceService) Search(ctx context.Context, term string, opts entity.ListOptions) ([]entity.DeviceSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, term, opts)
+	ret0, _ := ret[0].([]entity.DeviceSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockDeviceServiceMockRecorder) Search(ctx, term, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockDeviceService)(nil).Search), ctx, term, opts)
+}
+
+// Stats mocks base method.
+func (m *MockDeviceService) Stats(ctx context.Context, opts entity.StatsOptions) (entity.DeviceStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, opts)
+	ret0, _ := ret[0].(entity.DeviceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockDeviceServiceMockRecorder) Stats(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockDeviceService)(nil).Stats), ctx, opts)
+}
+
+// Update mocks base method.
+func (m *MockDeviceService) Update(ctx context.Context, device entity.Device) (entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, device)
+	ret0, _ := ret[0].(entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeviceServiceMockRecorder) Update(ctx, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeviceService)(nil).Update), ctx, device)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: disposal_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/disposal/entity"
+)
+
+// MockDisposalRepository is a mock of DisposalRepository interface.
+type MockDisposalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisposalRepositoryMockRecorder
+}
+
+// MockDisposalRepositoryMockRecorder is the mock recorder for MockDisposalRepository.
+type MockDisposalRepositoryMockRecorder struct {
+	mock *MockDisposalRepository
+}
+
+// NewMockDisposalRepository creates a new mock instance.
+func NewMockDisposalRepository(ctrl *gomock.Controller) *MockDisposalRepository {
+	mock := &MockDisposalRepository{ctrl: ctrl}
+	mock.recorder = &MockDisposalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisposalRepository) EXPECT() *MockDisposalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDisposalByDeviceID mocks base method.
+func (m *MockDisposalRepository) GetDisposalByDeviceID(ctx context.Context, deviceID uuid.UUID) (entity.DisposalRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisposalByDeviceID", ctx, deviceID)
+	ret0, _ := ret[0].(entity.DisposalRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisposalByDeviceID indicates an expected call of GetDisposalByDeviceID.
+func (mr *MockDisposalRepositoryMockRecorder) GetDisposalByDeviceID(ctx, deviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisposalByDeviceID", reflect.TypeOf((*MockDisposalRepository)(nil).GetDisposalByDeviceID), ctx, deviceID)
+}
+
+// RetireDevice mocks base method.
+func (m *MockDisposalRepository) RetireDevice(ctx context.Context, record *entity.DisposalRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetireDevice", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetireDevice indicates an expected call of RetireDevice.
+func (mr *MockDisposalRepositoryMockRecorder) RetireDevice(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetireDevice", reflect.TypeOf((*MockDisposalRepository)(nil).RetireDevice), ctx, record)
+}
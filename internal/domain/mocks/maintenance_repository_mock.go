@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maintenance_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/maintenance/entity"
+)
+
+// MockMaintenanceRepository is a mock of MaintenanceRepository interface.
+type MockMaintenanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceRepositoryMockRecorder
+}
+
+// MockMaintenanceRepositoryMockRecorder is the mock recorder for MockMaintenanceRepository.
+type MockMaintenanceRepositoryMockRecorder struct {
+	mock *MockMaintenanceRepository
+}
+
+// NewMockMaintenanceRepository creates a new mock instance.
+func NewMockMaintenanceRepository(ctrl *gomock.Controller) *MockMaintenanceRepository {
+	mock := &MockMaintenanceRepository{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceRepository) EXPECT() *MockMaintenanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CloseMaintenance mocks base method.
+func (m *MockMaintenanceRepository) CloseMaintenance(ctx context.Context, id uuid.UUID, resolution *string, cost *float64) (entity.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseMaintenance", ctx, id, resolution, cost)
+	ret0, _ := ret[0].(entity.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseMaintenance indicates an expected call of CloseMaintenance.
+func (mr *MockMaintenanceRepositoryMockRecorder) CloseMaintenance(ctx, id, resolution, cost interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseMaintenance", reflect.TypeOf((*MockMaintenanceRepository)(nil).CloseMaintenance), ctx, id, resolution, cost)
+}
+
+// GetMaintenanceByID mocks base method.
+func (m *MockMaintenanceRepository) GetMaintenanceByID(ctx context.Context, id uuid.UUID) (entity.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaintenanceByID", ctx, id)
+	ret0, _ := ret[0].(entity.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaintenanceByID indicates an expected call of GetMaintenanceByID.
+func (mr *MockMaintenanceRepositoryMockRecorder) GetMaintenanceByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaintenanceByID", reflect.TypeOf((*MockMaintenanceRepository)(nil).GetMaintenanceByID), ctx, id)
+}
+
+// ListMaintenance mocks base method.
+func (m *MockMaintenanceRepository) ListMaintenance(ctx context.Context, filter entity.MaintenanceFilter) ([]entity.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMaintenance", ctx, filter)
+	ret0, _ := ret[0].([]entity.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMaintenance indicates an expected call of ListMaintenance.
+func (mr *MockMaintenanceRepositoryMockRecorder) ListMaintenance(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMaintenance", reflect.TypeOf((*MockMaintenanceRepository)(nil).ListMaintenance), ctx, filter)
+}
+
+// OpenMaintenance mocks base method.
+func (m *MockMaintenanceRepository) OpenMaintenance(ctx context.Context, record *entity.MaintenanceRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenMaintenance", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenMaintenance indicates an expected call of OpenMaintenance.
+func (mr *MockMaintenanceRepositoryMockRecorder) OpenMaintenance(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenMaintenance", reflect.TypeOf((*MockMaintenanceRepository)(nil).OpenMaintenance), ctx, record)
+}
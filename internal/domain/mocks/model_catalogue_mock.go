@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: models.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+)
+
+// MockModelCatalogue is a mock of ModelCatalogue interface.
+type MockModelCatalogue struct {
+	ctrl     *gomock.Controller
+	recorder *MockModelCatalogueMockRecorder
+}
+
+// MockModelCatalogueMockRecorder is the mock recorder for MockModelCatalogue.
+type MockModelCatalogueMockRecorder struct {
+	mock *MockModelCatalogue
+}
+
+// NewMockModelCatalogue creates a new mock instance.
+func NewMockModelCatalogue(ctrl *gomock.Controller) *MockModelCatalogue {
+	mock := &MockModelCatalogue{ctrl: ctrl}
+	mock.recorder = &MockModelCatalogueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModelCatalogue) EXPECT() *MockModelCatalogueMockRecorder {
+	return m.recorder
+}
+
+// FindByName mocks base method.
+func (m *MockModelCatalogue) FindByName(ctx context.Context, name string, brandID *uuid.UUID) ([]entity.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name, brandID)
+	ret0, _ := ret[0].([]entity.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockModelCatalogueMockRecorder) FindByName(ctx, name, brandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockModelCatalogue)(nil).FindByName), ctx, name, brandID)
+}
+
+// GetByID mocks base method.
+func (m *MockModelCatalogue) GetByID(ctx context.Context, id uuid.UUID) (entity.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(entity.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockModelCatalogueMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockModelCatalogue)(nil).GetByID), ctx, id)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: model_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+)
+
+// MockModelRepository is a mock of ModelRepository interface.
+type MockModelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockModelRepositoryMockRecorder
+}
+
+// MockModelRepositoryMockRecorder is the mock recorder for MockModelRepository.
+type MockModelRepositoryMockRecorder struct {
+	mock *MockModelRepository
+}
+
+// NewMockModelRepository creates a new mock instance.
+func NewMockModelRepository(ctrl *gomock.Controller) *MockModelRepository {
+	mock := &MockModelRepository{ctrl: ctrl}
+	mock.recorder = &MockModelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModelRepository) EXPECT() *MockModelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateModel mocks base method.
+func (m *MockModelRepository) CreateModel(ctx context.Context, model *entity.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModel", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockModelRepositoryMockRecorder) CreateModel(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockModelRepository)(nil).CreateModel), ctx, model)
+}
+
+// DeleteModel mocks base method.
+func (m *MockModelRepository) DeleteModel(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModel indicates an expected call of DeleteModel.
+func (mr *MockModelRepositoryMockRecorder) DeleteModel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModel", reflect.TypeOf((*MockModelRepository)(nil).DeleteModel), ctx, id)
+}
+
+// GetModelByID mocks base method.
+func (m *MockModelRepository) GetModelByID(ctx context.Context, id uuid.UUID) (entity.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModelByID", ctx, id)
+	ret0, _ := ret[0].(entity.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModelByID indicates an expected call of GetModelByID.
+func (mr *MockModelRepositoryMockRecorder) GetModelByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModelByID", reflect.TypeOf((*MockModelRepository)(nil).GetModelByID), ctx, id)
+}
+
+// ListModels mocks base method.
+func (m *MockModelRepository) ListModels(ctx context.Context, filter entity.ModelFilter) ([]entity.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModels", ctx, filter)
+	ret0, _ := ret[0].([]entity.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModels indicates an expected call of ListModels.
+func (mr *MockModelRepositoryMockRecorder) ListModels(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModels", reflect.TypeOf((*MockModelRepository)(nil).ListModels), ctx, filter)
+}
+
+// UpdateModel mocks base method.
+func (m *MockModelRepository) UpdateModel(ctx context.Context, model *entity.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateModel", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateModel indicates an expected call of UpdateModel.
+func (mr *MockModelRepositoryMockRecorder) UpdateModel(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModel", reflect.TypeOf((*MockModelRepository)(nil).UpdateModel), ctx, model)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tiagos4ntos/device-manager/internal/domain/report/entity"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListPurchases mocks base method.
+func (m *MockReportRepository) ListPurchases(ctx context.Context, purchasedUntil time.Time) ([]entity.DeviceBookValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchases", ctx, purchasedUntil)
+	ret0, _ := ret[0].([]entity.DeviceBookValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchases indicates an expected call of ListPurchases.
+func (mr *MockReportRepositoryMockRecorder) ListPurchases(ctx, purchasedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchases", reflect.TypeOf((*MockReportRepository)(nil).ListPurchases), ctx, purchasedUntil)
+}
+
+// ListWarranties mocks base method.
+func (m *MockReportRepository) ListWarranties(ctx context.Context, filter entity.WarrantyFilter) ([]entity.WarrantyEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWarranties", ctx, filter)
+	ret0, _ := ret[0].([]entity.WarrantyEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWarranties indicates an expected call of ListWarranties.
+func (mr *MockReportRepositoryMockRecorder) ListWarranties(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWarranties", reflect.TypeOf((*MockReportRepository)(nil).ListWarranties), ctx, filter)
+}
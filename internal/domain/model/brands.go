@@ -0,0 +1,15 @@
+package model
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	brandentity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+)
+
+//go:generate mockgen -source=brands.go -destination=../mocks/brand_lookup_mock.go -package=mocks
+
+// BrandLookup reads the brand a model belongs to from the brand catalogue.
+type BrandLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (brandentity.Brand, error)
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FormFactor string
+
+const (
+	Phone    FormFactor = "phone"
+	Tablet   FormFactor = "tablet"
+	Laptop   FormFactor = "laptop"
+	Wearable FormFactor = "wearable"
+	Other    FormFactor = "other"
+)
+
+func (ff FormFactor) String() string {
+	return string(ff)
+}
+
+// FormFactors lists every form factor, in the order they are documented.
+var FormFactors = []FormFactor{Phone, Tablet, Laptop, Wearable, Other}
+
+type OSFamily string
+
+const (
+	Android  OSFamily = "android"
+	IOS      OSFamily = "ios"
+	IPadOS   OSFamily = "ipados"
+	Windows  OSFamily = "windows"
+	MacOS    OSFamily = "macos"
+	Linux    OSFamily = "linux"
+	ChromeOS OSFamily = "chromeos"
+	OtherOS  OSFamily = "other"
+)
+
+func (of OSFamily) String() string {
+	return string(of)
+}
+
+// OSFamilies lists every OS family, in the order they are documented.
+var OSFamilies = []OSFamily{Android, IOS, IPadOS, Windows, MacOS, Linux, ChromeOS, OtherOS}
+
+type Model struct {
+	ID      uuid.UUID `json:"id"`
+	BrandID uuid.UUID `json:"brand_id"`
+	// Brand is the name of the brand, read from the brand catalogue.
+	Brand       string     `json:"brand"`
+	Name        string     `json:"name"`
+	ReleaseYear *int       `json:"release_year"`
+	FormFactor  FormFactor `json:"form_factor"`
+	OSFamily    OSFamily   `json:"os_family"`
+	// StorageVariants lists the storage capacities the model is sold with, in GB.
+	StorageVariants []int32    `json:"storage_variants"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+}
+
+// ModelFilter narrows a model listing, nil criteria are not applied.
+type ModelFilter struct {
+	// IDs matches any of the models, when informed.
+	IDs     []uuid.UUID
+	BrandID *uuid.UUID
+	// Name is matched against the normalized model name.
+	Name *string
+}
+
+var spaces = regexp.MustCompile(`\s+`)
+
+// CleanModelName trims and collapses the spaces of a model name, keeping its case.
+func CleanModelName(name string) string {
+	return spaces.ReplaceAllString(strings.TrimSpace(name), " ")
+}
+
+// NormalizeModelName is the key models are unique by within a brand, so
+// "Pixel  7" and "pixel 7" are the same model.
+func NormalizeModelName(name string) string {
+	return strings.ToLower(CleanModelName(name))
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Normalize_Model_Name(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "Lower Cases", value: "Pixel 7", want: "pixel 7"},
+		{name: "Trims And Collapses Spaces", value: "  Galaxy   S23\tFE ", want: "galaxy s23 fe"},
+		{name: "Keeps Punctuation", value: "iPhone 15 Pro-Max", want: "iphone 15 pro-max"},
+		{name: "Empty", value: "   ", want: ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeModelName(tt.value))
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+)
+
+//go:generate mockgen -source=model_repository.go -destination=../../mocks/model_repository_mock.go -package=mocks
+
+type ModelRepository interface {
+	CreateModel(ctx context.Context, model *entity.Model) error
+	GetModelByID(ctx context.Context, id uuid.UUID) (entity.Model, error)
+	ListModels(ctx context.Context, filter entity.ModelFilter) ([]entity.Model, error)
+	UpdateModel(ctx context.Context, model *entity.Model) error
+	DeleteModel(ctx context.Context, id uuid.UUID) error
+}
+
+// modelColumns reads a model with the name of its brand.
+const modelColumns = `m.id, m.brand_id, b.name, m.name, m.release_year, m.form_factor, m.os_family, m.storage_variants, m.created_at, m.updated_at`
+
+func modelScanFields(model *entity.Model) []any {
+	return []any{
+		&model.ID,
+		&model.BrandID,
+		&model.Brand,
+		&model.Name,
+		&model.ReleaseYear,
+		&model.FormFactor,
+		&model.OSFamily,
+		pq.Array(&model.StorageVariants),
+		&model.CreatedAt,
+		&model.UpdatedAt,
+	}
+}
+
+type postgresModelRepository struct {
+	db *sql.DB
+}
+
+func NewModelRepository(db *sql.DB) *postgresModelRepository {
+	return &postgresModelRepository{db: db}
+}
+
+func (r *postgresModelRepository) CreateModel(ctx context.Context, model *entity.Model) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	const query = `
+	INSERT INTO models (id, brand_id, name, normalized_name, release_year, form_factor, os_family, storage_variants)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING created_at, updated_at;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	return stmt.QueryRowContext(ctx,
+		model.ID,
+		model.BrandID,
+		model.Name,
+		entity.NormalizeModelName(model.Name),
+		model.ReleaseYear,
+		model.FormFactor.String(),
+		model.OSFamily.String(),
+		storageVariantsArgument(model.StorageVariants),
+	).Scan(&model.CreatedAt, &model.UpdatedAt)
+}
+
+func (r *postgresModelRepository) GetModelByID(ctx context.Context, id uuid.UUID) (entity.Model, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var model entity.Model
+
+	query := `
+	SELECT ` + modelColumns + `
+	FROM models m
+	JOIN brands b ON b.id = m.brand_id
+	WHERE m.id = $1;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return model, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, id).Scan(modelScanFields(&model)...)
+	if err != nil {
+		return model, err
+	}
+
+	return model, nil
+}
+
+func (r *postgresModelRepository) ListModels(ctx context.Context, filter entity.ModelFilter) ([]entity.Model, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var models []entity.Model
+
+	conds := []string{}
+	params := []any{}
+	if len(filter.IDs) > 0 {
+		params = append(params, pq.Array(filter.IDs))
+		conds = append(conds, fmt.Sprintf("m.id = ANY($%d::uuid[])", len(params)))
+	}
+	if filter.BrandID != nil {
+		params = append(params, *filter.BrandID)
+		conds = append(conds, fmt.Sprintf("m.brand_id = $%d", len(params)))
+	}
+	if filter.Name != nil {
+		params = append(params, *filter.Name)
+		conds = append(conds, fmt.Sprintf("m.normalized_name = $%d", len(params)))
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "\n\tWHERE " + strings.Join(conds, " AND ")
+	}
+
+	query := `
+	SELECT ` + modelColumns + `
+	FROM models m
+	JOIN brands b ON b.id = m.brand_id` + where + `
+	ORDER BY b.name, m.name;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m entity.Model
+		err = rows.Scan(modelScanFields(&m)...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// UpdateModel replaces the model specs, when the model moves to another brand its
+// devices follow it, in a single transaction.
+func (r *postgresModelRepository) UpdateModel(ctx context.Context, model *entity.Model) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	const query = `
+	UPDATE models SET
+		brand_id = $2,
+		name = $3,
+		normalized_name = $4,
+		release_year = $5,
+		form_factor = $6,
+		os_family = $7,
+		storage_variants = $8,
+		updated_at = now()
+	WHERE id = $1
+	RETURNING created_at, updated_at;`
+
+	return database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query,
+			model.ID,
+			model.BrandID,
+			model.Name,
+			entity.NormalizeModelName(model.Name),
+			model.ReleaseYear,
+			model.FormFactor.String(),
+			model.OSFamily.String(),
+			storageVariantsArgument(model.StorageVariants),
+		).Scan(&model.CreatedAt, &model.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+	UPDATE devices SET
+		brand_id = b.id,
+		brand = b.name
+	FROM brands b
+	WHERE b.id = $2 AND devices.model_id = $1 AND devices.brand_id <> $2;`, model.ID, model.BrandID)
+		return err
+	})
+}
+
+// DeleteModel fails with a foreign key violation while devices reference the model.
+func (r *postgresModelRepository) DeleteModel(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	query := `DELETE FROM models WHERE id = $1;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount <= 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// storageVariantsArgument stores a model without storage variants as an empty
+// array, the column is NOT NULL.
+func storageVariantsArgument(variants []int32) any {
+	if variants == nil {
+		variants = []int32{}
+	}
+	return pq.Array(variants)
+}
@@ -0,0 +1,431 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+)
+
+var modelRowColumns = []string{"id", "brand_id", "brand", "name", "release_year", "form_factor", "os_family", "storage_variants", "created_at", "updated_at"}
+
+var (
+	googleBrandID = uuid.MustParse("1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704")
+	pixel7ModelID = uuid.MustParse("d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05")
+)
+
+func makeExpectedModelRecord() entity.Model {
+	return entity.Model{
+		ID:              pixel7ModelID,
+		BrandID:         googleBrandID,
+		Brand:           "Google",
+		Name:            "Pixel 7",
+		ReleaseYear:     lo.ToPtr(2022),
+		FormFactor:      entity.Phone,
+		OSFamily:        entity.Android,
+		StorageVariants: []int32{128, 256},
+		CreatedAt:       lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02")),
+	}
+}
+
+func Test_Create_Model(t *testing.T) {
+	assert := assert.New(t)
+
+	createQuery := regexp.QuoteMeta(`
+	INSERT INTO models (id, brand_id, name, normalized_name, release_year, form_factor, os_family, storage_variants)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING created_at, updated_at;`)
+
+	expectedModel := makeExpectedModelRecord()
+	modelToBeCreated := expectedModel
+	modelToBeCreated.CreatedAt = time.Time{}
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult entity.Model
+	}{
+		{
+			name: "Create Model Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(createQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(pixel7ModelID, googleBrandID, "Pixel 7", "pixel 7", lo.ToPtr(2022), "phone", "android", pq.Array([]int32{128, 256})).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(expectedModel.CreatedAt, nil))
+			},
+			wantedErr:    nil,
+			wantedResult: expectedModel,
+		},
+		{
+			name: "Create Model Fails on Prepare Statement",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(createQuery).
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: modelToBeCreated,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewModelRepository(db)
+
+			tt.sqlMock(mock)
+
+			model := modelToBeCreated
+			err = repository.CreateModel(context.TODO(), &model)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, model)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Get_Model_By_ID(t *testing.T) {
+	assert := assert.New(t)
+
+	getQuery := regexp.QuoteMeta(`
+	SELECT ` + modelColumns + `
+	FROM models m
+	JOIN brands b ON b.id = m.brand_id
+	WHERE m.id = $1;`)
+
+	expectedModel := makeExpectedModelRecord()
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult entity.Model
+	}{
+		{
+			name: "Get Model By ID Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(getQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(pixel7ModelID).
+					WillReturnRows(sqlmock.NewRows(modelRowColumns).
+						AddRow(pixel7ModelID, googleBrandID, "Google", "Pixel 7", 2022, "phone", "android", "{128,256}", expectedModel.CreatedAt, nil))
+			},
+			wantedErr:    nil,
+			wantedResult: expectedModel,
+		},
+		{
+			name: "Get Model By ID Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(getQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(pixel7ModelID).
+					WillReturnRows(sqlmock.NewRows(modelRowColumns))
+			},
+			wantedErr:    sql.ErrNoRows,
+			wantedResult: entity.Model{},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewModelRepository(db)
+
+			tt.sqlMock(mock)
+
+			model, err := repository.GetModelByID(context.TODO(), pixel7ModelID)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, model)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_List_Models(t *testing.T) {
+	assert := assert.New(t)
+
+	listQuery := regexp.QuoteMeta(`
+	SELECT ` + modelColumns + `
+	FROM models m
+	JOIN brands b ON b.id = m.brand_id
+	ORDER BY b.name, m.name;`)
+
+	listQueryFiltered := regexp.QuoteMeta(`
+	SELECT ` + modelColumns + `
+	FROM models m
+	JOIN brands b ON b.id = m.brand_id
+	WHERE m.brand_id = $1 AND m.normalized_name = $2
+	ORDER BY b.name, m.name;`)
+
+	listQueryByIDs := regexp.QuoteMeta(`
+	SELECT ` + modelColumns + `
+	FROM models m
+	JOIN brands b ON b.id = m.brand_id
+	WHERE m.id = ANY($1::uuid[])
+	ORDER BY b.name, m.name;`)
+
+	expectedModel := makeExpectedModelRecord()
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		filter       entity.ModelFilter
+		wantedErr    error
+		wantedResult []entity.Model
+	}{
+		{
+			name: "List Models Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs().
+					WillReturnRows(sqlmock.NewRows(modelRowColumns).
+						AddRow(pixel7ModelID, googleBrandID, "Google", "Pixel 7", 2022, "phone", "android", "{128,256}", expectedModel.CreatedAt, nil))
+			},
+			wantedErr:    nil,
+			wantedResult: []entity.Model{expectedModel},
+		},
+		{
+			name: "List Models filtering Brand and Name Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQueryFiltered).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(googleBrandID, "pixel 7").
+					WillReturnRows(sqlmock.NewRows(modelRowColumns).
+						AddRow(pixel7ModelID, googleBrandID, "Google", "Pixel 7", 2022, "phone", "android", "{128,256}", expectedModel.CreatedAt, nil))
+			},
+			filter:       entity.ModelFilter{BrandID: lo.ToPtr(googleBrandID), Name: lo.ToPtr("pixel 7")},
+			wantedErr:    nil,
+			wantedResult: []entity.Model{expectedModel},
+		},
+		{
+			name: "List Models filtering IDs Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQueryByIDs).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs(pq.Array([]uuid.UUID{pixel7ModelID})).
+					WillReturnRows(sqlmock.NewRows(modelRowColumns).
+						AddRow(pixel7ModelID, googleBrandID, "Google", "Pixel 7", 2022, "phone", "android", "{128,256}", expectedModel.CreatedAt, nil))
+			},
+			filter:       entity.ModelFilter{IDs: []uuid.UUID{pixel7ModelID}},
+			wantedErr:    nil,
+			wantedResult: []entity.Model{expectedModel},
+		},
+		{
+			name: "List Models Fails on Query",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(listQuery).
+					WillBeClosed().
+					ExpectQuery().
+					WithArgs().
+					WillReturnError(fmt.Errorf("some database error"))
+			},
+			wantedErr:    fmt.Errorf("some database error"),
+			wantedResult: nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewModelRepository(db)
+
+			tt.sqlMock(mock)
+
+			models, err := repository.ListModels(context.TODO(), tt.filter)
+
+			assert.Equal(tt.wantedErr, err)
+			assert.Equal(tt.wantedResult, models)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Update_Model(t *testing.T) {
+	assert := assert.New(t)
+	updatedAt := lo.Must(time.Parse(time.DateTime, "2025-09-01 10:00:00"))
+
+	updateQuery := regexp.QuoteMeta(`
+	UPDATE models SET
+		brand_id = $2,
+		name = $3,
+		normalized_name = $4,
+		release_year = $5,
+		form_factor = $6,
+		os_family = $7,
+		storage_variants = $8,
+		updated_at = now()
+	WHERE id = $1
+	RETURNING created_at, updated_at;`)
+
+	moveDevicesQuery := regexp.QuoteMeta(`
+	UPDATE devices SET
+		brand_id = b.id,
+		brand = b.name
+	FROM brands b
+	WHERE b.id = $2 AND devices.model_id = $1 AND devices.brand_id <> $2;`)
+
+	expectedModel := makeExpectedModelRecord()
+	expectedModel.UpdatedAt = &updatedAt
+
+	testCases := []struct {
+		name      string
+		sqlMock   func(mock sqlmock.Sqlmock)
+		wantedErr error
+	}{
+		{
+			name: "Update Model Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).
+					WithArgs(pixel7ModelID, googleBrandID, "Pixel 7", "pixel 7", lo.ToPtr(2022), "phone", "android", pq.Array([]int32{128, 256})).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(expectedModel.CreatedAt, updatedAt))
+				mock.ExpectExec(moveDevicesQuery).
+					WithArgs(pixel7ModelID, googleBrandID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantedErr: nil,
+		},
+		{
+			name: "Update Model Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).
+					WithArgs(pixel7ModelID, googleBrandID, "Pixel 7", "pixel 7", lo.ToPtr(2022), "phone", "android", pq.Array([]int32{128, 256})).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}))
+				mock.ExpectRollback()
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewModelRepository(db)
+
+			tt.sqlMock(mock)
+
+			model := makeExpectedModelRecord()
+			err = repository.UpdateModel(context.TODO(), &model)
+
+			assert.Equal(tt.wantedErr, err)
+			if tt.wantedErr == nil {
+				assert.Equal(expectedModel, model)
+			}
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Delete_Model(t *testing.T) {
+	assert := assert.New(t)
+
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM models WHERE id = $1;`)
+
+	testCases := []struct {
+		name      string
+		sqlMock   func(mock sqlmock.Sqlmock)
+		wantedErr error
+	}{
+		{
+			name: "Delete Model Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deleteQuery).
+					WillBeClosed().
+					ExpectExec().
+					WithArgs(pixel7ModelID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantedErr: nil,
+		},
+		{
+			name: "Delete Model Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(deleteQuery).
+					WillBeClosed().
+					ExpectExec().
+					WithArgs(pixel7ModelID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewModelRepository(db)
+
+			tt.sqlMock(mock)
+
+			err = repository.DeleteModel(context.TODO(), pixel7ModelID)
+
+			assert.Equal(tt.wantedErr, err)
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model/repository"
+)
+
+const (
+	maxModelNameLength = 100
+	minReleaseYear     = 1970
+)
+
+type ModelService interface {
+	List(ctx context.Context, filter entity.ModelFilter) ([]entity.Model, error)
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Model, error)
+	Create(ctx context.Context, model entity.Model) (entity.Model, error)
+	Update(ctx context.Context, model entity.Model) (entity.Model, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByName returns the models named name once normalized, of any brand
+	// unless brandID is informed.
+	FindByName(ctx context.Context, name string, brandID *uuid.UUID) ([]entity.Model, error)
+}
+
+type modelService struct {
+	repo   repository.ModelRepository
+	brands BrandLookup
+}
+
+func NewModelService(repo repository.ModelRepository, brands BrandLookup) *modelService {
+	return &modelService{repo: repo, brands: brands}
+}
+
+func (s *modelService) List(ctx context.Context, filter entity.ModelFilter) ([]entity.Model, error) {
+	if filter.Name != nil {
+		filter.Name = lo.ToPtr(entity.NormalizeModelName(*filter.Name))
+	}
+
+	models, err := s.repo.ListModels(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing models", err)
+	}
+	return models, nil
+}
+
+func (s *modelService) GetByID(ctx context.Context, id uuid.UUID) (entity.Model, error) {
+	model, err := s.repo.GetModelByID(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Model{}, errors.NewDeviceError(errors.ErrNotFound, "model not found", err)
+		}
+		return entity.Model{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving model", err)
+	}
+	return model, nil
+}
+
+func (s *modelService) Create(ctx context.Context, model entity.Model) (entity.Model, error) {
+	model.ID = uuid.New()
+	if err := s.normalizeAndValidate(ctx, &model); err != nil {
+		return model, err
+	}
+
+	err := s.repo.CreateModel(ctx, &model)
+	if err != nil {
+		if conflictErr := modelConflictError(err); conflictErr != nil {
+			return model, conflictErr
+		}
+		return model, errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating model", err)
+	}
+	return model, nil
+}
+
+func (s *modelService) Update(ctx context.Context, model entity.Model) (entity.Model, error) {
+	if _, err := s.GetByID(ctx, model.ID); err != nil {
+		return entity.Model{}, err
+	}
+
+	if err := s.normalizeAndValidate(ctx, &model); err != nil {
+		return entity.Model{}, err
+	}
+
+	err := s.repo.UpdateModel(ctx, &model)
+	if err != nil {
+		if conflictErr := modelConflictError(err); conflictErr != nil {
+			return entity.Model{}, conflictErr
+		}
+		return entity.Model{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while updating model", err)
+	}
+	return model, nil
+}
+
+func (s *modelService) Delete(ctx context.Context, id uuid.UUID) error {
+	err := s.repo.DeleteModel(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.NewDeviceError(errors.ErrNotFound, "model not found", err)
+		}
+		if conflictErr := modelConflictError(err); conflictErr != nil {
+			return conflictErr
+		}
+		return errors.NewDeviceError(errors.ErrInternal, "something went wrong while deleting model", err)
+	}
+	return nil
+}
+
+func (s *modelService) FindByName(ctx context.Context, name string, brandID *uuid.UUID) ([]entity.Model, error) {
+	return s.List(ctx, entity.ModelFilter{BrandID: brandID, Name: &name})
+}
+
+// normalizeAndValidate cleans the name, sorts the storage variants and checks the
+// specs, filling the brand name from the brand catalogue.
+func (s *modelService) normalizeAndValidate(ctx context.Context, model *entity.Model) error {
+	model.Name = entity.CleanModelName(model.Name)
+	if model.Name == "" {
+		return errors.NewDeviceError(errors.ErrInvalid, "model name must not be empty", nil)
+	}
+	if len(model.Name) > maxModelNameLength {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("model name must have at most %d characters", maxModelNameLength), nil)
+	}
+
+	if model.ReleaseYear != nil {
+		maxReleaseYear := time.Now().Year() + 1
+		if *model.ReleaseYear < minReleaseYear || *model.ReleaseYear > maxReleaseYear {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid release year %d, must be between %d and %d", *model.ReleaseYear, minReleaseYear, maxReleaseYear), nil)
+		}
+	}
+
+	if !slices.Contains(entity.FormFactors, model.FormFactor) {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid form factor %q, must be one of: %s", model.FormFactor, joinValues(entity.FormFactors)), nil)
+	}
+	if !slices.Contains(entity.OSFamilies, model.OSFamily) {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid os family %q, must be one of: %s", model.OSFamily, joinValues(entity.OSFamilies)), nil)
+	}
+
+	for _, variant := range model.StorageVariants {
+		if variant <= 0 {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid storage variant %d, must be a positive number of GB", variant), nil)
+		}
+	}
+	model.StorageVariants = slices.Compact(slices.Sorted(slices.Values(model.StorageVariants)))
+
+	brand, err := s.brands.GetByID(ctx, model.BrandID)
+	if err != nil {
+		if isNotFound(err) {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("brand %s does not exist", model.BrandID), err)
+		}
+		return err
+	}
+	model.Brand = brand.Name
+
+	return nil
+}
+
+func joinValues[T ~string](values []T) string {
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		texts = append(texts, string(value))
+	}
+	return strings.Join(texts, ", ")
+}
+
+func isNotFound(err error) bool {
+	var modelErr *errors.DeviceError
+	return goerrors.As(err, &modelErr) && modelErr.Type == errors.ErrNotFound
+}
+
+// modelConflictError maps the unique violation (SQLSTATE 23505) of a model name
+// repeated within a brand and the foreign key violations (SQLSTATE 23503) of a
+// model still used by devices or of a brand deleted meanwhile to conflict errors,
+// or returns nil for any other error.
+func modelConflictError(err error) *errors.DeviceError {
+	var pqErr *pq.Error
+	if !goerrors.As(err, &pqErr) {
+		return nil
+	}
+
+	switch pqErr.Code {
+	case "23505":
+		return errors.NewDeviceError(errors.ErrConflict, "a model with this name already exists for the brand", err)
+	case "23503":
+		if pqErr.Constraint == "models_brand_id_fkey" {
+			return errors.NewDeviceError(errors.ErrConflict, "the brand of the model was deleted", err)
+		}
+		return errors.NewDeviceError(errors.ErrConflict, "model is used by devices and cannot be deleted", err)
+	default:
+		return nil
+	}
+}
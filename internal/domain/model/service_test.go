@@ -0,0 +1,201 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	brandentity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+)
+
+var errDatabaseGeneric = fmt.Errorf("some database error")
+
+var google = brandentity.Brand{ID: uuid.MustParse("1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704"), Name: "Google"}
+
+func Test_Create_Model(t *testing.T) {
+	errNameUniqueViolation := &pq.Error{Code: "23505", Constraint: "uq_models_brand_normalized_name"}
+	errBrandNotFound := errors.NewDeviceError(errors.ErrNotFound, "brand not found", sql.ErrNoRows)
+	nextYear := time.Now().Year() + 1
+
+	pixel7 := entity.Model{
+		BrandID:         google.ID,
+		Name:            " Pixel   7 ",
+		ReleaseYear:     lo.ToPtr(2022),
+		FormFactor:      entity.Phone,
+		OSFamily:        entity.Android,
+		StorageVariants: []int32{256, 128, 256},
+	}
+
+	withChange := func(change func(m *entity.Model)) entity.Model {
+		model := pixel7
+		change(&model)
+		return model
+	}
+
+	tests := []struct {
+		name                string
+		model               entity.Model
+		wantBrandErr        error
+		wantBrandCalls      int
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantErr             error
+	}{
+		{
+			name:                "Create Model Success Case",
+			model:               pixel7,
+			wantBrandCalls:      1,
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:    "Create Model Empty Name Case",
+			model:   withChange(func(m *entity.Model) { m.Name = "  " }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "model name must not be empty", nil),
+		},
+		{
+			name:    "Create Model Release Year In The Future Case",
+			model:   withChange(func(m *entity.Model) { m.ReleaseYear = lo.ToPtr(nextYear + 1) }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("invalid release year %d, must be between 1970 and %d", nextYear+1, nextYear), nil),
+		},
+		{
+			name:    "Create Model Invalid Form Factor Case",
+			model:   withChange(func(m *entity.Model) { m.FormFactor = "phablet" }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, `invalid form factor "phablet", must be one of: phone, tablet, laptop, wearable, other`, nil),
+		},
+		{
+			name:    "Create Model Invalid OS Family Case",
+			model:   withChange(func(m *entity.Model) { m.OSFamily = "symbian" }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, `invalid os family "symbian", must be one of: android, ios, ipados, windows, macos, linux, chromeos, other`, nil),
+		},
+		{
+			name:    "Create Model Invalid Storage Variant Case",
+			model:   withChange(func(m *entity.Model) { m.StorageVariants = []int32{128, 0} }),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "invalid storage variant 0, must be a positive number of GB", nil),
+		},
+		{
+			name:           "Create Model Unknown Brand Case",
+			model:          pixel7,
+			wantBrandCalls: 1,
+			wantBrandErr:   errBrandNotFound,
+			wantErr:        errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("brand %s does not exist", google.ID), errBrandNotFound),
+		},
+		{
+			name:                "Create Model Name Already Exists Case",
+			model:               pixel7,
+			wantBrandCalls:      1,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errNameUniqueViolation,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "a model with this name already exists for the brand", errNameUniqueViolation),
+		},
+		{
+			name:                "Create Model Repository Error Case",
+			model:               pixel7,
+			wantBrandCalls:      1,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating model", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockModelRepository(mockCtrl)
+			mockBrands := mocks.NewMockBrandLookup(mockCtrl)
+			service := NewModelService(mockRepo, mockBrands)
+
+			mockBrands.
+				EXPECT().
+				GetByID(context.TODO(), google.ID).
+				Return(google, tt.wantBrandErr).
+				Times(tt.wantBrandCalls)
+
+			mockRepo.
+				EXPECT().
+				CreateModel(context.TODO(), gomock.Any()).
+				Return(tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			model, err := service.Create(context.TODO(), tt.model)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEqual(t, uuid.Nil, model.ID)
+				assert.Equal(t, "Pixel 7", model.Name)
+				assert.Equal(t, "Google", model.Brand)
+				assert.Equal(t, []int32{128, 256}, model.StorageVariants)
+			}
+		})
+	}
+}
+
+func Test_Delete_Model(t *testing.T) {
+	errModelInUse := &pq.Error{Code: "23503", Constraint: "devices_model_id_fkey"}
+	modelID := uuid.MustParse("d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05")
+
+	tests := []struct {
+		name              string
+		wantRepositoryErr error
+		wantErr           error
+	}{
+		{
+			name: "Delete Model Success Case",
+		},
+		{
+			name:              "Delete Model Not Found Case",
+			wantRepositoryErr: sql.ErrNoRows,
+			wantErr:           errors.NewDeviceError(errors.ErrNotFound, "model not found", sql.ErrNoRows),
+		},
+		{
+			name:              "Delete Model Used by Devices Case",
+			wantRepositoryErr: errModelInUse,
+			wantErr:           errors.NewDeviceError(errors.ErrConflict, "model is used by devices and cannot be deleted", errModelInUse),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockModelRepository(mockCtrl)
+			service := NewModelService(mockRepo, mocks.NewMockBrandLookup(mockCtrl))
+
+			mockRepo.
+				EXPECT().
+				DeleteModel(context.TODO(), modelID).
+				Return(tt.wantRepositoryErr)
+
+			err := service.Delete(context.TODO(), modelID)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_Find_Model_By_Name(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := mocks.NewMockModelRepository(mockCtrl)
+	service := NewModelService(mockRepo, mocks.NewMockBrandLookup(mockCtrl))
+
+	mockRepo.
+		EXPECT().
+		ListModels(context.TODO(), entity.ModelFilter{BrandID: &google.ID, Name: lo.ToPtr("pixel 7")}).
+		Return([]entity.Model{{Name: "Pixel 7"}}, nil)
+
+	models, err := service.FindByName(context.TODO(), "  PIXEL 7", &google.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Model{{Name: "Pixel 7"}}, models)
+}
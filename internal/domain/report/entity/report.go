@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WarrantyEntry is a device with a known warranty end. DaysLeft is negative
+// once the warranty has expired.
+type WarrantyEntry struct {
+	DeviceID     uuid.UUID `json:"device_id"`
+	Name         string    `json:"name"`
+	Brand        string    `json:"brand"`
+	SerialNumber *string   `json:"serial_number"`
+	Supplier     *string   `json:"supplier"`
+	// WarrantyEndsOn is a date, its time of day is always midnight UTC.
+	WarrantyEndsOn time.Time `json:"warranty_ends_on"`
+	DaysLeft       int       `json:"days_left"`
+}
+
+// WarrantyFilter selects the warranties ending until Until. Warranties that
+// ended before From are left out unless IncludeExpired is set.
+type WarrantyFilter struct {
+	From           time.Time
+	Until          time.Time
+	IncludeExpired bool
+}
+
+// DeviceBookValue is the straight line depreciation of a device purchase,
+// amounts are in Currency.
+type DeviceBookValue struct {
+	DeviceID      uuid.UUID `json:"device_id"`
+	Name          string    `json:"name"`
+	Brand         string    `json:"brand"`
+	PurchaseDate  time.Time `json:"purchase_date"`
+	PurchasePrice float64   `json:"purchase_price"`
+	Currency      string    `json:"currency"`
+	BookValue     float64   `json:"book_value"`
+}
+
+// BrandBookValue totals the devices of a brand bought in the same currency.
+type BrandBookValue struct {
+	Brand         string  `json:"brand"`
+	Currency      string  `json:"currency"`
+	Devices       int     `json:"devices"`
+	PurchaseTotal float64 `json:"purchase_total"`
+	BookValue     float64 `json:"book_value"`
+}
+
+// DepreciationReport holds the book value of every device with procurement
+// data at AsOf, depreciated to zero over UsefulLifeMonths.
+type DepreciationReport struct {
+	AsOf             time.Time         `json:"as_of"`
+	UsefulLifeMonths int               `json:"useful_life_months"`
+	Devices          []DeviceBookValue `json:"devices"`
+	Brands           []BrandBookValue  `json:"brands"`
+}
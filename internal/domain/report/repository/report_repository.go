@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/report/entity"
+)
+
+//go:generate mockgen -source=report_repository.go -destination=../../mocks/report_repository_mock.go -package=mocks
+
+type ReportRepository interface {
+	ListWarranties(ctx context.Context, filter entity.WarrantyFilter) ([]entity.WarrantyEntry, error)
+	ListPurchases(ctx context.Context, purchasedUntil time.Time) ([]entity.DeviceBookValue, error)
+}
+
+type postgresReportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) *postgresReportRepository {
+	return &postgresReportRepository{db: db}
+}
+
+// ListWarranties lists the devices whose warranty ends until filter.Until,
+// ordered by warranty end. DaysLeft is not filled.
+func (r *postgresReportRepository) ListWarranties(ctx context.Context, filter entity.WarrantyFilter) ([]entity.WarrantyEntry, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var entries []entity.WarrantyEntry
+
+	query := `
+	SELECT id, name, brand, serial_number, supplier, warranty_ends_on
+	FROM devices
+	WHERE deleted_at IS NULL AND warranty_ends_on <= $1`
+	params := []any{filter.Until}
+	if !filter.IncludeExpired {
+		params = append(params, filter.From)
+		query += ` AND warranty_ends_on >= $2`
+	}
+	query += `
+	ORDER BY warranty_ends_on, name;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry entity.WarrantyEntry
+		err = rows.Scan(&entry.DeviceID, &entry.Name, &entry.Brand, &entry.SerialNumber, &entry.Supplier, &entry.WarrantyEndsOn)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ListPurchases lists the devices with a purchase price bought until
+// purchasedUntil, ordered by brand. BookValue is not filled.
+func (r *postgresReportRepository) ListPurchases(ctx context.Context, purchasedUntil time.Time) ([]entity.DeviceBookValue, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var purchases []entity.DeviceBookValue
+
+	query := `
+	SELECT id, name, brand, purchase_date, purchase_price, purchase_currency
+	FROM devices
+	WHERE deleted_at IS NULL AND purchase_price IS NOT NULL AND purchase_date <= $1
+	ORDER BY brand, purchase_currency, name;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, purchasedUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var purchase entity.DeviceBookValue
+		err = rows.Scan(&purchase.DeviceID, &purchase.Name, &purchase.Brand, &purchase.PurchaseDate, &purchase.PurchasePrice, &purchase.Currency)
+		if err != nil {
+			return nil, err
+		}
+		purchases = append(purchases, purchase)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return purchases, nil
+}
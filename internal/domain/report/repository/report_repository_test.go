@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/report/entity"
+)
+
+var (
+	deviceID       = uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+	today          = lo.Must(time.Parse(time.DateOnly, "2025-09-01"))
+	warrantyEndsOn = lo.Must(time.Parse(time.DateOnly, "2025-09-20"))
+	purchaseDate   = lo.Must(time.Parse(time.DateOnly, "2024-03-10"))
+)
+
+func Test_List_Warranties(t *testing.T) {
+	assert := assert.New(t)
+
+	rowColumns := []string{"id", "name", "brand", "serial_number", "supplier", "warranty_ends_on"}
+
+	testCases := []struct {
+		name    string
+		filter  entity.WarrantyFilter
+		query   string
+		args    []driver.Value
+		wantLen int
+	}{
+		{
+			name:   "List Warranties Expiring Case",
+			filter: entity.WarrantyFilter{From: today, Until: today.AddDate(0, 0, 30)},
+			query: `
+	SELECT id, name, brand, serial_number, supplier, warranty_ends_on
+	FROM devices
+	WHERE deleted_at IS NULL AND warranty_ends_on <= $1 AND warranty_ends_on >= $2
+	ORDER BY warranty_ends_on, name;`,
+			args:    []driver.Value{today.AddDate(0, 0, 30), today},
+			wantLen: 1,
+		},
+		{
+			name:   "List Warranties Including Expired Case",
+			filter: entity.WarrantyFilter{From: today, Until: today.AddDate(0, 0, 30), IncludeExpired: true},
+			query: `
+	SELECT id, name, brand, serial_number, supplier, warranty_ends_on
+	FROM devices
+	WHERE deleted_at IS NULL AND warranty_ends_on <= $1
+	ORDER BY warranty_ends_on, name;`,
+			args:    []driver.Value{today.AddDate(0, 0, 30)},
+			wantLen: 1,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(tt.query)).
+				WillBeClosed().
+				ExpectQuery().
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows(rowColumns).
+					AddRow(deviceID, "Galaxy S21", "Samsung", "R58R12ABCDE", "ACME Distribution", warrantyEndsOn))
+
+			repository := NewReportRepository(db)
+			entries, err := repository.ListWarranties(context.TODO(), tt.filter)
+
+			assert.NoError(err)
+			assert.Len(entries, tt.wantLen)
+			assert.Equal(entity.WarrantyEntry{
+				DeviceID:       deviceID,
+				Name:           "Galaxy S21",
+				Brand:          "Samsung",
+				SerialNumber:   lo.ToPtr("R58R12ABCDE"),
+				Supplier:       lo.ToPtr("ACME Distribution"),
+				WarrantyEndsOn: warrantyEndsOn,
+			}, entries[0])
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_List_Purchases(t *testing.T) {
+	assert := assert.New(t)
+
+	listQuery := regexp.QuoteMeta(`
+	SELECT id, name, brand, purchase_date, purchase_price, purchase_currency
+	FROM devices
+	WHERE deleted_at IS NULL AND purchase_price IS NOT NULL AND purchase_date <= $1
+	ORDER BY brand, purchase_currency, name;`)
+
+	db, mock, err := sqlmock.New()
+	assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+	mock.ExpectPrepare(listQuery).
+		WillBeClosed().
+		ExpectQuery().
+		WithArgs(today).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "brand", "purchase_date", "purchase_price", "purchase_currency"}).
+			AddRow(deviceID, "Galaxy S21", "Samsung", purchaseDate, 799.0, "EUR"))
+
+	repository := NewReportRepository(db)
+	purchases, err := repository.ListPurchases(context.TODO(), today)
+
+	assert.NoError(err)
+	assert.Equal([]entity.DeviceBookValue{{
+		DeviceID:      deviceID,
+		Name:          "Galaxy S21",
+		Brand:         "Samsung",
+		PurchaseDate:  purchaseDate,
+		PurchasePrice: 799,
+		Currency:      "EUR",
+	}}, purchases)
+
+	mock.ExpectClose()
+
+	err = db.Close()
+	assert.NoErrorf(err, "db was not closed")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(err, "there were unfulfilled expectations")
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/report/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/report/repository"
+)
+
+const (
+	maxWarrantyWindowDays = 3650
+	maxUsefulLifeMonths   = 600
+)
+
+type ReportService interface {
+	Warranty(ctx context.Context, expiringWithinDays int, includeExpired bool) ([]entity.WarrantyEntry, error)
+	Depreciation(ctx context.Context, asOf time.Time, usefulLifeMonths int) (entity.DepreciationReport, error)
+}
+
+type reportService struct {
+	repo repository.ReportRepository
+}
+
+func NewReportService(repo repository.ReportRepository) *reportService {
+	return &reportService{repo: repo}
+}
+
+// Warranty lists the warranties ending in the next expiringWithinDays days,
+// counted from today in UTC, and optionally the ones already expired.
+func (s *reportService) Warranty(ctx context.Context, expiringWithinDays int, includeExpired bool) ([]entity.WarrantyEntry, error) {
+	if expiringWithinDays < 1 || expiringWithinDays > maxWarrantyWindowDays {
+		return nil, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("expiring window must be between 1 and %d days", maxWarrantyWindowDays), nil)
+	}
+
+	today := dateOf(time.Now())
+	entries, err := s.repo.ListWarranties(ctx, entity.WarrantyFilter{
+		From:           today,
+		Until:          today.AddDate(0, 0, expiringWithinDays),
+		IncludeExpired: includeExpired,
+	})
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing warranties", err)
+	}
+
+	for i := range entries {
+		entries[i].DaysLeft = daysBetween(today, entries[i].WarrantyEndsOn)
+	}
+	return entries, nil
+}
+
+// Depreciation computes the book value at asOf of every device purchased until
+// then, and totals them per brand and currency.
+func (s *reportService) Depreciation(ctx context.Context, asOf time.Time, usefulLifeMonths int) (entity.DepreciationReport, error) {
+	if usefulLifeMonths < 1 || usefulLifeMonths > maxUsefulLifeMonths {
+		return entity.DepreciationReport{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("useful life must be between 1 and %d months", maxUsefulLifeMonths), nil)
+	}
+
+	asOf = dateOf(asOf)
+	devices, err := s.repo.ListPurchases(ctx, asOf)
+	if err != nil {
+		return entity.DepreciationReport{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing device purchases", err)
+	}
+
+	report := entity.DepreciationReport{
+		AsOf:             asOf,
+		UsefulLifeMonths: usefulLifeMonths,
+		Devices:          devices,
+		Brands:           []entity.BrandBookValue{},
+	}
+
+	for i, device := range report.Devices {
+		report.Devices[i].BookValue = BookValue(device.PurchasePrice, device.PurchaseDate, asOf, usefulLifeMonths)
+
+		// devices come ordered by brand and currency, so each total is contiguous
+		last := len(report.Brands) - 1
+		if last < 0 || report.Brands[last].Brand != device.Brand || report.Brands[last].Currency != device.Currency {
+			report.Brands = append(report.Brands, entity.BrandBookValue{Brand: device.Brand, Currency: device.Currency})
+			last++
+		}
+		report.Brands[last].Devices++
+		report.Brands[last].PurchaseTotal = roundCents(report.Brands[last].PurchaseTotal + device.PurchasePrice)
+		report.Brands[last].BookValue = roundCents(report.Brands[last].BookValue + report.Devices[i].BookValue)
+	}
+
+	return report, nil
+}
+
+// BookValue depreciates price linearly, day by day, from purchaseDate to zero
+// usefulLifeMonths later and returns what is left at asOf, rounded to cents.
+func BookValue(price float64, purchaseDate, asOf time.Time, usefulLifeMonths int) float64 {
+	purchaseDate = dateOf(purchaseDate)
+	lifeDays := daysBetween(purchaseDate, purchaseDate.AddDate(0, usefulLifeMonths, 0))
+	elapsedDays := daysBetween(purchaseDate, dateOf(asOf))
+
+	switch {
+	case elapsedDays <= 0:
+		return roundCents(price)
+	case elapsedDays >= lifeDays:
+		return 0
+	}
+	return roundCents(price * float64(lifeDays-elapsedDays) / float64(lifeDays))
+}
+
+// dateOf drops the time of day of t, keeping its calendar date in UTC.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+	"github.com/tiagos4ntos/device-manager/internal/domain/report/entity"
+)
+
+var errDatabaseGeneric = fmt.Errorf("some database error")
+
+func date(value string) time.Time {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func Test_BookValue(t *testing.T) {
+	tests := []struct {
+		name             string
+		asOf             time.Time
+		usefulLifeMonths int
+		want             float64
+	}{
+		{name: "Book Value On Purchase Date Case", asOf: date("2024-01-01"), usefulLifeMonths: 12, want: 1200},
+		{name: "Book Value Before Purchase Case", asOf: date("2023-06-01"), usefulLifeMonths: 12, want: 1200},
+		{name: "Book Value Half Life Case", asOf: date("2024-07-01"), usefulLifeMonths: 12, want: 603.28},
+		{name: "Book Value Ignores Time Of Day Case", asOf: date("2024-07-01").Add(23 * time.Hour), usefulLifeMonths: 12, want: 603.28},
+		{name: "Book Value End Of Life Case", asOf: date("2025-01-01"), usefulLifeMonths: 12, want: 0},
+		{name: "Book Value After End Of Life Case", asOf: date("2026-01-01"), usefulLifeMonths: 12, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BookValue(1200, date("2024-01-01"), tt.asOf, tt.usefulLifeMonths))
+		})
+	}
+}
+
+func Test_Depreciation_Report(t *testing.T) {
+	asOf := date("2025-01-01")
+	devices := func() []entity.DeviceBookValue {
+		return []entity.DeviceBookValue{
+			{DeviceID: uuid.New(), Name: "iPhone 13", Brand: "Apple", PurchaseDate: date("2024-01-01"), PurchasePrice: 900, Currency: "EUR"},
+			{DeviceID: uuid.New(), Name: "iPhone 15", Brand: "Apple", PurchaseDate: date("2025-01-01"), PurchasePrice: 1100, Currency: "EUR"},
+			{DeviceID: uuid.New(), Name: "iPhone 14", Brand: "Apple", PurchaseDate: date("2023-01-01"), PurchasePrice: 800, Currency: "USD"},
+			{DeviceID: uuid.New(), Name: "Galaxy S21", Brand: "Samsung", PurchaseDate: date("2021-01-01"), PurchasePrice: 700, Currency: "EUR"},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		usefulLifeMonths    int
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantBrands          []entity.BrandBookValue
+		wantErr             error
+	}{
+		{
+			name:                "Depreciation Totals Per Brand And Currency Case",
+			usefulLifeMonths:    24,
+			wantRepositoryCalls: 1,
+			wantBrands: []entity.BrandBookValue{
+				{Brand: "Apple", Currency: "EUR", Devices: 2, PurchaseTotal: 2000, BookValue: 1549.38},
+				{Brand: "Apple", Currency: "USD", Devices: 1, PurchaseTotal: 800, BookValue: 0},
+				{Brand: "Samsung", Currency: "EUR", Devices: 1, PurchaseTotal: 700, BookValue: 0},
+			},
+		},
+		{
+			name:             "Depreciation Invalid Useful Life Case",
+			usefulLifeMonths: 0,
+			wantErr:          errors.NewDeviceError(errors.ErrInvalid, "useful life must be between 1 and 600 months", nil),
+		},
+		{
+			name:                "Depreciation Repository Error Case",
+			usefulLifeMonths:    36,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing device purchases", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockReportRepository(mockCtrl)
+			service := NewReportService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				ListPurchases(context.TODO(), asOf).
+				Return(devices(), tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			report, err := service.Depreciation(context.TODO(), asOf.Add(15*time.Hour), tt.usefulLifeMonths)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, asOf, report.AsOf)
+				assert.Len(t, report.Devices, 4)
+				assert.Equal(t, tt.wantBrands, report.Brands)
+			}
+		})
+	}
+}
+
+func Test_Warranty_Report(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	tests := []struct {
+		name                string
+		days                int
+		includeExpired      bool
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantErr             error
+	}{
+		{
+			name:                "Warranty Expiring Within 30 Days Case",
+			days:                30,
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:                "Warranty Including Expired Case",
+			days:                30,
+			includeExpired:      true,
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:    "Warranty Invalid Window Case",
+			days:    0,
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "expiring window must be between 1 and 3650 days", nil),
+		},
+		{
+			name:                "Warranty Repository Error Case",
+			days:                30,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing warranties", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockReportRepository(mockCtrl)
+			service := NewReportService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				ListWarranties(context.TODO(), entity.WarrantyFilter{From: today, Until: today.AddDate(0, 0, tt.days), IncludeExpired: tt.includeExpired}).
+				Return([]entity.WarrantyEntry{
+					{Name: "iPhone 13", WarrantyEndsOn: today.AddDate(0, 0, -3)},
+					{Name: "Galaxy S21", WarrantyEndsOn: today.AddDate(0, 0, 12)},
+				}, tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			entries, err := service.Warranty(context.TODO(), tt.days, tt.includeExpired)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, -3, entries[0].DaysLeft)
+				assert.Equal(t, 12, entries[1].DaysLeft)
+			}
+		})
+	}
+}
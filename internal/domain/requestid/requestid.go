@@ -0,0 +1,20 @@
+// Package requestid carries the ID identifying a request in the logs.
+package requestid
+
+import "context"
+
+type contextKey struct{}
+
+// WithID returns a copy of ctx carrying the request ID, an empty ID leaves ctx unchanged.
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// IDFromContext returns the request ID carried by ctx, if any.
+func IDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok
+}
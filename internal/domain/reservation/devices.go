@@ -0,0 +1,15 @@
+package reservation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	deviceentity "github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+//go:generate mockgen -source=devices.go -destination=../mocks/device_lookup_mock.go -package=mocks
+
+// DeviceLookup reads the devices being reserved.
+type DeviceLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (deviceentity.Device, error)
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationStatus string
+
+const (
+	Booked     ReservationStatus = "booked"
+	CheckedOut ReservationStatus = "checked-out"
+	Cancelled  ReservationStatus = "cancelled"
+)
+
+func (rs ReservationStatus) String() string {
+	return string(rs)
+}
+
+// Reservation books a device for the period [StartsAt, EndsAt). Booked and
+// checked out reservations of a device never overlap.
+type Reservation struct {
+	ID           uuid.UUID         `json:"id"`
+	DeviceID     uuid.UUID         `json:"device_id"`
+	ReservedBy   string            `json:"reserved_by"`
+	StartsAt     time.Time         `json:"starts_at"`
+	EndsAt       time.Time         `json:"ends_at"`
+	Note         *string           `json:"note"`
+	Status       ReservationStatus `json:"status"`
+	CheckedOutAt *time.Time        `json:"checked_out_at"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    *time.Time        `json:"updated_at"`
+}
+
+// ReservationFilter narrows a reservation listing, nil criteria are not applied.
+// From and To select the reservations whose period overlaps [From, To).
+type ReservationFilter struct {
+	DeviceID *uuid.UUID
+	// DeviceIDs matches the reservations of any of the devices, when informed.
+	DeviceIDs  []uuid.UUID
+	ReservedBy *string
+	From       *time.Time
+	To         *time.Time
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation/entity"
+)
+
+//go:generate mockgen -source=reservation_repository.go -destination=../../mocks/reservation_repository_mock.go -package=mocks
+
+// ErrDeviceNotAvailable is returned on check out when the reserved device is not
+// available, so it cannot be handed over.
+var ErrDeviceNotAvailable = errors.New("reserved device is not available")
+
+// ErrDeviceNotReservable is returned on create when the device is inactive or
+// retired, so it cannot be booked.
+var ErrDeviceNotReservable = errors.New("device is not reservable")
+
+type ReservationRepository interface {
+	CreateReservation(ctx context.Context, reservation *entity.Reservation) error
+	GetReservationByID(ctx context.Context, id uuid.UUID) (entity.Reservation, error)
+	ListReservations(ctx context.Context, filter entity.ReservationFilter) ([]entity.Reservation, error)
+	CancelReservation(ctx context.Context, id uuid.UUID) (entity.Reservation, error)
+	CheckOutReservation(ctx context.Context, id uuid.UUID) (entity.Reservation, error)
+}
+
+// reservationColumns is the column list read into an entity.Reservation by reservationScanFields, in the same order.
+const reservationColumns = `id, device_id, reserved_by, lower(period), upper(period), note, status, checked_out_at, created_at, updated_at`
+
+func reservationScanFields(reservation *entity.Reservation) []any {
+	return []any{
+		&reservation.ID,
+		&reservation.DeviceID,
+		&reservation.ReservedBy,
+		&reservation.StartsAt,
+		&reservation.EndsAt,
+		&reservation.Note,
+		&reservation.Status,
+		&reservation.CheckedOutAt,
+		&reservation.CreatedAt,
+		&reservation.UpdatedAt,
+	}
+}
+
+type postgresReservationRepository struct {
+	db *sql.DB
+}
+
+func NewReservationRepository(db *sql.DB) *postgresReservationRepository {
+	return &postgresReservationRepository{db: db}
+}
+
+// CreateReservation books the device in a single transaction, its row locked
+// first so it is not made inactive, retired or deleted while being booked.
+// ErrDeviceNotReservable is returned when the device is inactive or retired,
+// sql.ErrNoRows when it does not exist, and an exclusion violation when the
+// period overlaps another reservation of the device that was not cancelled.
+func (r *postgresReservationRepository) CreateReservation(ctx context.Context, reservation *entity.Reservation) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	return database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		var state string
+		err := tx.QueryRowContext(ctx, `
+	SELECT state
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`, reservation.DeviceID).Scan(&state)
+		if err != nil {
+			return err
+		}
+
+		if state == "inactive" || state == "retired" {
+			return ErrDeviceNotReservable
+		}
+
+		return tx.QueryRowContext(ctx, `
+	INSERT INTO reservations (id, device_id, reserved_by, period, note)
+	VALUES ($1, $2, $3, tstzrange($4, $5, '[)'), $6)
+	RETURNING status, created_at, updated_at;`,
+			reservation.ID,
+			reservation.DeviceID,
+			reservation.ReservedBy,
+			reservation.StartsAt,
+			reservation.EndsAt,
+			reservation.Note,
+		).Scan(&reservation.Status, &reservation.CreatedAt, &reservation.UpdatedAt)
+	})
+}
+
+func (r *postgresReservationRepository) GetReservationByID(ctx context.Context, id uuid.UUID) (entity.Reservation, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var reservation entity.Reservation
+
+	query := `
+	SELECT ` + reservationColumns + `
+	FROM reservations
+	WHERE id = $1;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return reservation, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, id).Scan(reservationScanFields(&reservation)...)
+	if err != nil {
+		return reservation, err
+	}
+
+	return reservation, nil
+}
+
+func (r *postgresReservationRepository) ListReservations(ctx context.Context, filter entity.ReservationFilter) ([]entity.Reservation, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var reservations []entity.Reservation
+
+	conds := []string{}
+	params := []any{}
+	if filter.DeviceID != nil {
+		params = append(params, *filter.DeviceID)
+		conds = append(conds, fmt.Sprintf("device_id = $%d", len(params)))
+	}
+	if len(filter.DeviceIDs) > 0 {
+		params = append(params, pq.Array(filter.DeviceIDs))
+		conds = append(conds, fmt.Sprintf("device_id = ANY($%d::uuid[])", len(params)))
+	}
+	if filter.ReservedBy != nil {
+		params = append(params, *filter.ReservedBy)
+		conds = append(conds, fmt.Sprintf("lower(reserved_by) = lower($%d)", len(params)))
+	}
+	if filter.From != nil || filter.To != nil {
+		// a nil bound leaves the range unbounded on that side
+		params = append(params, filter.From, filter.To)
+		conds = append(conds, fmt.Sprintf("period && tstzrange($%d, $%d, '[)')", len(params)-1, len(params)))
+	}
+
+	query := `
+	SELECT ` + reservationColumns + `
+	FROM reservations`
+	if len(conds) > 0 {
+		query += `
+	WHERE ` + strings.Join(conds, " AND ")
+	}
+	query += `
+	ORDER BY lower(period), created_at;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reservation entity.Reservation
+		err = rows.Scan(reservationScanFields(&reservation)...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// CancelReservation frees the period of a booked reservation, sql.ErrNoRows is
+// returned when there is no booked reservation with the id.
+func (r *postgresReservationRepository) CancelReservation(ctx context.Context, id uuid.UUID) (entity.Reservation, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var reservation entity.Reservation
+
+	query := `
+	UPDATE reservations SET
+		status = 'cancelled',
+		updated_at = now()
+	WHERE id = $1 AND status = 'booked'
+	RETURNING ` + reservationColumns + `;`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return reservation, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, id).Scan(reservationScanFields(&reservation)...)
+	if err != nil {
+		return reservation, err
+	}
+
+	return reservation, nil
+}
+
+// CheckOutReservation hands the device of a booked reservation over, marking the
+// reservation as checked out and the device as in use in a single transaction.
+// sql.ErrNoRows is returned when there is no booked reservation with the id whose
+// period is going on, ErrDeviceNotAvailable when the device is not available.
+func (r *postgresReservationRepository) CheckOutReservation(ctx context.Context, id uuid.UUID) (entity.Reservation, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	var reservation entity.Reservation
+
+	err := database.WithinTx(ctx, r.db, nil, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+	UPDATE reservations SET
+		status = 'checked-out',
+		checked_out_at = now(),
+		updated_at = now()
+	WHERE id = $1 AND status = 'booked' AND period @> now()
+	RETURNING `+reservationColumns+`;`, id).Scan(reservationScanFields(&reservation)...)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, `
+	UPDATE devices SET
+		state = 'in-use',
+		updated_at = now()
+	WHERE id = $1 AND deleted_at IS NULL AND state = 'available';`, reservation.DeviceID)
+		if err != nil {
+			return err
+		}
+
+		rowCount, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowCount <= 0 {
+			return ErrDeviceNotAvailable
+		}
+
+		return nil
+	})
+
+	return reservation, err
+}
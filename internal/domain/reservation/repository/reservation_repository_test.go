@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation/entity"
+)
+
+var reservationRowColumns = []string{"id", "device_id", "reserved_by", "lower", "upper", "note", "status", "checked_out_at", "created_at", "updated_at"}
+
+var (
+	reservationID = uuid.MustParse("3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c06")
+	deviceID      = uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+	startsAt      = lo.Must(time.Parse(time.RFC3339, "2025-09-04T09:00:00Z"))
+	endsAt        = lo.Must(time.Parse(time.RFC3339, "2025-09-04T18:00:00Z"))
+	createdAt     = lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+)
+
+func Test_List_Reservations(t *testing.T) {
+	assert := assert.New(t)
+
+	listQuery := regexp.QuoteMeta(`
+	SELECT id, device_id, reserved_by, lower(period), upper(period), note, status, checked_out_at, created_at, updated_at
+	FROM reservations
+	WHERE device_id = $1 AND lower(reserved_by) = lower($2) AND period && tstzrange($3, $4, '[)')
+	ORDER BY lower(period), created_at;`)
+
+	from := lo.Must(time.Parse(time.RFC3339, "2025-09-01T00:00:00Z"))
+
+	db, mock, err := sqlmock.New()
+	assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+	mock.ExpectPrepare(listQuery).
+		WillBeClosed().
+		ExpectQuery().
+		WithArgs(deviceID, "Ana Lima", &from, nil).
+		WillReturnRows(sqlmock.NewRows(reservationRowColumns).
+			AddRow(reservationID, deviceID, "Ana Lima", startsAt, endsAt, nil, "booked", nil, createdAt, nil))
+
+	repository := NewReservationRepository(db)
+	reservations, err := repository.ListReservations(context.TODO(), entity.ReservationFilter{
+		DeviceID:   &deviceID,
+		ReservedBy: lo.ToPtr("Ana Lima"),
+		From:       &from,
+	})
+
+	assert.NoError(err)
+	assert.Equal([]entity.Reservation{{
+		ID:         reservationID,
+		DeviceID:   deviceID,
+		ReservedBy: "Ana Lima",
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+		Status:     entity.Booked,
+		CreatedAt:  createdAt,
+	}}, reservations)
+
+	mock.ExpectClose()
+
+	err = db.Close()
+	assert.NoErrorf(err, "db was not closed")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(err, "there were unfulfilled expectations")
+}
+
+func Test_Create_Reservation(t *testing.T) {
+	assert := assert.New(t)
+
+	lockDeviceQuery := regexp.QuoteMeta(`
+	SELECT state
+	FROM devices
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE;`)
+
+	insertQuery := regexp.QuoteMeta(`
+	INSERT INTO reservations (id, device_id, reserved_by, period, note)
+	VALUES ($1, $2, $3, tstzrange($4, $5, '[)'), $6)
+	RETURNING status, created_at, updated_at;`)
+
+	testCases := []struct {
+		name      string
+		sqlMock   func(mock sqlmock.Sqlmock)
+		wantedErr error
+	}{
+		{
+			name: "Create Reservation Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockDeviceQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("available"))
+				mock.ExpectQuery(insertQuery).
+					WithArgs(reservationID, deviceID, "Ana Lima", startsAt, endsAt, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "created_at", "updated_at"}).AddRow("booked", createdAt, nil))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Create Reservation Device Not Found Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockDeviceQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}))
+				mock.ExpectRollback()
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+		{
+			name: "Create Reservation Device Inactive Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockDeviceQuery).
+					WithArgs(deviceID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("inactive"))
+				mock.ExpectRollback()
+			},
+			wantedErr: ErrDeviceNotReservable,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewReservationRepository(db)
+
+			tt.sqlMock(mock)
+
+			reservation := entity.Reservation{ID: reservationID, DeviceID: deviceID, ReservedBy: "Ana Lima", StartsAt: startsAt, EndsAt: endsAt}
+			err = repository.CreateReservation(context.TODO(), &reservation)
+
+			assert.Equal(tt.wantedErr, err)
+			if tt.wantedErr == nil {
+				assert.Equal(entity.Booked, reservation.Status)
+				assert.Equal(createdAt, reservation.CreatedAt)
+			}
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_Check_Out_Reservation(t *testing.T) {
+	assert := assert.New(t)
+
+	checkOutQuery := regexp.QuoteMeta(`
+	UPDATE reservations SET
+		status = 'checked-out',
+		checked_out_at = now(),
+		updated_at = now()
+	WHERE id = $1 AND status = 'booked' AND period @> now()
+	RETURNING id, device_id, reserved_by, lower(period), upper(period), note, status, checked_out_at, created_at, updated_at;`)
+
+	deviceQuery := regexp.QuoteMeta(`
+	UPDATE devices SET
+		state = 'in-use',
+		updated_at = now()
+	WHERE id = $1 AND deleted_at IS NULL AND state = 'available';`)
+
+	checkedOutAt := startsAt.Add(5 * time.Minute)
+
+	testCases := []struct {
+		name         string
+		sqlMock      func(mock sqlmock.Sqlmock)
+		wantedErr    error
+		wantedResult entity.Reservation
+	}{
+		{
+			name: "Check Out Reservation Success Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkOutQuery).
+					WithArgs(reservationID).
+					WillReturnRows(sqlmock.NewRows(reservationRowColumns).
+						AddRow(reservationID, deviceID, "Ana Lima", startsAt, endsAt, nil, "checked-out", checkedOutAt, createdAt, checkedOutAt))
+				mock.ExpectExec(deviceQuery).
+					WithArgs(deviceID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantedResult: entity.Reservation{
+				ID:           reservationID,
+				DeviceID:     deviceID,
+				ReservedBy:   "Ana Lima",
+				StartsAt:     startsAt,
+				EndsAt:       endsAt,
+				Status:       entity.CheckedOut,
+				CheckedOutAt: &checkedOutAt,
+				CreatedAt:    createdAt,
+				UpdatedAt:    &checkedOutAt,
+			},
+		},
+		{
+			name: "Check Out Reservation Not Booked Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkOutQuery).
+					WithArgs(reservationID).
+					WillReturnRows(sqlmock.NewRows(reservationRowColumns))
+				mock.ExpectRollback()
+			},
+			wantedErr: sql.ErrNoRows,
+		},
+		{
+			name: "Check Out Reservation Device Not Available Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(checkOutQuery).
+					WithArgs(reservationID).
+					WillReturnRows(sqlmock.NewRows(reservationRowColumns).
+						AddRow(reservationID, deviceID, "Ana Lima", startsAt, endsAt, nil, "checked-out", checkedOutAt, createdAt, checkedOutAt))
+				mock.ExpectExec(deviceQuery).
+					WithArgs(deviceID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantedErr: ErrDeviceNotAvailable,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoErrorf(err, "an error '%s' was nto expected when opening a stub database connection", err)
+
+			repository := NewReservationRepository(db)
+
+			tt.sqlMock(mock)
+
+			reservation, err := repository.CheckOutReservation(context.TODO(), reservationID)
+
+			assert.Equal(tt.wantedErr, err)
+			if tt.wantedErr == nil {
+				assert.Equal(tt.wantedResult, reservation)
+			}
+
+			mock.ExpectClose()
+
+			err = db.Close()
+			assert.NoErrorf(err, "db was not closed")
+
+			err = mock.ExpectationsWereMet()
+			assert.NoErrorf(err, "there were unfulfilled expectations")
+		})
+	}
+}
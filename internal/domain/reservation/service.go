@@ -0,0 +1,212 @@
+package reservation
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	deviceentity "github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation/repository"
+)
+
+const (
+	maxReservedByLength      = 100
+	maxReservationNoteLength = 500
+)
+
+type ReservationService interface {
+	List(ctx context.Context, filter entity.ReservationFilter) ([]entity.Reservation, error)
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Reservation, error)
+	Create(ctx context.Context, reservation entity.Reservation) (entity.Reservation, error)
+	Cancel(ctx context.Context, id uuid.UUID) (entity.Reservation, error)
+	CheckOut(ctx context.Context, id uuid.UUID) (entity.Reservation, error)
+}
+
+type reservationService struct {
+	repo    repository.ReservationRepository
+	devices DeviceLookup
+}
+
+func NewReservationService(repo repository.ReservationRepository, devices DeviceLookup) *reservationService {
+	return &reservationService{repo: repo, devices: devices}
+}
+
+// List returns the reservations matching the filter ordered by start, when the
+// filter names a device it must exist.
+func (s *reservationService) List(ctx context.Context, filter entity.ReservationFilter) ([]entity.Reservation, error) {
+	if filter.DeviceID != nil {
+		if _, err := s.devices.GetByID(ctx, *filter.DeviceID); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.ReservedBy != nil {
+		reservedBy := strings.Join(strings.Fields(*filter.ReservedBy), " ")
+		filter.ReservedBy = &reservedBy
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, errors.NewDeviceError(errors.ErrInvalid, "invalid period, from must be before to", nil)
+	}
+
+	reservations, err := s.repo.ListReservations(ctx, filter)
+	if err != nil {
+		return nil, errors.NewDeviceError(errors.ErrInternal, "something went wrong while listing reservations", err)
+	}
+	return reservations, nil
+}
+
+func (s *reservationService) GetByID(ctx context.Context, id uuid.UUID) (entity.Reservation, error) {
+	reservation, err := s.repo.GetReservationByID(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Reservation{}, errors.NewDeviceError(errors.ErrNotFound, "reservation not found", err)
+		}
+		return entity.Reservation{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while retrieving reservation", err)
+	}
+	return reservation, nil
+}
+
+// Create books the device for the reservation period, inactive and retired
+// devices cannot be booked and periods of the same device never overlap.
+func (s *reservationService) Create(ctx context.Context, reservation entity.Reservation) (entity.Reservation, error) {
+	reservation.ID = uuid.New()
+	if err := normalizeAndValidate(&reservation); err != nil {
+		return reservation, err
+	}
+
+	device, err := s.devices.GetByID(ctx, reservation.DeviceID)
+	if err != nil {
+		return reservation, err
+	}
+	if device.State == deviceentity.Inactive || device.State == deviceentity.Retired {
+		return reservation, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("device is %s and cannot be reserved", device.State), nil)
+	}
+
+	err = s.repo.CreateReservation(ctx, &reservation)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return reservation, errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+		}
+		if goerrors.Is(err, repository.ErrDeviceNotReservable) {
+			return reservation, errors.NewDeviceError(errors.ErrConflict, "device was made inactive or retired meanwhile and cannot be reserved", err)
+		}
+		var pqErr *pq.Error
+		if goerrors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23P01":
+				return reservation, errors.NewDeviceError(errors.ErrConflict, "device is already reserved for part of this period", err)
+			case "23503":
+				return reservation, errors.NewDeviceError(errors.ErrNotFound, "device not found", err)
+			}
+		}
+		return reservation, errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating reservation", err)
+	}
+	return reservation, nil
+}
+
+// Cancel frees the period of a booked reservation.
+func (s *reservationService) Cancel(ctx context.Context, id uuid.UUID) (entity.Reservation, error) {
+	reservation, err := s.GetByID(ctx, id)
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+
+	if reservation.Status != entity.Booked {
+		return entity.Reservation{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("reservation is %s and cannot be cancelled", reservation.Status), nil)
+	}
+
+	reservation, err = s.repo.CancelReservation(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Reservation{}, errors.NewDeviceError(errors.ErrConflict, "reservation is no longer booked", err)
+		}
+		return entity.Reservation{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while cancelling reservation", err)
+	}
+	return reservation, nil
+}
+
+// CheckOut hands the device over to whoever booked it during the reservation
+// period, the device goes from available to in use.
+func (s *reservationService) CheckOut(ctx context.Context, id uuid.UUID) (entity.Reservation, error) {
+	reservation, err := s.GetByID(ctx, id)
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+
+	if reservation.Status != entity.Booked {
+		return entity.Reservation{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("reservation is %s and cannot be checked out", reservation.Status), nil)
+	}
+
+	now := time.Now()
+	if now.Before(reservation.StartsAt) {
+		return entity.Reservation{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("reservation starts at %s and cannot be checked out before", reservation.StartsAt.Format(time.RFC3339)), nil)
+	}
+	if !now.Before(reservation.EndsAt) {
+		return entity.Reservation{}, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("reservation ended at %s and can no longer be checked out", reservation.EndsAt.Format(time.RFC3339)), nil)
+	}
+
+	device, err := s.devices.GetByID(ctx, reservation.DeviceID)
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+	if device.State != deviceentity.Available {
+		return entity.Reservation{}, errors.NewDeviceError(errors.ErrConflict, fmt.Sprintf("device is %s and cannot be checked out", device.State), nil)
+	}
+
+	reservation, err = s.repo.CheckOutReservation(ctx, id)
+	if err != nil {
+		if goerrors.Is(err, repository.ErrDeviceNotAvailable) {
+			return entity.Reservation{}, errors.NewDeviceError(errors.ErrConflict, "device is no longer available and cannot be checked out", err)
+		}
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return entity.Reservation{}, errors.NewDeviceError(errors.ErrConflict, "reservation is no longer booked", err)
+		}
+		return entity.Reservation{}, errors.NewDeviceError(errors.ErrInternal, "something went wrong while checking out reservation", err)
+	}
+	return reservation, nil
+}
+
+// normalizeAndValidate collapses the spaces of the name the device is reserved by
+// and checks the reservation period, which must not be over yet.
+func normalizeAndValidate(reservation *entity.Reservation) error {
+	reservation.ReservedBy = strings.Join(strings.Fields(reservation.ReservedBy), " ")
+	if reservation.ReservedBy == "" {
+		return errors.NewDeviceError(errors.ErrInvalid, "reserved_by must not be empty", nil)
+	}
+	if len(reservation.ReservedBy) > maxReservedByLength {
+		return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("reserved_by must have at most %d characters", maxReservedByLength), nil)
+	}
+
+	if reservation.StartsAt.IsZero() || reservation.EndsAt.IsZero() {
+		return errors.NewDeviceError(errors.ErrInvalid, "starts_at and ends_at are required", nil)
+	}
+	if !reservation.StartsAt.Before(reservation.EndsAt) {
+		return errors.NewDeviceError(errors.ErrInvalid, "reservation must end after it starts", nil)
+	}
+	if !reservation.EndsAt.After(time.Now()) {
+		return errors.NewDeviceError(errors.ErrInvalid, "reservation must end in the future", nil)
+	}
+	reservation.StartsAt = reservation.StartsAt.UTC()
+	reservation.EndsAt = reservation.EndsAt.UTC()
+
+	if reservation.Note != nil {
+		note := strings.TrimSpace(*reservation.Note)
+		if len(note) > maxReservationNoteLength {
+			return errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("note must have at most %d characters", maxReservationNoteLength), nil)
+		}
+		reservation.Note = &note
+		if note == "" {
+			reservation.Note = nil
+		}
+	}
+
+	return nil
+}
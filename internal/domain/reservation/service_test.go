@@ -0,0 +1,273 @@
+package reservation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	deviceentity "github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation/repository"
+)
+
+var errDatabaseGeneric = fmt.Errorf("some database error")
+
+var pixel = deviceentity.Device{ID: uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"), Name: "Pixel 7", State: deviceentity.Available}
+
+func Test_Create_Reservation(t *testing.T) {
+	errOverlap := &pq.Error{Code: "23P01", Constraint: "reservations_no_overlap"}
+	thursday := time.Now().Add(72 * time.Hour).Truncate(time.Hour)
+
+	booking := entity.Reservation{
+		DeviceID:   pixel.ID,
+		ReservedBy: "  Ana   Lima ",
+		StartsAt:   thursday,
+		EndsAt:     thursday.Add(8 * time.Hour),
+	}
+
+	withChange := func(change func(r *entity.Reservation)) entity.Reservation {
+		reservation := booking
+		change(&reservation)
+		return reservation
+	}
+
+	tests := []struct {
+		name                string
+		reservation         entity.Reservation
+		device              deviceentity.Device
+		wantDeviceCalls     int
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantErr             error
+	}{
+		{
+			name:                "Create Reservation Success Case",
+			reservation:         booking,
+			device:              pixel,
+			wantDeviceCalls:     1,
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:        "Create Reservation Without Reserved By Case",
+			reservation: withChange(func(r *entity.Reservation) { r.ReservedBy = " " }),
+			wantErr:     errors.NewDeviceError(errors.ErrInvalid, "reserved_by must not be empty", nil),
+		},
+		{
+			name:        "Create Reservation Ending Before Starting Case",
+			reservation: withChange(func(r *entity.Reservation) { r.EndsAt = r.StartsAt }),
+			wantErr:     errors.NewDeviceError(errors.ErrInvalid, "reservation must end after it starts", nil),
+		},
+		{
+			name: "Create Reservation In The Past Case",
+			reservation: withChange(func(r *entity.Reservation) {
+				r.StartsAt = time.Now().Add(-48 * time.Hour)
+				r.EndsAt = time.Now().Add(-24 * time.Hour)
+			}),
+			wantErr: errors.NewDeviceError(errors.ErrInvalid, "reservation must end in the future", nil),
+		},
+		{
+			name:            "Create Reservation Inactive Device Case",
+			reservation:     booking,
+			device:          deviceentity.Device{ID: pixel.ID, State: deviceentity.Inactive},
+			wantDeviceCalls: 1,
+			wantErr:         errors.NewDeviceError(errors.ErrInvalid, "device is inactive and cannot be reserved", nil),
+		},
+		{
+			name:            "Create Reservation Retired Device Case",
+			reservation:     booking,
+			device:          deviceentity.Device{ID: pixel.ID, State: deviceentity.Retired},
+			wantDeviceCalls: 1,
+			wantErr:         errors.NewDeviceError(errors.ErrInvalid, "device is retired and cannot be reserved", nil),
+		},
+		{
+			name:                "Create Reservation Overlapping Case",
+			reservation:         booking,
+			device:              pixel,
+			wantDeviceCalls:     1,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errOverlap,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "device is already reserved for part of this period", errOverlap),
+		},
+		{
+			name:                "Create Reservation Device Deleted Meanwhile Case",
+			reservation:         booking,
+			device:              pixel,
+			wantDeviceCalls:     1,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   sql.ErrNoRows,
+			wantErr:             errors.NewDeviceError(errors.ErrNotFound, "device not found", sql.ErrNoRows),
+		},
+		{
+			name:                "Create Reservation Device Made Inactive Meanwhile Case",
+			reservation:         booking,
+			device:              pixel,
+			wantDeviceCalls:     1,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   repository.ErrDeviceNotReservable,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "device was made inactive or retired meanwhile and cannot be reserved", repository.ErrDeviceNotReservable),
+		},
+		{
+			name:                "Create Reservation Repository Error Case",
+			reservation:         booking,
+			device:              pixel,
+			wantDeviceCalls:     1,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   errDatabaseGeneric,
+			wantErr:             errors.NewDeviceError(errors.ErrInternal, "something went wrong while creating reservation", errDatabaseGeneric),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockReservationRepository(mockCtrl)
+			mockDevices := mocks.NewMockDeviceLookup(mockCtrl)
+			service := NewReservationService(mockRepo, mockDevices)
+
+			mockDevices.
+				EXPECT().
+				GetByID(context.TODO(), pixel.ID).
+				Return(tt.device, nil).
+				Times(tt.wantDeviceCalls)
+
+			mockRepo.
+				EXPECT().
+				CreateReservation(context.TODO(), gomock.Any()).
+				Return(tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			reservation, err := service.Create(context.TODO(), tt.reservation)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEqual(t, uuid.Nil, reservation.ID)
+				assert.Equal(t, "Ana Lima", reservation.ReservedBy)
+			}
+		})
+	}
+}
+
+func Test_Check_Out_Reservation(t *testing.T) {
+	reservationID := uuid.MustParse("3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c06")
+	now := time.Now()
+
+	ongoing := entity.Reservation{
+		ID:         reservationID,
+		DeviceID:   pixel.ID,
+		ReservedBy: "Ana Lima",
+		StartsAt:   now.Add(-time.Hour),
+		EndsAt:     now.Add(time.Hour),
+		Status:     entity.Booked,
+	}
+	upcoming := ongoing
+	upcoming.StartsAt = now.Add(24 * time.Hour)
+	upcoming.EndsAt = now.Add(48 * time.Hour)
+	cancelled := ongoing
+	cancelled.Status = entity.Cancelled
+
+	tests := []struct {
+		name                string
+		reservation         entity.Reservation
+		device              deviceentity.Device
+		wantDeviceCalls     int
+		wantRepositoryCalls int
+		wantRepositoryErr   error
+		wantErr             error
+	}{
+		{
+			name:                "Check Out Reservation Success Case",
+			reservation:         ongoing,
+			device:              pixel,
+			wantDeviceCalls:     1,
+			wantRepositoryCalls: 1,
+		},
+		{
+			name:        "Check Out Cancelled Reservation Case",
+			reservation: cancelled,
+			wantErr:     errors.NewDeviceError(errors.ErrInvalid, "reservation is cancelled and cannot be checked out", nil),
+		},
+		{
+			name:        "Check Out Reservation Before It Starts Case",
+			reservation: upcoming,
+			wantErr:     errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("reservation starts at %s and cannot be checked out before", upcoming.StartsAt.Format(time.RFC3339)), nil),
+		},
+		{
+			name:            "Check Out Reservation Device In Use Case",
+			reservation:     ongoing,
+			device:          deviceentity.Device{ID: pixel.ID, State: deviceentity.InUse},
+			wantDeviceCalls: 1,
+			wantErr:         errors.NewDeviceError(errors.ErrConflict, "device is in-use and cannot be checked out", nil),
+		},
+		{
+			name:                "Check Out Reservation Device Taken Meanwhile Case",
+			reservation:         ongoing,
+			device:              pixel,
+			wantDeviceCalls:     1,
+			wantRepositoryCalls: 1,
+			wantRepositoryErr:   repository.ErrDeviceNotAvailable,
+			wantErr:             errors.NewDeviceError(errors.ErrConflict, "device is no longer available and cannot be checked out", repository.ErrDeviceNotAvailable),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mocks.NewMockReservationRepository(mockCtrl)
+			mockDevices := mocks.NewMockDeviceLookup(mockCtrl)
+			service := NewReservationService(mockRepo, mockDevices)
+
+			mockRepo.
+				EXPECT().
+				GetReservationByID(context.TODO(), reservationID).
+				Return(tt.reservation, nil)
+
+			mockDevices.
+				EXPECT().
+				GetByID(context.TODO(), pixel.ID).
+				Return(tt.device, nil).
+				Times(tt.wantDeviceCalls)
+
+			checkedOut := tt.reservation
+			checkedOut.Status = entity.CheckedOut
+			mockRepo.
+				EXPECT().
+				CheckOutReservation(context.TODO(), reservationID).
+				Return(checkedOut, tt.wantRepositoryErr).
+				Times(tt.wantRepositoryCalls)
+
+			reservation, err := service.CheckOut(context.TODO(), reservationID)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, entity.CheckedOut, reservation.Status)
+			}
+		})
+	}
+}
+
+func Test_Get_Reservation_Not_Found(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	reservationID := uuid.MustParse("3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c06")
+	mockRepo := mocks.NewMockReservationRepository(mockCtrl)
+	service := NewReservationService(mockRepo, mocks.NewMockDeviceLookup(mockCtrl))
+
+	mockRepo.
+		EXPECT().
+		GetReservationByID(context.TODO(), reservationID).
+		Return(entity.Reservation{}, sql.ErrNoRows)
+
+	_, err := service.GetByID(context.TODO(), reservationID)
+	assert.Equal(t, errors.NewDeviceError(errors.ErrNotFound, "reservation not found", sql.ErrNoRows), err)
+}
@@ -0,0 +1,31 @@
+// Package tenant carries the tenant a request is made on behalf of.
+package tenant
+
+import (
+	"context"
+	"regexp"
+)
+
+type contextKey struct{}
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// ValidID reports whether id starts with a letter or digit and contains only
+// letters, digits and _ . -, up to 64 characters.
+func ValidID(id string) bool {
+	return validID.MatchString(id)
+}
+
+// WithID returns a copy of ctx carrying the tenant ID, an empty ID leaves ctx unchanged.
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// IDFromContext returns the tenant ID carried by ctx, if any.
+func IDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok
+}
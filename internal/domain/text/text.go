@@ -0,0 +1,26 @@
+// Package text normalizes the free texts informed on the records of devices.
+package text
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+)
+
+// Normalize trims an optional text, blank texts are treated as not informed.
+// Texts longer than maxLength are rejected as invalid, naming the field.
+func Normalize(value *string, field string, maxLength int) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	text := strings.TrimSpace(*value)
+	if text == "" {
+		return nil, nil
+	}
+	if len(text) > maxLength {
+		return nil, errors.NewDeviceError(errors.ErrInvalid, fmt.Sprintf("%s must have at most %d characters", field, maxLength), nil)
+	}
+	return &text, nil
+}
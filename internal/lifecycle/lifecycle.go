@@ -0,0 +1,178 @@
+// Package lifecycle starts the components of the application in order and
+// stops them in reverse order on shutdown, within a bounded deadline.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Component is a part of the application started and stopped along with it,
+// such as a server, a background worker or the database pool.
+type Component struct {
+	Name string
+	// Run serves the component until it is stopped, calling started once it
+	// serves, nil for the components ready once created. The next component is
+	// only started then. An error returned before Stop is called shuts the
+	// application down.
+	Run func(started func()) error
+	// Stop stops the component, giving up once ctx is done. Nil for the
+	// components stopped along with Run.
+	Stop func(ctx context.Context) error
+}
+
+// Manager runs the components added, stopping them in reverse order so each
+// one is stopped before the ones it depends on, added before it.
+type Manager struct {
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+
+	components []Component
+	ready      atomic.Bool
+}
+
+// NewManager returns a manager stopping the components within shutdownTimeout,
+// reporting it is not ready drainDelay before, so the load balancers stop
+// sending requests before the servers stop accepting them.
+func NewManager(shutdownTimeout, drainDelay time.Duration) *Manager {
+	return &Manager{shutdownTimeout: shutdownTimeout, drainDelay: drainDelay}
+}
+
+// Add adds c, started after and stopped before the components added so far.
+func (m *Manager) Add(c Component) {
+	m.components = append(m.components, c)
+}
+
+// Ready tells whether every component was started and the shutdown has not
+// begun.
+func (m *Manager) Ready() bool {
+	return m.ready.Load()
+}
+
+// Run starts the components in order, each one once the previous one reported
+// it started, and reports it is ready once the last one did. Once ctx is done
+// or a component fails, the components started are stopped. Every one is
+// stopped even when the deadline is exceeded, the errors being returned
+// together.
+func (m *Manager) Run(ctx context.Context) error {
+	failed := make(chan error, len(m.components))
+	var stopping atomic.Bool
+
+	var errs []error
+	launched, err := m.start(ctx, failed, &stopping)
+	if err != nil {
+		log.Printf("lifecycle: %v, shutting down", err)
+		errs = append(errs, err)
+	} else if launched == len(m.components) {
+		m.ready.Store(true)
+		log.Println("lifecycle: ready")
+
+		select {
+		case <-ctx.Done():
+		case err := <-failed:
+			log.Printf("lifecycle: %v, shutting down", err)
+			errs = append(errs, err)
+		}
+	}
+
+	m.ready.Store(false)
+	stopping.Store(true)
+
+	deadline := time.Now().Add(m.shutdownTimeout)
+	shutdownCtx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	if m.drainDelay > 0 && launched == len(m.components) {
+		log.Printf("lifecycle: not ready, draining for %v", m.drainDelay)
+		select {
+		case <-time.After(m.drainDelay):
+		case <-shutdownCtx.Done():
+		}
+	}
+
+	for i := launched - 1; i >= 0; i-- {
+		c := m.components[i]
+		if c.Stop == nil {
+			continue
+		}
+		started := time.Now()
+		if err := c.Stop(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+			continue
+		}
+		log.Printf("lifecycle: %s stopped in %v", c.Name, time.Since(started).Round(time.Millisecond))
+	}
+
+	return errors.Join(errs...)
+}
+
+// start starts the components in order, returning how many were launched,
+// the ones to stop. It gives up when ctx is done or a component fails before
+// it started, the error of the component being returned.
+func (m *Manager) start(ctx context.Context, failed chan error, stopping *atomic.Bool) (int, error) {
+	for i, c := range m.components {
+		if c.Run == nil {
+			continue
+		}
+
+		started := make(chan struct{})
+		var once sync.Once
+		returned := make(chan error, 1)
+		go func() {
+			err := c.Run(func() { once.Do(func() { close(started) }) })
+			if err != nil {
+				err = fmt.Errorf("%s: %w", c.Name, err)
+			}
+			returned <- err
+			if err != nil && !stopping.Load() {
+				failed <- err
+			}
+		}()
+
+		select {
+		case <-started:
+		case err := <-returned:
+			// a component returning before it reported it started is done
+			// starting, failing when it returned an error
+			if err != nil {
+				<-failed
+				return i + 1, err
+			}
+		case <-ctx.Done():
+			return i + 1, nil
+		}
+	}
+
+	return len(m.components), nil
+}
+
+// Worker returns the component running fn until it is stopped, the context of
+// fn being canceled on Stop, which waits for fn to return.
+func Worker(name string, fn func(ctx context.Context)) Component {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	return Component{
+		Name: name,
+		Run: func(started func()) error {
+			defer close(done)
+			started()
+			fn(ctx)
+			return nil
+		},
+		Stop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	}
+}
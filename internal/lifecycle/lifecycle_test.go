@@ -0,0 +1,219 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder records the components stopped, in order.
+type recorder struct {
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (r *recorder) component(name string, stop func(ctx context.Context) error) Component {
+	return Component{
+		Name: name,
+		Stop: func(ctx context.Context) error {
+			r.mu.Lock()
+			r.stopped = append(r.stopped, name)
+			r.mu.Unlock()
+			if stop != nil {
+				return stop(ctx)
+			}
+			return nil
+		},
+	}
+}
+
+func Test_Manager_Run(t *testing.T) {
+	t.Run("Stop In Reverse Order Case", func(t *testing.T) {
+		r := &recorder{}
+		m := NewManager(time.Second, 0)
+		m.Add(r.component("database", nil))
+		m.Add(r.component("worker", nil))
+		m.Add(r.component("server", nil))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.NoError(t, m.Run(ctx))
+		assert.Equal(t, []string{"server", "worker", "database"}, r.stopped)
+	})
+
+	t.Run("Component Failed Case", func(t *testing.T) {
+		r := &recorder{}
+		m := NewManager(time.Second, 0)
+		m.Add(r.component("database", nil))
+		m.Add(Component{Name: "server", Run: func(func()) error { return errors.New("address already in use") }})
+
+		err := m.Run(context.Background())
+
+		assert.EqualError(t, err, "server: address already in use")
+		assert.Equal(t, []string{"database"}, r.stopped)
+	})
+
+	t.Run("Deadline Exceeded Stopping Every Component Case", func(t *testing.T) {
+		r := &recorder{}
+		m := NewManager(10*time.Millisecond, 0)
+		m.Add(r.component("database", nil))
+		m.Add(r.component("server", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := m.Run(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "server: ")
+		assert.Equal(t, []string{"server", "database"}, r.stopped)
+	})
+
+	t.Run("Not Ready While Draining Case", func(t *testing.T) {
+		var readyOnStop bool
+		m := NewManager(time.Second, 10*time.Millisecond)
+		started := make(chan struct{})
+		m.Add(Component{
+			Name: "server",
+			Run: func(func()) error {
+				close(started)
+				return nil
+			},
+			Stop: func(context.Context) error {
+				readyOnStop = m.Ready()
+				return nil
+			},
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- m.Run(ctx) }()
+
+		<-started
+		assert.Eventually(t, m.Ready, time.Second, time.Millisecond)
+		began := time.Now()
+		cancel()
+
+		assert.NoError(t, <-done)
+		assert.GreaterOrEqual(t, time.Since(began), 10*time.Millisecond)
+		assert.False(t, readyOnStop)
+		assert.False(t, m.Ready())
+	})
+}
+
+func Test_Manager_Run_Start_In_Order(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	release := make(chan struct{})
+	serve := func(name string, wait chan struct{}) Component {
+		stop := make(chan struct{})
+		return Component{
+			Name: name,
+			Run: func(started func()) error {
+				if wait != nil {
+					<-wait
+				}
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				started()
+				<-stop
+				return nil
+			},
+			Stop: func(context.Context) error {
+				close(stop)
+				return nil
+			},
+		}
+	}
+	m := NewManager(time.Second, 0)
+	m.Add(Component{Name: "database"})
+	m.Add(serve("listener", release))
+	m.Add(serve("server", nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+
+	time.Sleep(10 * time.Millisecond)
+	mu.Lock()
+	assert.Empty(t, order, "server started before the listener reported it started")
+	mu.Unlock()
+	assert.False(t, m.Ready(), "ready before every component started")
+
+	close(release)
+	assert.Eventually(t, m.Ready, time.Second, time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []string{"listener", "server"}, order)
+	mu.Unlock()
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func Test_Manager_Run_Canceled_While_Starting(t *testing.T) {
+	var stopped []string
+	release := make(chan struct{})
+	m := NewManager(time.Second, 0)
+	m.Add(Component{Name: "database", Stop: func(context.Context) error {
+		stopped = append(stopped, "database")
+		return nil
+	}})
+	m.Add(Component{
+		Name: "listener",
+		Run: func(func()) error {
+			<-release
+			return nil
+		},
+		Stop: func(context.Context) error {
+			stopped = append(stopped, "listener")
+			close(release)
+			return nil
+		},
+	})
+	m.Add(Component{Name: "server", Stop: func(context.Context) error {
+		stopped = append(stopped, "server")
+		return nil
+	}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, m.Run(ctx))
+	assert.Equal(t, []string{"listener", "database"}, stopped)
+	assert.False(t, m.Ready())
+}
+
+func Test_Worker(t *testing.T) {
+	t.Run("Stop Case", func(t *testing.T) {
+		w := Worker("worker", func(ctx context.Context) { <-ctx.Done() })
+		go w.Run(func() {})
+
+		assert.NoError(t, w.Stop(context.Background()))
+	})
+
+	t.Run("Stop Deadline Exceeded Case", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		w := Worker("worker", func(context.Context) { <-release })
+		go w.Run(func() {})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, w.Stop(ctx), context.DeadlineExceeded)
+	})
+}
+
+func Test_Requests(t *testing.T) {
+	r := &Requests{}
+	first := r.Begin()
+	second := r.Begin()
+	assert.Equal(t, int64(2), r.InFlight())
+
+	first()
+	second()
+	assert.Equal(t, int64(0), r.InFlight())
+}
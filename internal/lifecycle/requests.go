@@ -0,0 +1,20 @@
+package lifecycle
+
+import "sync/atomic"
+
+// Requests counts the requests in flight, to tell on shutdown how many are
+// still being served.
+type Requests struct {
+	inFlight atomic.Int64
+}
+
+// Begin counts a request in flight until the returned func is called.
+func (r *Requests) Begin() (end func()) {
+	r.inFlight.Add(1)
+	return func() { r.inFlight.Add(-1) }
+}
+
+// InFlight returns the number of requests in flight.
+func (r *Requests) InFlight() int64 {
+	return r.inFlight.Load()
+}
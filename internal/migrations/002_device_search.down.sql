@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_devices_brand_trgm;
+DROP INDEX IF EXISTS idx_devices_name_trgm;
+DROP INDEX IF EXISTS idx_devices_search_vector;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS search_vector;
+
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,16 @@
+-- Enable trigram matching used for fuzzy search on name and brand
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Full-text document built from name (higher weight) and brand
+ALTER TABLE devices
+    ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(brand, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_devices_search_vector ON devices USING GIN (search_vector);
+
+CREATE INDEX idx_devices_name_trgm ON devices USING GIN (name gin_trgm_ops);
+
+CREATE INDEX idx_devices_brand_trgm ON devices USING GIN (brand gin_trgm_ops);
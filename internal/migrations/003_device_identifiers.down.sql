@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS uq_devices_imei;
+DROP INDEX IF EXISTS uq_devices_serial_number;
+
+ALTER TABLE devices
+    DROP COLUMN IF EXISTS os_version,
+    DROP COLUMN IF EXISTS model_identifier,
+    DROP COLUMN IF EXISTS imei,
+    DROP COLUMN IF EXISTS serial_number;
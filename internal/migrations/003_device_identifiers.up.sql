@@ -0,0 +1,11 @@
+ALTER TABLE devices
+    ADD COLUMN serial_number TEXT,
+    ADD COLUMN imei TEXT,
+    ADD COLUMN model_identifier TEXT,
+    ADD COLUMN os_version TEXT;
+
+-- Serial numbers and IMEI/MEID must be unique among the devices that were not deleted,
+-- deleted devices keep their identifiers so they can be registered again
+CREATE UNIQUE INDEX uq_devices_serial_number ON devices (serial_number) WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX uq_devices_imei ON devices (imei) WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS attribute_definitions;
+
+DROP TYPE IF EXISTS attribute_type;
+
+DROP INDEX IF EXISTS idx_devices_attributes;
+DROP INDEX IF EXISTS idx_devices_tags;
+
+ALTER TABLE devices
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS tags;
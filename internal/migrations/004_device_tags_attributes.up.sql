@@ -0,0 +1,22 @@
+ALTER TABLE devices
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+-- Containment indexes backing the tag (tags @> ...) and attribute (attributes @> ...) filters
+CREATE INDEX idx_devices_tags ON devices USING GIN (tags);
+
+CREATE INDEX idx_devices_attributes ON devices USING GIN (attributes jsonb_path_ops);
+
+-- Optional attribute schema of each tenant, devices are validated against it before being persisted
+CREATE TYPE attribute_type AS ENUM ('string', 'number', 'boolean');
+
+CREATE TABLE attribute_definitions (
+    tenant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    type attribute_type NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    enum TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    PRIMARY KEY (tenant_id, name)
+);
@@ -0,0 +1,55 @@
+CREATE TABLE brands (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    -- lower cased name without extra spaces and company suffixes, "APPLE Inc" and "apple " are both "apple"
+    normalized_name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_brands_normalized_name ON brands (normalized_name);
+
+-- Alternative names resolved to a brand, eg. "hp" for Hewlett-Packard, stored normalized
+CREATE TABLE brand_aliases (
+    alias TEXT PRIMARY KEY,
+    brand_id UUID NOT NULL REFERENCES brands (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_brand_aliases_brand_id ON brand_aliases (brand_id);
+
+-- Mirrors entity.NormalizeBrandName, only used to backfill the existing devices
+CREATE FUNCTION pg_temp.normalize_brand_name(name TEXT) RETURNS TEXT AS $$
+    SELECT trim(regexp_replace(
+        regexp_replace(lower(trim(name)), '\s+', ' ', 'g'),
+        '([ ,]+(inc|incorporated|corp|corporation|co|company|ltd|limited|llc|plc|gmbh|ag|sa)\.?)+$', ''
+    ));
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- One brand per normalized name, named after its most used spelling
+INSERT INTO brands (id, name, normalized_name)
+SELECT gen_random_uuid(), spelling, normalized_name
+FROM (
+    SELECT DISTINCT ON (normalized_name) normalized_name, spelling
+    FROM (
+        SELECT pg_temp.normalize_brand_name(brand) AS normalized_name,
+               regexp_replace(trim(brand), '\s+', ' ', 'g') AS spelling,
+               count(*) AS usage
+        FROM devices
+        GROUP BY 1, 2
+    ) spellings
+    ORDER BY normalized_name, usage DESC, spelling
+) brand_names;
+
+ALTER TABLE devices ADD COLUMN brand_id UUID REFERENCES brands (id);
+
+UPDATE devices d
+SET brand_id = b.id,
+    brand = b.name
+FROM brands b
+WHERE b.normalized_name = pg_temp.normalize_brand_name(d.brand);
+
+ALTER TABLE devices ALTER COLUMN brand_id SET NOT NULL;
+
+CREATE INDEX idx_devices_brand_id ON devices (brand_id);
+
+DROP FUNCTION pg_temp.normalize_brand_name(TEXT);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_devices_model_id;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS model_id;
+
+DROP TABLE IF EXISTS models;
+
+DROP TYPE IF EXISTS os_family;
+
+DROP TYPE IF EXISTS form_factor;
@@ -0,0 +1,26 @@
+CREATE TYPE form_factor AS ENUM ('phone', 'tablet', 'laptop', 'wearable', 'other');
+
+CREATE TYPE os_family AS ENUM ('android', 'ios', 'ipados', 'windows', 'macos', 'linux', 'chromeos', 'other');
+
+CREATE TABLE models (
+    id UUID PRIMARY KEY,
+    brand_id UUID NOT NULL REFERENCES brands (id),
+    name TEXT NOT NULL,
+    -- lower cased name without extra spaces, "Pixel  7" and "pixel 7" are the same model of a brand
+    normalized_name TEXT NOT NULL,
+    release_year INT CHECK (release_year BETWEEN 1970 AND 2100),
+    form_factor form_factor NOT NULL,
+    os_family os_family NOT NULL,
+    -- storage capacities the model is sold with, in GB
+    storage_variants INT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_models_brand_normalized_name ON models (brand_id, normalized_name);
+
+CREATE INDEX idx_models_normalized_name ON models (normalized_name);
+
+ALTER TABLE devices ADD COLUMN model_id UUID REFERENCES models (id);
+
+CREATE INDEX idx_devices_model_id ON devices (model_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS device_history;
+
+DROP INDEX IF EXISTS idx_devices_location_id;
+
+ALTER TABLE devices DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS locations;
+
+DROP TYPE IF EXISTS location_kind;
@@ -0,0 +1,34 @@
+CREATE TYPE location_kind AS ENUM ('site', 'room', 'shelf');
+
+-- Places devices are kept in: sites hold rooms and rooms hold shelves
+CREATE TABLE locations (
+    id UUID PRIMARY KEY,
+    parent_id UUID REFERENCES locations (id),
+    kind location_kind NOT NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    CONSTRAINT locations_sites_are_roots CHECK ((kind = 'site') = (parent_id IS NULL))
+);
+
+-- names are unique among the locations of a parent, ignoring case, sites among sites
+CREATE UNIQUE INDEX uq_locations_parent_name ON locations (COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), lower(name));
+
+CREATE INDEX idx_locations_parent_id ON locations (parent_id);
+
+ALTER TABLE devices ADD COLUMN location_id UUID REFERENCES locations (id);
+
+CREATE INDEX idx_devices_location_id ON devices (location_id);
+
+-- What happened to a device over time, newest events are read first
+CREATE TABLE device_history (
+    id UUID PRIMARY KEY,
+    device_id UUID NOT NULL REFERENCES devices (id),
+    event TEXT NOT NULL CONSTRAINT device_history_event_check CHECK (event IN ('moved')),
+    from_location_id UUID REFERENCES locations (id) ON DELETE SET NULL,
+    to_location_id UUID REFERENCES locations (id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_device_history_device_id_created_at ON device_history (device_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS reservations;
+
+DROP TYPE IF EXISTS reservation_status;
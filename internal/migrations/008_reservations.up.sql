@@ -0,0 +1,22 @@
+-- btree_gist lets the exclusion constraint compare device ids with =
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TYPE reservation_status AS ENUM ('booked', 'checked-out', 'cancelled');
+
+-- Bookings of a device for a period, a device is booked by one user at a time
+CREATE TABLE reservations (
+    id UUID PRIMARY KEY,
+    device_id UUID NOT NULL REFERENCES devices (id),
+    reserved_by TEXT NOT NULL,
+    period TSTZRANGE NOT NULL,
+    note TEXT,
+    status reservation_status NOT NULL DEFAULT 'booked',
+    checked_out_at TIMESTAMPTZ,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    CONSTRAINT reservations_period_not_empty CHECK (NOT isempty(period)),
+    -- cancelled reservations free their period for other bookings
+    CONSTRAINT reservations_no_overlap EXCLUDE USING gist (device_id WITH =, period WITH &&) WHERE (status <> 'cancelled')
+);
+
+CREATE INDEX idx_reservations_reserved_by ON reservations (lower(reserved_by), lower(period));
@@ -0,0 +1,10 @@
+-- Enum values cannot be dropped, the type is recreated without maintenance
+UPDATE devices SET state = 'inactive' WHERE state = 'maintenance';
+
+ALTER TYPE device_state RENAME TO device_state_old;
+
+CREATE TYPE device_state AS ENUM ('available', 'in-use', 'inactive');
+
+ALTER TABLE devices ALTER COLUMN state TYPE device_state USING state::text::device_state;
+
+DROP TYPE device_state_old;
@@ -0,0 +1,2 @@
+-- Added on its own as a new enum value cannot be used in the transaction adding it
+ALTER TYPE device_state ADD VALUE IF NOT EXISTS 'maintenance';
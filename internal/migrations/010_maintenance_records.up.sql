@@ -0,0 +1,19 @@
+-- Repairs of devices, a device stays under maintenance while its record is open
+CREATE TABLE maintenance_records (
+    id UUID PRIMARY KEY,
+    device_id UUID NOT NULL REFERENCES devices (id),
+    reason TEXT NOT NULL,
+    vendor TEXT,
+    cost NUMERIC(12, 2) CONSTRAINT maintenance_records_cost_check CHECK (cost >= 0),
+    expected_return_date DATE,
+    resolution TEXT,
+    opened_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP
+);
+
+-- a device has at most one open maintenance record
+CREATE UNIQUE INDEX uq_maintenance_records_open_device ON maintenance_records (device_id) WHERE closed_at IS NULL;
+
+CREATE INDEX idx_maintenance_records_device_id_opened_at ON maintenance_records (device_id, opened_at DESC);
+
+CREATE INDEX idx_maintenance_records_open_expected_return_date ON maintenance_records (expected_return_date) WHERE closed_at IS NULL;
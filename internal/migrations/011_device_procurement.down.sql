@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_devices_warranty_ends_on;
+
+ALTER TABLE devices
+    DROP CONSTRAINT IF EXISTS devices_purchase_price_currency_check,
+    DROP COLUMN IF EXISTS warranty_ends_on,
+    DROP COLUMN IF EXISTS invoice_reference,
+    DROP COLUMN IF EXISTS supplier,
+    DROP COLUMN IF EXISTS purchase_currency,
+    DROP COLUMN IF EXISTS purchase_price,
+    DROP COLUMN IF EXISTS purchase_date;
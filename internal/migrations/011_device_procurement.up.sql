@@ -0,0 +1,11 @@
+-- Procurement data of devices, prices are kept in the currency they were paid in
+ALTER TABLE devices
+    ADD COLUMN purchase_date DATE,
+    ADD COLUMN purchase_price NUMERIC(12, 2) CONSTRAINT devices_purchase_price_check CHECK (purchase_price >= 0),
+    ADD COLUMN purchase_currency CHAR(3),
+    ADD COLUMN supplier TEXT,
+    ADD COLUMN invoice_reference TEXT,
+    ADD COLUMN warranty_ends_on DATE,
+    ADD CONSTRAINT devices_purchase_price_currency_check CHECK ((purchase_price IS NULL) = (purchase_currency IS NULL));
+
+CREATE INDEX idx_devices_warranty_ends_on ON devices (warranty_ends_on) WHERE deleted_at IS NULL;
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS trg_devices_state_history ON devices;
+
+DROP FUNCTION IF EXISTS record_device_state_history();
+
+DROP INDEX IF EXISTS idx_device_history_state_events_created_at;
+
+DELETE FROM device_history WHERE event <> 'moved';
+
+ALTER TABLE device_history
+    DROP COLUMN IF EXISTS to_state,
+    DROP COLUMN IF EXISTS from_state,
+    DROP CONSTRAINT device_history_event_check,
+    ADD CONSTRAINT device_history_event_check CHECK (event IN ('moved'));
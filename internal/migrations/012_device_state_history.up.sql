@@ -0,0 +1,42 @@
+-- The history also tracks the state of devices, so inventory counts can be
+-- rebuilt for any past day
+ALTER TABLE device_history
+    DROP CONSTRAINT device_history_event_check,
+    ADD CONSTRAINT device_history_event_check CHECK (event IN ('moved', 'created', 'state-changed', 'deleted')),
+    ADD COLUMN from_state device_state,
+    ADD COLUMN to_state device_state;
+
+CREATE INDEX idx_device_history_state_events_created_at ON device_history (created_at) WHERE event <> 'moved';
+
+-- State changes come from device updates, reservation check outs and maintenance,
+-- recording them on the table keeps every path covered
+CREATE FUNCTION record_device_state_history() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO device_history (id, device_id, event, to_state, created_at)
+        VALUES (gen_random_uuid(), NEW.id, 'created', NEW.state, NEW.created_at);
+    ELSIF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
+        INSERT INTO device_history (id, device_id, event, from_state)
+        VALUES (gen_random_uuid(), NEW.id, 'deleted', OLD.state);
+    ELSIF NEW.state IS DISTINCT FROM OLD.state THEN
+        INSERT INTO device_history (id, device_id, event, from_state, to_state)
+        VALUES (gen_random_uuid(), NEW.id, 'state-changed', OLD.state, NEW.state);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_devices_state_history
+    AFTER INSERT OR UPDATE OF state, deleted_at ON devices
+    FOR EACH ROW EXECUTE FUNCTION record_device_state_history();
+
+-- Devices created before have their current state as the created one, as
+-- earlier changes were not recorded
+INSERT INTO device_history (id, device_id, event, to_state, created_at)
+SELECT gen_random_uuid(), id, 'created', state, created_at
+FROM devices;
+
+INSERT INTO device_history (id, device_id, event, from_state, created_at)
+SELECT gen_random_uuid(), id, 'deleted', state, deleted_at
+FROM devices
+WHERE deleted_at IS NOT NULL;
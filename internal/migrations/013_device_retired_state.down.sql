@@ -0,0 +1,23 @@
+-- Enum values cannot be dropped, the type is recreated without retired. The
+-- state history trigger depends on the state column and is recreated as well
+DROP TRIGGER IF EXISTS trg_devices_state_history ON devices;
+
+UPDATE devices SET state = 'inactive' WHERE state = 'retired';
+UPDATE device_history SET from_state = 'inactive' WHERE from_state = 'retired';
+UPDATE device_history SET to_state = 'inactive' WHERE to_state = 'retired';
+
+ALTER TYPE device_state RENAME TO device_state_old;
+
+CREATE TYPE device_state AS ENUM ('available', 'in-use', 'inactive', 'maintenance');
+
+ALTER TABLE devices ALTER COLUMN state TYPE device_state USING state::text::device_state;
+
+ALTER TABLE device_history
+    ALTER COLUMN from_state TYPE device_state USING from_state::text::device_state,
+    ALTER COLUMN to_state TYPE device_state USING to_state::text::device_state;
+
+DROP TYPE device_state_old;
+
+CREATE TRIGGER trg_devices_state_history
+    AFTER INSERT OR UPDATE OF state, deleted_at ON devices
+    FOR EACH ROW EXECUTE FUNCTION record_device_state_history();
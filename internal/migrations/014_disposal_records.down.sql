@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS disposal_records;
+
+DROP TYPE IF EXISTS disposal_reason;
@@ -0,0 +1,15 @@
+CREATE TYPE disposal_reason AS ENUM ('lost', 'stolen', 'recycled', 'sold');
+
+-- How a retired device left the inventory, a device is disposed of only once
+CREATE TABLE disposal_records (
+    id UUID PRIMARY KEY,
+    device_id UUID NOT NULL REFERENCES devices (id),
+    reason disposal_reason NOT NULL,
+    data_wiped BOOLEAN NOT NULL,
+    certificate_reference TEXT,
+    note TEXT,
+    disposed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_disposal_records_device_id UNIQUE (device_id),
+    -- devices handed over to a recycler or a buyer must have been wiped
+    CONSTRAINT disposal_records_data_wiped_check CHECK (data_wiped OR reason IN ('lost', 'stolen'))
+);
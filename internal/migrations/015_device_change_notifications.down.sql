@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_devices_change_notification ON devices;
+
+DROP FUNCTION IF EXISTS notify_device_change();
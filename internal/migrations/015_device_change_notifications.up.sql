@@ -0,0 +1,24 @@
+-- Device changes are notified on the device_changes channel, whatever made
+-- them, so watchers of every instance learn about them
+CREATE FUNCTION notify_device_change() RETURNS trigger AS $$
+DECLARE
+    change text;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        change := 'created';
+    ELSIF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
+        change := 'deleted';
+    ELSIF NEW.deleted_at IS NULL THEN
+        change := 'updated';
+    ELSE
+        RETURN NEW;
+    END IF;
+
+    PERFORM pg_notify('device_changes', json_build_object('device_id', NEW.id, 'change', change, 'changed_at', now())::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_devices_change_notification
+    AFTER INSERT OR UPDATE ON devices
+    FOR EACH ROW EXECUTE FUNCTION notify_device_change();
@@ -0,0 +1,9 @@
+-- Token buckets of the rate limited clients, shared by the replicas, a bucket
+-- holding the requests left as of updated_at
+CREATE TABLE rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
@@ -0,0 +1,97 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/spec"
+	"github.com/labstack/echo/v4"
+	echoSwagger "github.com/swaggo/echo-swagger"
+	"github.com/swaggo/swag"
+	"github.com/tiagos4ntos/device-manager/docs"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+// instanceName is the swag registry entry of the document served by Handler,
+// kept apart from the one registered by the generated docs package.
+const instanceName = "device-manager"
+
+// Operation binds a documented route to the schema of its query string.
+type Operation struct {
+	Method string
+	Path   string
+	Query  *queryparam.Schema
+}
+
+// Handler serves the Swagger UI with the generated document, replacing the query
+// parameters of each operation by the ones declared in its schema.
+func Handler(operations ...Operation) (echo.HandlerFunc, error) {
+	doc, err := Build(docs.SwaggerInfo.ReadDoc(), operations...)
+	if err != nil {
+		return nil, err
+	}
+
+	swag.Register(instanceName, document(doc))
+
+	return echoSwagger.EchoWrapHandler(echoSwagger.InstanceName(instanceName)), nil
+}
+
+// Build returns baseDoc with the query parameters of the operations taken from their schemas.
+func Build(baseDoc string, operations ...Operation) (string, error) {
+	var swagger spec.Swagger
+	if err := json.Unmarshal([]byte(baseDoc), &swagger); err != nil {
+		return "", fmt.Errorf("invalid swagger document: %w", err)
+	}
+
+	for _, op := range operations {
+		var pathItem spec.PathItem
+		if swagger.Paths != nil {
+			pathItem = swagger.Paths.Paths[op.Path]
+		}
+
+		operation := operationFor(&pathItem, op.Method)
+		if operation == nil {
+			return "", fmt.Errorf("operation %s %s is not documented", op.Method, op.Path)
+		}
+
+		parameters := make([]spec.Parameter, 0, len(operation.Parameters))
+		for _, p := range operation.Parameters {
+			if p.In != "query" {
+				parameters = append(parameters, p)
+			}
+		}
+		operation.Parameters = append(parameters, op.Query.SwaggerParameters()...)
+
+		swagger.Paths.Paths[op.Path] = pathItem
+	}
+
+	doc, err := json.Marshal(swagger)
+	if err != nil {
+		return "", err
+	}
+	return string(doc), nil
+}
+
+func operationFor(pathItem *spec.PathItem, method string) *spec.Operation {
+	switch method {
+	case http.MethodGet:
+		return pathItem.Get
+	case http.MethodPost:
+		return pathItem.Post
+	case http.MethodPut:
+		return pathItem.Put
+	case http.MethodPatch:
+		return pathItem.Patch
+	case http.MethodDelete:
+		return pathItem.Delete
+	default:
+		return nil
+	}
+}
+
+type document string
+
+func (d document) ReadDoc() string {
+	return string(d)
+}
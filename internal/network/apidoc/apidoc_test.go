@@ -0,0 +1,53 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+const baseDoc = `{
+	"swagger": "2.0",
+	"paths": {
+		"/devices": {
+			"get": {
+				"parameters": [
+					{"type": "string", "name": "stale", "in": "query"},
+					{"type": "string", "name": "X-Request-ID", "in": "header"}
+				]
+			}
+		}
+	}
+}`
+
+func Test_Build(t *testing.T) {
+	assert := assert.New(t)
+
+	schema := queryparam.NewSchema(
+		queryparam.Param{Name: "brand", Description: "Brand name"},
+		queryparam.Param{Name: "state", Multi: true, Enum: []string{"available"}},
+	)
+
+	doc, err := Build(baseDoc, Operation{Method: http.MethodGet, Path: "/devices", Query: schema})
+	assert.NoError(err)
+
+	var swagger spec.Swagger
+	assert.NoError(json.Unmarshal([]byte(doc), &swagger))
+
+	parameters := swagger.Paths.Paths["/devices"].Get.Parameters
+	names := make([]string, 0, len(parameters))
+	for _, p := range parameters {
+		names = append(names, p.In+":"+p.Name)
+	}
+	assert.Equal([]string{"header:X-Request-ID", "query:brand", "query:state"}, names)
+}
+
+func Test_Build_Fails_On_Undocumented_Operation(t *testing.T) {
+	_, err := Build(baseDoc, Operation{Method: http.MethodPost, Path: "/devices", Query: queryparam.NewSchema()})
+
+	assert.EqualError(t, err, "operation POST /devices is not documented")
+}
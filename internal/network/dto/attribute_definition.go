@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+)
+
+var validAttributeTypes = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"boolean": true,
+}
+
+type PutAttributeDefinitionRequest struct {
+	Type     string   `json:"type" validate:"required,oneof=string number boolean" example:"string"`
+	Required bool     `json:"required" example:"true"`
+	Enum     []string `json:"enum" example:"vodafone,orange,o2"`
+}
+
+func (r PutAttributeDefinitionRequest) Validate() error {
+	if !validAttributeTypes[r.Type] {
+		return fmt.Errorf("invalid attribute type %s", r.Type)
+	}
+	return nil
+}
+
+type AttributeDefinitionResponse struct {
+	Name      string     `json:"name" example:"carrier"`
+	Type      string     `json:"type" example:"string"`
+	Required  bool       `json:"required" example:"true"`
+	Enum      []string   `json:"enum" example:"vodafone,orange,o2"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-08-31T21:00:00Z"`
+	UpdatedAt *time.Time `json:"updated_at" example:"2025-08-31T21:00:00Z"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type BrandRequest struct {
+	Name    string   `json:"name" validate:"required" example:"Hewlett-Packard"`
+	Aliases []string `json:"aliases" example:"hp,hewlett packard"`
+}
+
+type BrandResponse struct {
+	ID        string     `json:"id" example:"3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"`
+	Name      string     `json:"name" example:"Hewlett-Packard"`
+	Aliases   []string   `json:"aliases" example:"hp,hewlett packard"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-08-31T21:00:00Z"`
+	UpdatedAt *time.Time `json:"updated_at" example:"2025-08-31T21:00:00Z"`
+}
@@ -3,46 +3,138 @@ package dto
 import (
 	"fmt"
 	"time"
-)
 
-var validDeviceStatuses = map[string]bool{
-	"available": true,
-	"in-use":    true,
-	"inactive":  true,
-}
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
 
 type CreateDeviceRequest struct {
-	Name  string `json:"name" validate:"required" example:"Moto G100"`
-	Brand string `json:"brand" validate:"required" example:"Motorola"`
-	State string `json:"state" validate:"required,oneof=available in-use inactive" example:"available"`
+	Name            string  `json:"name" validate:"required" example:"Moto G100"`
+	Brand           string  `json:"brand" example:"Motorola"`
+	ModelID         *string `json:"model_id" example:"d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"`
+	State           string  `json:"state" validate:"required,oneof=available in-use inactive" example:"available"`
+	SerialNumber    *string `json:"serial_number" example:"ZY22C5XKQ7"`
+	IMEI            *string `json:"imei" example:"490154203237518"`
+	ModelIdentifier *string `json:"model_identifier" example:"XT2125-4"`
+	OSVersion       *string `json:"os_version" example:"Android 13"`
+	// PurchaseDate and WarrantyEndsOn are dates in the YYYY-MM-DD format
+	PurchaseDate     *string        `json:"purchase_date" example:"2025-03-10"`
+	PurchasePrice    *float64       `json:"purchase_price" example:"349.90"`
+	PurchaseCurrency *string        `json:"purchase_currency" example:"EUR"`
+	Supplier         *string        `json:"supplier" example:"ACME Distribution"`
+	InvoiceReference *string        `json:"invoice_reference" example:"INV-2025-0042"`
+	WarrantyEndsOn   *string        `json:"warranty_ends_on" example:"2027-03-10"`
+	Tags             []string       `json:"tags" example:"qa,lab"`
+	Attributes       map[string]any `json:"attributes" swaggertype:"object,string" example:"carrier:vodafone,cost_center:cc-42"`
 }
 
 func (r CreateDeviceRequest) Validate() error {
-	if !validDeviceStatuses[r.State] {
+	if !entity.DeviceState(r.State).Writable() {
 		return fmt.Errorf("invalid device state %s", r.State)
 	}
 	return nil
 }
 
 type DeviceResponse struct {
-	ID        string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name      string     `json:"name" example:"iPhone 13"`
-	Brand     string     `json:"brand" example:"Apple"`
-	State     string     `json:"state" example:"available"`
-	CreatedAt time.Time  `json:"created_at" example:"2025-08-31T21:00:00Z"`
-	UpdatedAt *time.Time `json:"updated_at" example:"2025-08-31T21:00:00Z"`
-	DeletedAt *time.Time `json:"deleted_at" example:"null"`
+	ID               string         `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name             string         `json:"name" example:"iPhone 13"`
+	Brand            string         `json:"brand" example:"Apple"`
+	BrandID          string         `json:"brand_id" example:"3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"`
+	ModelID          *string        `json:"model_id" example:"d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"`
+	LocationID       *string        `json:"location_id" example:"7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603"`
+	State            string         `json:"state" example:"available"`
+	SerialNumber     *string        `json:"serial_number" example:"F2LXK1ABCD12"`
+	IMEI             *string        `json:"imei" example:"490154203237518"`
+	ModelIdentifier  *string        `json:"model_identifier" example:"iPhone14,5"`
+	OSVersion        *string        `json:"os_version" example:"iOS 17.5"`
+	PurchaseDate     *string        `json:"purchase_date" example:"2025-03-10"`
+	PurchasePrice    *float64       `json:"purchase_price" example:"999.00"`
+	PurchaseCurrency *string        `json:"purchase_currency" example:"EUR"`
+	Supplier         *string        `json:"supplier" example:"ACME Distribution"`
+	InvoiceReference *string        `json:"invoice_reference" example:"INV-2025-0042"`
+	WarrantyEndsOn   *string        `json:"warranty_ends_on" example:"2027-03-10"`
+	Tags             []string       `json:"tags" example:"qa,lab"`
+	Attributes       map[string]any `json:"attributes" swaggertype:"object,string" example:"carrier:vodafone,cost_center:cc-42"`
+	CreatedAt        time.Time      `json:"created_at" example:"2025-08-31T21:00:00Z"`
+	UpdatedAt        *time.Time     `json:"updated_at" example:"2025-08-31T21:00:00Z"`
+	DeletedAt        *time.Time     `json:"deleted_at" example:"null"`
 }
 
 type UpdateDeviceRequest struct {
-	Name  string `json:"name" example:"Galaxy S21"`
-	Brand string `json:"brand" example:"Samsung"`
-	State string `json:"state" validate:"required,oneof=available in-use inactive" example:"in-use"`
+	Name            string  `json:"name" example:"Galaxy S21"`
+	Brand           string  `json:"brand" example:"Samsung"`
+	ModelID         *string `json:"model_id" example:"d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"`
+	State           string  `json:"state" validate:"required,oneof=available in-use inactive" example:"in-use"`
+	SerialNumber    *string `json:"serial_number" example:"R58R12ABCDE"`
+	IMEI            *string `json:"imei" example:"356938035643809"`
+	ModelIdentifier *string `json:"model_identifier" example:"SM-G991B"`
+	OSVersion       *string `json:"os_version" example:"Android 14"`
+	// PurchaseDate and WarrantyEndsOn are dates in the YYYY-MM-DD format
+	PurchaseDate     *string        `json:"purchase_date" example:"2025-03-10"`
+	PurchasePrice    *float64       `json:"purchase_price" example:"799.00"`
+	PurchaseCurrency *string        `json:"purchase_currency" example:"EUR"`
+	Supplier         *string        `json:"supplier" example:"ACME Distribution"`
+	InvoiceReference *string        `json:"invoice_reference" example:"INV-2025-0042"`
+	WarrantyEndsOn   *string        `json:"warranty_ends_on" example:"2027-03-10"`
+	Tags             []string       `json:"tags" example:"qa,lab"`
+	Attributes       map[string]any `json:"attributes" swaggertype:"object,string" example:"carrier:vodafone,cost_center:cc-42"`
 }
 
 func (r UpdateDeviceRequest) Validate() error {
-	if !validDeviceStatuses[r.State] {
+	if !entity.DeviceState(r.State).Writable() {
 		return fmt.Errorf("invalid device state %s", r.State)
 	}
 	return nil
 }
+
+type DeviceSearchResponse struct {
+	DeviceResponse
+	Rank       float64                `json:"rank" example:"0.6079271"`
+	Highlights DeviceSearchHighlights `json:"highlights"`
+}
+
+type DeviceSearchHighlights struct {
+	Name  string `json:"name" example:"<mark>iPhone</mark> 13"`
+	Brand string `json:"brand" example:"Apple"`
+}
+
+type MoveDeviceRequest struct {
+	LocationID string  `json:"location_id" validate:"required" example:"7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603"`
+	Note       *string `json:"note" example:"back from the field test"`
+}
+
+type DeviceHistoryEventResponse struct {
+	ID             string    `json:"id" example:"0e1f2a3b-4c5d-4e6f-8a7b-9c0d1e2f3a4b"`
+	Event          string    `json:"event" example:"moved"`
+	FromLocationID *string   `json:"from_location_id" example:"5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401"`
+	ToLocationID   *string   `json:"to_location_id" example:"7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603"`
+	FromState      *string   `json:"from_state" example:"null"`
+	ToState        *string   `json:"to_state" example:"null"`
+	Note           *string   `json:"note" example:"back from the field test"`
+	CreatedAt      time.Time `json:"created_at" example:"2025-09-01T10:00:00Z"`
+}
+
+type DeviceStatsResponse struct {
+	Total   int                  `json:"total" example:"42"`
+	ByState map[string]int       `json:"by_state" swaggertype:"object,integer" example:"available:30,in-use:8,inactive:3,maintenance:1"`
+	ByBrand []BrandCountResponse `json:"by_brand"`
+	// ByLocation is only present when by_location is requested
+	ByLocation []LocationCountResponse   `json:"by_location,omitempty"`
+	Daily      []DailyStateCountResponse `json:"daily"`
+}
+
+type BrandCountResponse struct {
+	BrandID string `json:"brand_id" example:"3f0c6a52-8a43-4d59-a1a4-2f0f3b1c9e10"`
+	Brand   string `json:"brand" example:"Samsung"`
+	Devices int    `json:"devices" example:"18"`
+}
+
+type LocationCountResponse struct {
+	LocationID *string `json:"location_id" example:"7f3a4b50-6c7d-4e8f-a091-b2c3d4e5f603"`
+	Name       *string `json:"name" example:"Shelf A"`
+	Devices    int     `json:"devices" example:"12"`
+}
+
+type DailyStateCountResponse struct {
+	Day     string         `json:"day" example:"2025-09-01"`
+	ByState map[string]int `json:"by_state" swaggertype:"object,integer" example:"available:30,in-use:8,inactive:3,maintenance:1"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type RetireDeviceRequest struct {
+	Reason               string  `json:"reason" validate:"required" example:"recycled" enums:"lost,stolen,recycled,sold"`
+	DataWiped            bool    `json:"data_wiped" example:"true"`
+	CertificateReference *string `json:"certificate_reference" example:"WEEE-2025-0193"`
+	Note                 *string `json:"note" example:"battery swollen, sent to the recycling partner"`
+}
+
+type DisposalResponse struct {
+	ID                   string    `json:"id" example:"2d3e4f50-6a7b-4c8d-9e0f-1a2b3c4d5e06"`
+	DeviceID             string    `json:"device_id" example:"b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"`
+	Reason               string    `json:"reason" example:"recycled"`
+	DataWiped            bool      `json:"data_wiped" example:"true"`
+	CertificateReference *string   `json:"certificate_reference" example:"WEEE-2025-0193"`
+	Note                 *string   `json:"note" example:"battery swollen, sent to the recycling partner"`
+	DisposedAt           time.Time `json:"disposed_at" example:"2025-09-10T16:00:00Z"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type HealthResponse struct {
+	Status string `json:"status" example:"ready" enums:"live,ready,draining"`
+}
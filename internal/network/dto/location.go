@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type LocationRequest struct {
+	ParentID *string `json:"parent_id" example:"4c0d1e20-3f4a-4b5c-9d6e-7f8091a2b300"`
+	Kind     string  `json:"kind" validate:"required,oneof=site room shelf" example:"room"`
+	Name     string  `json:"name" validate:"required" example:"QA Lab"`
+}
+
+type LocationResponse struct {
+	ID       string  `json:"id" example:"5d1e2f30-4a5b-4c6d-8e7f-90a1b2c3d401"`
+	ParentID *string `json:"parent_id" example:"4c0d1e20-3f4a-4b5c-9d6e-7f8091a2b300"`
+	Kind     string  `json:"kind" example:"room"`
+	Name     string  `json:"name" example:"QA Lab"`
+	// DeviceCounts holds the number of devices per state at the location and below it
+	DeviceCounts map[string]int `json:"device_counts" swaggertype:"object,integer" example:"available:3,in-use:2,inactive:0"`
+	CreatedAt    time.Time      `json:"created_at" example:"2025-08-31T21:00:00Z"`
+	UpdatedAt    *time.Time     `json:"updated_at" example:"2025-08-31T21:00:00Z"`
+}
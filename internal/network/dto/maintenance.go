@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type OpenMaintenanceRequest struct {
+	Reason string   `json:"reason" validate:"required" example:"cracked screen"`
+	Vendor *string  `json:"vendor" example:"iFix Lisboa"`
+	Cost   *float64 `json:"cost" example:"89.90"`
+	// ExpectedReturnDate is a date in the YYYY-MM-DD format
+	ExpectedReturnDate *string `json:"expected_return_date" example:"2025-09-15"`
+}
+
+type CloseMaintenanceRequest struct {
+	Resolution *string  `json:"resolution" example:"screen replaced"`
+	Cost       *float64 `json:"cost" example:"120.00"`
+}
+
+type MaintenanceResponse struct {
+	ID                 string     `json:"id" example:"9c8b7a6d-5e4f-4a3b-9c2d-1e0f9a8b7c05"`
+	DeviceID           string     `json:"device_id" example:"b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"`
+	Reason             string     `json:"reason" example:"cracked screen"`
+	Vendor             *string    `json:"vendor" example:"iFix Lisboa"`
+	Cost               *float64   `json:"cost" example:"89.90"`
+	ExpectedReturnDate *string    `json:"expected_return_date" example:"2025-09-15"`
+	Resolution         *string    `json:"resolution" example:"screen replaced"`
+	Overdue            bool       `json:"overdue" example:"false"`
+	OpenedAt           time.Time  `json:"opened_at" example:"2025-09-01T10:00:00Z"`
+	ClosedAt           *time.Time `json:"closed_at" example:"2025-09-12T16:30:00Z"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type ModelRequest struct {
+	BrandID         string  `json:"brand_id" validate:"required" example:"1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704"`
+	Name            string  `json:"name" validate:"required" example:"Pixel 7"`
+	ReleaseYear     *int    `json:"release_year" example:"2022"`
+	FormFactor      string  `json:"form_factor" validate:"required,oneof=phone tablet laptop wearable other" example:"phone"`
+	OSFamily        string  `json:"os_family" validate:"required,oneof=android ios ipados windows macos linux chromeos other" example:"android"`
+	StorageVariants []int32 `json:"storage_variants" example:"128,256"`
+}
+
+type ModelResponse struct {
+	ID              string     `json:"id" example:"d2a4f1e0-7b3c-4c8e-9f61-5a0b2c3d4e05"`
+	BrandID         string     `json:"brand_id" example:"1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704"`
+	Brand           string     `json:"brand" example:"Google"`
+	Name            string     `json:"name" example:"Pixel 7"`
+	ReleaseYear     *int       `json:"release_year" example:"2022"`
+	FormFactor      string     `json:"form_factor" example:"phone"`
+	OSFamily        string     `json:"os_family" example:"android"`
+	StorageVariants []int32    `json:"storage_variants" example:"128,256"`
+	CreatedAt       time.Time  `json:"created_at" example:"2025-08-31T21:00:00Z"`
+	UpdatedAt       *time.Time `json:"updated_at" example:"2025-08-31T21:00:00Z"`
+}
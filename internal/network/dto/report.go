@@ -0,0 +1,36 @@
+package dto
+
+type WarrantyEntryResponse struct {
+	DeviceID       string  `json:"device_id" example:"b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"`
+	Name           string  `json:"name" example:"Galaxy S21"`
+	Brand          string  `json:"brand" example:"Samsung"`
+	SerialNumber   *string `json:"serial_number" example:"R58R12ABCDE"`
+	Supplier       *string `json:"supplier" example:"ACME Distribution"`
+	WarrantyEndsOn string  `json:"warranty_ends_on" example:"2025-09-20"`
+	DaysLeft       int     `json:"days_left" example:"19"`
+}
+
+type DeviceBookValueResponse struct {
+	DeviceID      string  `json:"device_id" example:"b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"`
+	Name          string  `json:"name" example:"Galaxy S21"`
+	Brand         string  `json:"brand" example:"Samsung"`
+	PurchaseDate  string  `json:"purchase_date" example:"2024-03-10"`
+	PurchasePrice float64 `json:"purchase_price" example:"799.00"`
+	Currency      string  `json:"currency" example:"EUR"`
+	BookValue     float64 `json:"book_value" example:"404.97"`
+}
+
+type BrandBookValueResponse struct {
+	Brand         string  `json:"brand" example:"Samsung"`
+	Currency      string  `json:"currency" example:"EUR"`
+	Devices       int     `json:"devices" example:"12"`
+	PurchaseTotal float64 `json:"purchase_total" example:"9588.00"`
+	BookValue     float64 `json:"book_value" example:"4859.64"`
+}
+
+type DepreciationReportResponse struct {
+	AsOf             string                    `json:"as_of" example:"2025-09-01"`
+	UsefulLifeMonths int                       `json:"useful_life_months" example:"36"`
+	Devices          []DeviceBookValueResponse `json:"devices"`
+	Brands           []BrandBookValueResponse  `json:"brands"`
+}
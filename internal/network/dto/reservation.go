@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type ReservationRequest struct {
+	ReservedBy string    `json:"reserved_by" validate:"required" example:"Ana Lima"`
+	StartsAt   time.Time `json:"starts_at" validate:"required" example:"2025-09-04T09:00:00Z"`
+	EndsAt     time.Time `json:"ends_at" validate:"required" example:"2025-09-04T18:00:00Z"`
+	Note       *string   `json:"note" example:"regression tests of the 2.4 release"`
+}
+
+type ReservationResponse struct {
+	ID           string     `json:"id" example:"3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c06"`
+	DeviceID     string     `json:"device_id" example:"b44ecc02-872e-4c18-8d2a-ac09dfc4b49a"`
+	ReservedBy   string     `json:"reserved_by" example:"Ana Lima"`
+	StartsAt     time.Time  `json:"starts_at" example:"2025-09-04T09:00:00Z"`
+	EndsAt       time.Time  `json:"ends_at" example:"2025-09-04T18:00:00Z"`
+	Note         *string    `json:"note" example:"regression tests of the 2.4 release"`
+	Status       string     `json:"status" example:"booked"`
+	CheckedOutAt *time.Time `json:"checked_out_at" example:"2025-09-04T09:05:00Z"`
+	CreatedAt    time.Time  `json:"created_at" example:"2025-08-31T21:00:00Z"`
+	UpdatedAt    *time.Time `json:"updated_at" example:"2025-08-31T21:00:00Z"`
+}
@@ -5,8 +5,9 @@ import "fmt"
 type ApiErrorType string
 
 const (
-	ErrNotFound ApiErrorType = "not_found"
-	ErrInvalid  ApiErrorType = "invalid"
+	ErrNotFound        ApiErrorType = "not_found"
+	ErrInvalid         ApiErrorType = "invalid"
+	ErrTooManyRequests ApiErrorType = "too_many_requests"
 )
 
 type ApiError struct {
@@ -22,6 +23,10 @@ func (e *ApiError) Error() string {
 	return e.Message
 }
 
+func (e *ApiError) Unwrap() error {
+	return e.Err
+}
+
 func NewApiError(t ApiErrorType, msg string, err error) *ApiError {
 	return &ApiError{
 		Type:    t,
@@ -1,24 +1,99 @@
 package errors
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/database"
 	deviceerrors "github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/requestid"
 )
 
+// RetryAfterSeconds is how long clients are told to wait before retrying a
+// request that failed because the database was unavailable or too slow.
+const RetryAfterSeconds = 5
+
+// StatusClientClosedRequest is logged for the requests given up by the client,
+// which never reads the response.
+const StatusClientClosedRequest = 499
+
 func Handle(c echo.Context, err error) error {
 
-	c.Logger().Error(err)
+	ctx := c.Request().Context()
+	logged := any(err)
+	if id, ok := requestid.IDFromContext(ctx); ok {
+		logged = fmt.Sprintf("request %s: %v", id, err)
+	}
+
+	if IsCanceled(ctx, err) {
+		c.Logger().Warn(logged)
+		return c.JSON(StatusClientClosedRequest, ErrorResponse("Client closed request"))
+	}
 
-	switch e := err.(type) {
-	case *ApiError:
-		return c.JSON(mapApiErrorsToStatusCode(e.Type), ErrorResponse(e.Error()))
-	case *deviceerrors.DeviceError:
-		return c.JSON(mapDomainErrorsToStatusCode(e.Type), ErrorResponse(e.Message))
-	default:
-		return c.JSON(http.StatusInternalServerError, ErrorResponse("Internal server error"))
+	c.Logger().Error(logged)
+
+	if IsTimeout(err) {
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(RetryAfterSeconds))
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse("Request timed out, retry later"))
+	}
+
+	if IsUnavailable(err) {
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(RetryAfterSeconds))
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse("Service temporarily unavailable, retry later"))
+	}
+
+	if IsConcurrentUpdate(err) {
+		return c.JSON(http.StatusConflict, ErrorResponse("Changed by a concurrent request, retry"))
+	}
+
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return c.JSON(mapApiErrorsToStatusCode(apiErr.Type), ErrorResponse(apiErr.Error()))
+	}
+	var deviceErr *deviceerrors.DeviceError
+	if errors.As(err, &deviceErr) {
+		return c.JSON(mapDomainErrorsToStatusCode(deviceErr.Type), ErrorResponse(deviceErr.Message))
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorResponse("Internal server error"))
+}
+
+// IsUnavailable tells whether err is caused by the database being unavailable,
+// which is expected to be over shortly, whatever the type of the device error
+// wrapping it.
+func IsUnavailable(err error) bool {
+	var e *deviceerrors.DeviceError
+	if errors.As(err, &e) {
+		return database.IsUnavailable(e.Err)
+	}
+	return database.IsUnavailable(err)
+}
+
+// IsConcurrentUpdate tells whether err is caused by a transaction that kept
+// failing on the changes made by concurrent ones, which may succeed when
+// retried.
+func IsConcurrentUpdate(err error) bool {
+	return database.IsSerializationFailure(err)
+}
+
+// IsCanceled tells whether err is caused by the request of ctx being given up
+// by the client.
+func IsCanceled(ctx context.Context, err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled)
+}
+
+// IsTimeout tells whether err is caused by an operation running out of time,
+// the request deadline or the database ones, whatever the type of the device
+// error wrapping it.
+func IsTimeout(err error) bool {
+	var e *deviceerrors.DeviceError
+	if errors.As(err, &e) {
+		return database.IsTimeout(e.Err)
 	}
+	return database.IsTimeout(err)
 }
 
 func mapDomainErrorsToStatusCode(t deviceerrors.DeviceErrorType) int {
@@ -27,6 +102,8 @@ func mapDomainErrorsToStatusCode(t deviceerrors.DeviceErrorType) int {
 		return http.StatusNotFound
 	case deviceerrors.ErrInvalid:
 		return http.StatusBadRequest
+	case deviceerrors.ErrConflict:
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
@@ -38,6 +115,8 @@ func mapApiErrorsToStatusCode(t ApiErrorType) int {
 		return http.StatusNotFound
 	case ErrInvalid:
 		return http.StatusBadRequest
+	case ErrTooManyRequests:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	deviceerrors "github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+)
+
+func Test_Handle(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantBody       string
+		wantRetryAfter string
+	}{
+		{
+			name:       "Handle Not Found Case",
+			err:        deviceerrors.NewDeviceError(deviceerrors.ErrNotFound, "device not found", nil),
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error":"device not found"}`,
+		},
+		{
+			name:       "Handle Internal Case",
+			err:        deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "something went wrong while listing devices", fmt.Errorf("some database error")),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"error":"something went wrong while listing devices"}`,
+		},
+		{
+			name:           "Handle Database Unavailable Case",
+			err:            deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "something went wrong while listing devices", driver.ErrBadConn),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantBody:       `{"error":"Service temporarily unavailable, retry later"}`,
+			wantRetryAfter: "5",
+		},
+		{
+			name:           "Handle Database Unavailable Not Internal Case",
+			err:            deviceerrors.NewDeviceError(deviceerrors.ErrNotFound, "something went wrong while retrieving device", driver.ErrBadConn),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantBody:       `{"error":"Service temporarily unavailable, retry later"}`,
+			wantRetryAfter: "5",
+		},
+		{
+			name:           "Handle Wrapped Database Unavailable Case",
+			err:            fmt.Errorf("updating device: %w", deviceerrors.NewDeviceError(deviceerrors.ErrInvalid, "something went wrong while retrieving device", driver.ErrBadConn)),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantBody:       `{"error":"Service temporarily unavailable, retry later"}`,
+			wantRetryAfter: "5",
+		},
+		{
+			name:           "Handle Database Timeout Case",
+			err:            deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "something went wrong while listing devices", context.DeadlineExceeded),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantBody:       `{"error":"Request timed out, retry later"}`,
+			wantRetryAfter: "5",
+		},
+		{
+			name:           "Handle Statement Canceled Case",
+			err:            deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "something went wrong while listing devices", &pq.Error{Code: "57014"}),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantBody:       `{"error":"Request timed out, retry later"}`,
+			wantRetryAfter: "5",
+		},
+		{
+			name:       "Handle Concurrent Update Case",
+			err:        deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "something went wrong while updating device", &pq.Error{Code: "40001"}),
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"error":"Changed by a concurrent request, retry"}`,
+		},
+		{
+			name:       "Handle Client Canceled Case",
+			err:        deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "something went wrong while listing devices", context.Canceled),
+			wantStatus: StatusClientClosedRequest,
+			wantBody:   `{"error":"Client closed request"}`,
+		},
+		{
+			name:       "Handle Invalid Case",
+			err:        NewApiError(ErrInvalid, "invalid device id format, must be an uuid", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid device id format, must be an uuid"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/devices", nil), rec)
+
+			assert.NoError(t, Handle(c, tt.err))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			assert.Equal(t, tt.wantRetryAfter, rec.Header().Get(echo.HeaderRetryAfter))
+		})
+	}
+}
+
+func Test_Handle_Canceled_Request(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/devices", nil).WithContext(ctx), rec)
+
+	// lib/pq reports the statements canceled along with the request as query_canceled
+	err := deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "something went wrong while listing devices", &pq.Error{Code: "57014"})
+
+	assert.NoError(t, Handle(c, err))
+	assert.Equal(t, StatusClientClosedRequest, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderRetryAfter))
+}
@@ -0,0 +1,81 @@
+package graphqlapi
+
+import (
+	"errors"
+	"log"
+
+	deviceerrors "github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+)
+
+// Error codes set on the extensions of the errors, the counterpart of the HTTP
+// status the REST API answers with.
+const (
+	codeNotFound = "NOT_FOUND"
+	codeInvalid  = "BAD_USER_INPUT"
+	codeConflict = "CONFLICT"
+	codeInternal = "INTERNAL_SERVER_ERROR"
+	// codeUnavailable is reported when the database is unavailable or the
+	// operation timed out, it may be retried shortly.
+	codeUnavailable = "SERVICE_UNAVAILABLE"
+)
+
+// resolverError is reported on the errors of the response along with its code.
+type resolverError struct {
+	code    string
+	message string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// toResolverError turns a domain error into the error reported to the client,
+// hiding the details of unexpected ones. Only the server errors are logged, as
+// the REST error handler does.
+func toResolverError(err error) error {
+	if errorhandler.IsTimeout(err) {
+		log.Printf("graphql: %v", err)
+		return &resolverError{code: codeUnavailable, message: "Request timed out, retry later"}
+	}
+	if errorhandler.IsUnavailable(err) {
+		log.Printf("graphql: %v", err)
+		return &resolverError{code: codeUnavailable, message: "Service temporarily unavailable, retry later"}
+	}
+	if errorhandler.IsConcurrentUpdate(err) {
+		return &resolverError{code: codeConflict, message: "Changed by a concurrent request, retry"}
+	}
+
+	var e *deviceerrors.DeviceError
+	if !errors.As(err, &e) {
+		log.Printf("graphql: %v", err)
+		return &resolverError{code: codeInternal, message: "Internal server error"}
+	}
+
+	code := mapDomainErrorsToCode(e.Type)
+	if code == codeInternal {
+		log.Printf("graphql: %v", err)
+	}
+	return &resolverError{code: code, message: e.Message}
+}
+
+func mapDomainErrorsToCode(t deviceerrors.DeviceErrorType) string {
+	switch t {
+	case deviceerrors.ErrNotFound:
+		return codeNotFound
+	case deviceerrors.ErrInvalid:
+		return codeInvalid
+	case deviceerrors.ErrConflict:
+		return codeConflict
+	default:
+		return codeInternal
+	}
+}
+
+func invalidInput(msg string) error {
+	return &resolverError{code: codeInvalid, message: msg}
+}
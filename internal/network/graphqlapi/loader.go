@@ -0,0 +1,86 @@
+package graphqlapi
+
+import (
+	"context"
+	"sync"
+)
+
+// batchLoader looks values up by key for the duration of a request. Keys are
+// registered as the objects referring to them are resolved, the first load then
+// fetches every key registered so far in a single call and the following loads
+// are answered from its results. A page of devices thus reads each relation
+// once rather than once per device.
+type batchLoader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+	// follow, when set, returns the keys a fetched value refers to, such as the
+	// parent of a location, queued for the next fetch.
+	follow func(value V) []K
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	results map[K]batchResult[V]
+}
+
+type batchResult[V any] struct {
+	value V
+	found bool
+	err   error
+}
+
+func newBatchLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *batchLoader[K, V] {
+	return &batchLoader[K, V]{
+		fetch:   fetch,
+		queued:  map[K]bool{},
+		results: map[K]batchResult[V]{},
+	}
+}
+
+// register queues keys to be fetched along with the next load.
+func (l *batchLoader[K, V]) register(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		l.queue(key)
+	}
+}
+
+// load returns the value of key, found is false when the fetch returned none.
+// The error of a failed fetch is returned for every key it was made for.
+func (l *batchLoader[K, V]) load(ctx context.Context, key K) (value V, found bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.results[key]; !ok {
+		l.queue(key)
+
+		keys := l.pending
+		l.pending = nil
+		clear(l.queued)
+
+		values, err := l.fetch(ctx, keys)
+		for _, k := range keys {
+			v, ok := values[k]
+			l.results[k] = batchResult[V]{value: v, found: ok, err: err}
+		}
+		if l.follow != nil {
+			for _, v := range values {
+				for _, k := range l.follow(v) {
+					l.queue(k)
+				}
+			}
+		}
+	}
+
+	result := l.results[key]
+	return result.value, result.found, result.err
+}
+
+func (l *batchLoader[K, V]) queue(key K) {
+	if _, done := l.results[key]; done || l.queued[key] {
+		return
+	}
+	l.queued[key] = true
+	l.pending = append(l.pending, key)
+}
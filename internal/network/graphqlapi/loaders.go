@@ -0,0 +1,89 @@
+package graphqlapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand"
+	brandentity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+	deviceentity "github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location"
+	locationentity "github.com/tiagos4ntos/device-manager/internal/domain/location/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model"
+	modelentity "github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation"
+	reservationentity "github.com/tiagos4ntos/device-manager/internal/domain/reservation/entity"
+)
+
+// loaders batch the lookups of the relations of a request, see batchLoader.
+type loaders struct {
+	brands    *batchLoader[uuid.UUID, brandentity.Brand]
+	models    *batchLoader[uuid.UUID, modelentity.Model]
+	locations *batchLoader[uuid.UUID, locationentity.Location]
+	// reservations holds the reservations of each device that did not end
+	// before the request started.
+	reservations *batchLoader[uuid.UUID, []reservationentity.Reservation]
+	now          time.Time
+}
+
+type loadersKey struct{}
+
+func newLoaders(brands brand.BrandService, models model.ModelService, locations location.LocationService, reservations reservation.ReservationService) *loaders {
+	l := &loaders{now: time.Now()}
+
+	l.brands = newBatchLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]brandentity.Brand, error) {
+		found, err := brands.ListByIDs(ctx, ids)
+		return lo.KeyBy(found, func(b brandentity.Brand) uuid.UUID { return b.ID }), err
+	})
+
+	l.models = newBatchLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]modelentity.Model, error) {
+		found, err := models.List(ctx, modelentity.ModelFilter{IDs: ids})
+		for _, m := range found {
+			l.brands.register(m.BrandID)
+		}
+		return lo.KeyBy(found, func(m modelentity.Model) uuid.UUID { return m.ID }), err
+	})
+
+	l.locations = newBatchLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]locationentity.Location, error) {
+		found, err := locations.List(ctx, locationentity.LocationFilter{IDs: ids})
+		return lo.KeyBy(found, func(loc locationentity.Location) uuid.UUID { return loc.ID }), err
+	})
+	l.locations.follow = func(loc locationentity.Location) []uuid.UUID {
+		if loc.ParentID == nil {
+			return nil
+		}
+		return []uuid.UUID{*loc.ParentID}
+	}
+
+	l.reservations = newBatchLoader(func(ctx context.Context, deviceIDs []uuid.UUID) (map[uuid.UUID][]reservationentity.Reservation, error) {
+		found, err := reservations.List(ctx, reservationentity.ReservationFilter{DeviceIDs: deviceIDs, From: &l.now})
+		return lo.GroupBy(found, func(r reservationentity.Reservation) uuid.UUID { return r.DeviceID }), err
+	})
+
+	return l
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// registerDevices queues the relations of devices, so the first of them
+// requested is read for all the devices at once.
+func (l *loaders) registerDevices(devices []deviceentity.Device) {
+	for _, d := range devices {
+		l.brands.register(d.BrandID)
+		if d.ModelID != nil {
+			l.models.register(*d.ModelID)
+		}
+		if d.LocationID != nil {
+			l.locations.register(*d.LocationID)
+		}
+		l.reservations.register(d.ID)
+	}
+}
@@ -0,0 +1,341 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/samber/lo"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	deviceerrors "github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+)
+
+// maxPageSize bounds the first argument, the schema defaults it to 50.
+const maxPageSize = 500
+
+var graphqlStates = map[entity.DeviceState]string{
+	entity.Available:   "AVAILABLE",
+	entity.InUse:       "IN_USE",
+	entity.Inactive:    "INACTIVE",
+	entity.Maintenance: "MAINTENANCE",
+	entity.Retired:     "RETIRED",
+}
+
+var graphqlSortFields = map[string]entity.SortField{
+	"NAME":       entity.SortByName,
+	"BRAND":      entity.SortByBrand,
+	"STATE":      entity.SortByState,
+	"CREATED_AT": entity.SortByCreatedAt,
+	"UPDATED_AT": entity.SortByUpdatedAt,
+}
+
+// rootResolver resolves the fields of Query and Mutation.
+type rootResolver struct {
+	devices device.DeviceService
+}
+
+type deviceFilterInput struct {
+	Brand          *string
+	Model          *string
+	LocationID     *graphql.ID
+	States         *[]string
+	IncludeRetired bool
+	NameContains   *string
+	CreatedAfter   *graphql.Time
+	CreatedBefore  *graphql.Time
+	UpdatedSince   *graphql.Time
+	Tags           *[]string
+	Attributes     *attributes
+}
+
+type sortKeyInput struct {
+	Field      string
+	Descending bool
+}
+
+type deviceInput struct {
+	Name             string
+	Brand            string
+	ModelID          *graphql.ID
+	State            string
+	SerialNumber     *string
+	IMEI             *string
+	ModelIdentifier  *string
+	OSVersion        *string
+	PurchaseDate     *string
+	PurchasePrice    *float64
+	PurchaseCurrency *string
+	Supplier         *string
+	InvoiceReference *string
+	WarrantyEndsOn   *string
+	Tags             *[]string
+	Attributes       *attributes
+}
+
+func (r *rootResolver) Devices(ctx context.Context, args struct {
+	Filter *deviceFilterInput
+	Sort   *[]sortKeyInput
+	First  int32
+	After  *string
+}) (*deviceConnectionResolver, error) {
+	opts, err := parseListOptions(args.Filter, args.Sort, args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	// one device more than the page tells whether there is a next page
+	pageSize := opts.Limit
+	opts.Limit++
+
+	devices, err := r.devices.List(ctx, opts)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+
+	connection := &deviceConnectionResolver{}
+	if len(devices) > pageSize {
+		devices = devices[:pageSize]
+		connection.pageInfo.hasNextPage = true
+	}
+	if len(devices) > 0 {
+		connection.pageInfo.endCursor = lo.ToPtr(encodeCursor(opts.Offset + len(devices)))
+	}
+
+	loadersFrom(ctx).registerDevices(devices)
+	connection.nodes = lo.Map(devices, func(d entity.Device, _ int) *deviceResolver { return &deviceResolver{device: d} })
+
+	return connection, nil
+}
+
+func (r *rootResolver) Device(ctx context.Context, args struct{ ID graphql.ID }) (*deviceResolver, error) {
+	deviceID, err := parseID("device", args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := r.devices.GetByID(ctx, deviceID)
+	if err != nil {
+		var e *deviceerrors.DeviceError
+		if errors.As(err, &e) && e.Type == deviceerrors.ErrNotFound {
+			return nil, nil
+		}
+		return nil, toResolverError(err)
+	}
+
+	return &deviceResolver{device: d}, nil
+}
+
+func (r *rootResolver) CreateDevice(ctx context.Context, args struct{ Input deviceInput }) (*deviceResolver, error) {
+	d, err := parseDeviceInput(args.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.devices.Create(ctx, d)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+
+	return &deviceResolver{device: created}, nil
+}
+
+func (r *rootResolver) UpdateDevice(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input deviceInput
+}) (*deviceResolver, error) {
+	deviceID, err := parseID("device", args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := parseDeviceInput(args.Input)
+	if err != nil {
+		return nil, err
+	}
+	d.ID = deviceID
+
+	updated, err := r.devices.Update(ctx, d)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+
+	return &deviceResolver{device: updated}, nil
+}
+
+func (r *rootResolver) DeleteDevice(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	deviceID, err := parseID("device", args.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.devices.Delete(ctx, deviceID); err != nil {
+		return "", toResolverError(err)
+	}
+
+	return args.ID, nil
+}
+
+func parseListOptions(filter *deviceFilterInput, sort *[]sortKeyInput, first int32, after *string) (entity.ListOptions, error) {
+	var opts entity.ListOptions
+
+	if filter != nil {
+		var err error
+		if opts.Filter, err = parseDeviceFilter(*filter); err != nil {
+			return opts, err
+		}
+	}
+
+	if sort != nil {
+		for _, key := range *sort {
+			opts.Sort = append(opts.Sort, entity.SortKey{Field: graphqlSortFields[key.Field], Descending: key.Descending})
+		}
+		if err := entity.ValidateSortKeys(opts.Sort); err != nil {
+			return opts, invalidInput(err.Error())
+		}
+	}
+
+	if first < 1 {
+		return opts, invalidInput("first must be positive")
+	}
+	opts.Limit = min(int(first), maxPageSize)
+
+	if after != nil {
+		var err error
+		if opts.Offset, err = decodeCursor(*after); err != nil {
+			return opts, err
+		}
+	}
+
+	return opts, nil
+}
+
+func parseDeviceFilter(input deviceFilterInput) (entity.DeviceFilter, error) {
+	filter := entity.DeviceFilter{
+		Brand:          input.Brand,
+		Model:          input.Model,
+		IncludeRetired: input.IncludeRetired,
+		NameContains:   input.NameContains,
+		CreatedAfter:   optionalTime(input.CreatedAfter),
+		CreatedBefore:  optionalTime(input.CreatedBefore),
+		UpdatedSince:   optionalTime(input.UpdatedSince),
+		Tags:           lo.FromPtr(input.Tags),
+	}
+
+	if input.LocationID != nil {
+		locationID, err := parseID("location", *input.LocationID)
+		if err != nil {
+			return filter, err
+		}
+		filter.LocationID = &locationID
+	}
+
+	for _, value := range lo.FromPtr(input.States) {
+		filter.States = append(filter.States, fromGraphQLState(value))
+	}
+
+	if input.Attributes != nil && len(*input.Attributes) > 0 {
+		filter.Attributes = entity.Attributes(*input.Attributes)
+	}
+
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return filter, invalidInput("invalid date range, createdAfter must be before createdBefore")
+	}
+
+	return filter, nil
+}
+
+func parseDeviceInput(input deviceInput) (entity.Device, error) {
+	state := fromGraphQLState(input.State)
+	if !state.Writable() {
+		return entity.Device{}, invalidInput("invalid device state, must be one of: AVAILABLE, IN_USE, INACTIVE")
+	}
+
+	d := entity.Device{
+		Name:             input.Name,
+		Brand:            input.Brand,
+		State:            state,
+		SerialNumber:     input.SerialNumber,
+		IMEI:             input.IMEI,
+		ModelIdentifier:  input.ModelIdentifier,
+		OSVersion:        input.OSVersion,
+		PurchasePrice:    input.PurchasePrice,
+		PurchaseCurrency: input.PurchaseCurrency,
+		Supplier:         input.Supplier,
+		InvoiceReference: input.InvoiceReference,
+		Tags:             lo.FromPtr(input.Tags),
+	}
+
+	if input.ModelID != nil {
+		modelID, err := parseID("model", *input.ModelID)
+		if err != nil {
+			return entity.Device{}, err
+		}
+		d.ModelID = &modelID
+	}
+
+	var err error
+	if d.PurchaseDate, err = parseOptionalDate("purchaseDate", input.PurchaseDate); err != nil {
+		return entity.Device{}, err
+	}
+	if d.WarrantyEndsOn, err = parseOptionalDate("warrantyEndsOn", input.WarrantyEndsOn); err != nil {
+		return entity.Device{}, err
+	}
+
+	if input.Attributes != nil {
+		d.Attributes = entity.Attributes(*input.Attributes)
+	}
+
+	return d, nil
+}
+
+func parseID(kind string, id graphql.ID) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, invalidInput(fmt.Sprintf("invalid %s id format, must be an uuid", kind))
+	}
+	return parsed, nil
+}
+
+func parseOptionalDate(field string, value *string) (*time.Time, error) {
+	date, err := entity.ParseDate(field, value)
+	if err != nil {
+		return nil, invalidInput(err.Error())
+	}
+	return date, nil
+}
+
+func optionalTime(t *graphql.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	return &t.Time
+}
+
+// fromGraphQLState returns the state of an enum value, the schema only lets
+// known values through.
+func fromGraphQLState(value string) entity.DeviceState {
+	return lo.Invert(graphqlStates)[value]
+}
+
+// encodeCursor hides the offset of the device following a page behind an opaque cursor.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, invalidInput("invalid after cursor")
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, invalidInput("invalid after cursor")
+	}
+	return offset, nil
+}
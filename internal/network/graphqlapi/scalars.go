@@ -0,0 +1,31 @@
+package graphqlapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+)
+
+// attributes implements the Attributes scalar, a JSON object of attribute values.
+type attributes entity.Attributes
+
+func (attributes) ImplementsGraphQLType(name string) bool {
+	return name == "Attributes"
+}
+
+func (a *attributes) UnmarshalGraphQL(input any) error {
+	values, ok := input.(map[string]any)
+	if !ok {
+		return fmt.Errorf("wrong type for Attributes: %T, must be an object", input)
+	}
+	*a = values
+	return nil
+}
+
+func (a attributes) MarshalJSON() ([]byte, error) {
+	if a == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]any(a))
+}
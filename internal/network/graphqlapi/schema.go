@@ -0,0 +1,48 @@
+// Package graphqlapi serves the devices and their related data over GraphQL,
+// next to the REST API.
+package graphqlapi
+
+import (
+	"context"
+	_ "embed"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation"
+)
+
+//go:embed schema.graphql
+var schemaDefinition string
+
+// maxDepth bounds how deep queries may nest, location parents being the only
+// recursive relation.
+const maxDepth = 10
+
+// Schema executes the GraphQL operations against the domain services.
+type Schema struct {
+	schema       *graphql.Schema
+	brands       brand.BrandService
+	models       model.ModelService
+	locations    location.LocationService
+	reservations reservation.ReservationService
+}
+
+func NewSchema(devices device.DeviceService, brands brand.BrandService, models model.ModelService, locations location.LocationService, reservations reservation.ReservationService) *Schema {
+	return &Schema{
+		schema:       graphql.MustParseSchema(schemaDefinition, &rootResolver{devices: devices}, graphql.UseStringDescriptions(), graphql.MaxDepth(maxDepth)),
+		brands:       brands,
+		models:       models,
+		locations:    locations,
+		reservations: reservations,
+	}
+}
+
+// Exec runs an operation with loaders of its own, so relations are batched
+// within the operation and never shared between operations.
+func (s *Schema) Exec(ctx context.Context, query string, operationName string, variables map[string]any) *graphql.Response {
+	ctx = withLoaders(ctx, newLoaders(s.brands, s.models, s.locations, s.reservations))
+	return s.schema.Exec(ctx, query, operationName, variables)
+}
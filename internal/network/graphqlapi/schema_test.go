@@ -0,0 +1,422 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand"
+	brandentity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location"
+	locationentity "github.com/tiagos4ntos/device-manager/internal/domain/location/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model"
+	modelentity "github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation"
+	reservationentity "github.com/tiagos4ntos/device-manager/internal/domain/reservation/entity"
+)
+
+var (
+	pixelID   = uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+	iphoneID  = uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19")
+	googleID  = uuid.MustParse("1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704")
+	appleID   = uuid.MustParse("2c3d4e5f-6071-4b82-9ca3-b4c5d6e7f815")
+	pixel7ID  = uuid.MustParse("3d4e5f60-7182-4c93-8db4-c5d6e7f8a926")
+	shelfID   = uuid.MustParse("4e5f6071-8293-4da4-9ec5-d6e7f8a9ba37")
+	roomID    = uuid.MustParse("5f607182-93a4-4eb5-8fd6-e7f8a9bacb48")
+	createdAt = lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	pixel = entity.Device{
+		ID:         pixelID,
+		Name:       "Pixel 7",
+		Brand:      "Google",
+		BrandID:    googleID,
+		ModelID:    &pixel7ID,
+		LocationID: &shelfID,
+		State:      entity.InUse,
+		Tags:       []string{"qa"},
+		Attributes: entity.Attributes{"carrier": "vodafone"},
+		CreatedAt:  createdAt,
+	}
+	iphone = entity.Device{
+		ID:         iphoneID,
+		Name:       "iPhone 15",
+		Brand:      "Apple",
+		BrandID:    appleID,
+		LocationID: &roomID,
+		State:      entity.Available,
+		CreatedAt:  createdAt,
+	}
+)
+
+type testMocks struct {
+	devices      *mocks.MockDeviceService
+	brands       *mocks.MockBrandRepository
+	models       *mocks.MockModelRepository
+	locations    *mocks.MockLocationRepository
+	reservations *mocks.MockReservationRepository
+}
+
+// newTestSchema runs the schema against the domain services, backed by mocked repositories.
+func newTestSchema(t *testing.T) (*Schema, testMocks) {
+	mockCtrl := gomock.NewController(t)
+	m := testMocks{
+		devices:      mocks.NewMockDeviceService(mockCtrl),
+		brands:       mocks.NewMockBrandRepository(mockCtrl),
+		models:       mocks.NewMockModelRepository(mockCtrl),
+		locations:    mocks.NewMockLocationRepository(mockCtrl),
+		reservations: mocks.NewMockReservationRepository(mockCtrl),
+	}
+
+	schema := NewSchema(
+		m.devices,
+		brand.NewBrandService(m.brands),
+		model.NewModelService(m.models, mocks.NewMockBrandLookup(mockCtrl)),
+		location.NewLocationService(m.locations),
+		reservation.NewReservationService(m.reservations, mocks.NewMockDeviceLookup(mockCtrl)),
+	)
+	return schema, m
+}
+
+func exec(t *testing.T, schema *Schema, query string, variables map[string]any) (map[string]any, []map[string]any) {
+	response := schema.Exec(context.TODO(), query, "", variables)
+
+	raw, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var result struct {
+		Data   map[string]any   `json:"data"`
+		Errors []map[string]any `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &result))
+	return result.Data, result.Errors
+}
+
+func Test_Devices_Batches_Relations(t *testing.T) {
+	schema, m := newTestSchema(t)
+
+	checkedOut := reservationentity.Reservation{
+		ID:         uuid.MustParse("60718293-a4b5-4fc6-90e7-f8a9bacbdc59"),
+		DeviceID:   pixelID,
+		ReservedBy: "Ana Lima",
+		StartsAt:   time.Now().Add(-time.Hour),
+		EndsAt:     time.Now().Add(time.Hour),
+		Status:     reservationentity.CheckedOut,
+	}
+	cancelled := checkedOut
+	cancelled.ID = uuid.MustParse("718293a4-b5c6-40d7-81f8-a9bacbdced6a")
+	cancelled.Status = reservationentity.Cancelled
+
+	m.devices.
+		EXPECT().
+		List(gomock.Any(), entity.ListOptions{Limit: 51}).
+		Return([]entity.Device{pixel, iphone}, nil)
+
+	m.models.
+		EXPECT().
+		ListModels(gomock.Any(), modelentity.ModelFilter{IDs: []uuid.UUID{pixel7ID}}).
+		Return([]modelentity.Model{{ID: pixel7ID, BrandID: googleID, Name: "Pixel 7", FormFactor: modelentity.Phone, OSFamily: modelentity.Android}}, nil)
+	m.brands.
+		EXPECT().
+		ListBrandsByIDs(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ids []uuid.UUID) ([]brandentity.Brand, error) {
+			assert.ElementsMatch(t, []uuid.UUID{googleID, appleID}, ids)
+			return []brandentity.Brand{{ID: googleID, Name: "Google"}, {ID: appleID, Name: "Apple"}}, nil
+		})
+
+	// the parent of the shelf is read along with the room of the iPhone
+	m.locations.
+		EXPECT().
+		ListLocations(gomock.Any(), locationentity.LocationFilter{IDs: []uuid.UUID{shelfID, roomID}}).
+		Return([]locationentity.Location{
+			{ID: shelfID, ParentID: &roomID, Kind: locationentity.Shelf, Name: "Shelf A"},
+			{ID: roomID, Kind: locationentity.Room, Name: "Lab"},
+		}, nil)
+
+	m.reservations.
+		EXPECT().
+		ListReservations(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter reservationentity.ReservationFilter) ([]reservationentity.Reservation, error) {
+			assert.Equal(t, []uuid.UUID{pixelID, iphoneID}, filter.DeviceIDs)
+			assert.NotNil(t, filter.From)
+			return []reservationentity.Reservation{checkedOut, cancelled}, nil
+		})
+
+	data, errs := exec(t, schema, `{
+		devices {
+			nodes {
+				name
+				state
+				brand { name }
+				model { name brand { name } }
+				location { name parent { name } }
+				assignment { reservedBy status }
+				reservations { id }
+			}
+			pageInfo { hasNextPage endCursor }
+		}
+	}`, nil)
+	require.Empty(t, errs)
+
+	expected := map[string]any{
+		"devices": map[string]any{
+			"nodes": []any{
+				map[string]any{
+					"name":         "Pixel 7",
+					"state":        "IN_USE",
+					"brand":        map[string]any{"name": "Google"},
+					"model":        map[string]any{"name": "Pixel 7", "brand": map[string]any{"name": "Google"}},
+					"location":     map[string]any{"name": "Shelf A", "parent": map[string]any{"name": "Lab"}},
+					"assignment":   map[string]any{"reservedBy": "Ana Lima", "status": "checked-out"},
+					"reservations": []any{map[string]any{"id": checkedOut.ID.String()}},
+				},
+				map[string]any{
+					"name":         "iPhone 15",
+					"state":        "AVAILABLE",
+					"brand":        map[string]any{"name": "Apple"},
+					"model":        nil,
+					"location":     map[string]any{"name": "Lab", "parent": nil},
+					"assignment":   nil,
+					"reservations": []any{},
+				},
+			},
+			"pageInfo": map[string]any{"hasNextPage": false, "endCursor": encodeCursor(2)},
+		},
+	}
+	assert.Equal(t, expected, data)
+}
+
+func Test_Devices_Arguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		wantOpts  *entity.ListOptions
+		wantErr   string
+	}{
+		{
+			name: "Devices Filtered Sorted And Paged Case",
+			query: `query($after: String) {
+				devices(
+					filter: {brand: "Google", states: [AVAILABLE, IN_USE], tags: ["qa"], attributes: {carrier: "vodafone"}},
+					sort: [{field: STATE}, {field: NAME, descending: true}],
+					first: 10, after: $after
+				) { nodes { name } }
+			}`,
+			variables: map[string]any{"after": encodeCursor(20)},
+			wantOpts: &entity.ListOptions{
+				Filter: entity.DeviceFilter{
+					Brand:      lo.ToPtr("Google"),
+					States:     []entity.DeviceState{entity.Available, entity.InUse},
+					Tags:       []string{"qa"},
+					Attributes: entity.Attributes{"carrier": "vodafone"},
+				},
+				Sort:   []entity.SortKey{{Field: entity.SortByState}, {Field: entity.SortByName, Descending: true}},
+				Limit:  11,
+				Offset: 20,
+			},
+		},
+		{
+			name:     "Devices Page Size Above Maximum Case",
+			query:    `{ devices(first: 10000) { nodes { name } } }`,
+			wantOpts: &entity.ListOptions{Limit: maxPageSize + 1},
+		},
+		{
+			name:    "Devices Page Size Not Positive Case",
+			query:   `{ devices(first: 0) { nodes { name } } }`,
+			wantErr: "first must be positive",
+		},
+		{
+			name:    "Devices Invalid Cursor Case",
+			query:   `{ devices(after: "not-a-cursor") { nodes { name } } }`,
+			wantErr: "invalid after cursor",
+		},
+		{
+			name:    "Devices Sort Field Repeated Case",
+			query:   `{ devices(sort: [{field: NAME}, {field: NAME, descending: true}]) { nodes { name } } }`,
+			wantErr: `sort field "name" is informed more than once`,
+		},
+		{
+			name:    "Devices Invalid Location Case",
+			query:   `{ devices(filter: {locationId: "shelf"}) { nodes { name } } }`,
+			wantErr: "invalid location id format, must be an uuid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, m := newTestSchema(t)
+
+			if tt.wantOpts != nil {
+				m.devices.
+					EXPECT().
+					List(gomock.Any(), *tt.wantOpts).
+					Return([]entity.Device{iphone}, nil)
+			}
+
+			_, errs := exec(t, schema, tt.query, tt.variables)
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantErr, errs[0]["message"])
+			assert.Equal(t, map[string]any{"code": codeInvalid}, errs[0]["extensions"])
+		})
+	}
+}
+
+func Test_Devices_Next_Page(t *testing.T) {
+	schema, m := newTestSchema(t)
+
+	m.devices.
+		EXPECT().
+		List(gomock.Any(), entity.ListOptions{Limit: 2}).
+		Return([]entity.Device{pixel, iphone}, nil)
+
+	data, errs := exec(t, schema, `{ devices(first: 1) { nodes { name } pageInfo { hasNextPage endCursor } } }`, nil)
+	require.Empty(t, errs)
+	assert.Equal(t, map[string]any{
+		"nodes":    []any{map[string]any{"name": "Pixel 7"}},
+		"pageInfo": map[string]any{"hasNextPage": true, "endCursor": encodeCursor(1)},
+	}, data["devices"])
+}
+
+func Test_Device_Not_Found(t *testing.T) {
+	schema, m := newTestSchema(t)
+
+	m.devices.
+		EXPECT().
+		GetByID(gomock.Any(), pixelID).
+		Return(entity.Device{}, errors.NewDeviceError(errors.ErrNotFound, "device not found", nil))
+
+	data, errs := exec(t, schema, `query($id: ID!) { device(id: $id) { name } }`, map[string]any{"id": pixelID.String()})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"device": nil}, data)
+}
+
+func Test_Device_Wrapped_Not_Found(t *testing.T) {
+	schema, m := newTestSchema(t)
+
+	m.devices.
+		EXPECT().
+		GetByID(gomock.Any(), pixelID).
+		Return(entity.Device{}, fmt.Errorf("loading device: %w", errors.NewDeviceError(errors.ErrNotFound, "device not found", nil)))
+
+	data, errs := exec(t, schema, `query($id: ID!) { device(id: $id) { name } }`, map[string]any{"id": pixelID.String()})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"device": nil}, data)
+}
+
+func Test_Device_Mutations(t *testing.T) {
+	const create = `mutation($input: DeviceInput!) { createDevice(input: $input) { id state } }`
+	const update = `mutation($id: ID!, $input: DeviceInput!) { updateDevice(id: $id, input: $input) { id } }`
+	const remove = `mutation($id: ID!) { deleteDevice(id: $id) }`
+
+	input := map[string]any{"name": "Pixel 7", "brand": "Google", "state": "AVAILABLE", "purchaseDate": "2025-03-10"}
+	withInput := func(change map[string]any) map[string]any {
+		return lo.Assign(input, change)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		setup     func(m testMocks)
+		wantData  map[string]any
+		wantErr   string
+		wantCode  string
+	}{
+		{
+			name:      "Create Device Success Case",
+			query:     create,
+			variables: map[string]any{"input": input},
+			setup: func(m testMocks) {
+				m.devices.
+					EXPECT().
+					Create(gomock.Any(), entity.Device{
+						Name:         "Pixel 7",
+						Brand:        "Google",
+						State:        entity.Available,
+						PurchaseDate: lo.ToPtr(lo.Must(time.Parse(time.DateOnly, "2025-03-10"))),
+					}).
+					Return(pixel, nil)
+			},
+			wantData: map[string]any{"createDevice": map[string]any{"id": pixelID.String(), "state": "IN_USE"}},
+		},
+		{
+			name:      "Create Device In Maintenance Case",
+			query:     create,
+			variables: map[string]any{"input": withInput(map[string]any{"state": "MAINTENANCE"})},
+			wantErr:   "invalid device state, must be one of: AVAILABLE, IN_USE, INACTIVE",
+			wantCode:  codeInvalid,
+		},
+		{
+			name:      "Create Device Invalid Purchase Date Case",
+			query:     create,
+			variables: map[string]any{"input": withInput(map[string]any{"purchaseDate": "10/03/2025"})},
+			wantErr:   "invalid purchaseDate format, must be a YYYY-MM-DD date",
+			wantCode:  codeInvalid,
+		},
+		{
+			name:      "Update Device Conflict Case",
+			query:     update,
+			variables: map[string]any{"id": pixelID.String(), "input": input},
+			setup: func(m testMocks) {
+				m.devices.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(entity.Device{}, errors.NewDeviceError(errors.ErrConflict, "serial number already in use", nil))
+			},
+			wantErr:  "serial number already in use",
+			wantCode: codeConflict,
+		},
+		{
+			name:      "Delete Device Success Case",
+			query:     remove,
+			variables: map[string]any{"id": pixelID.String()},
+			setup: func(m testMocks) {
+				m.devices.EXPECT().Delete(gomock.Any(), pixelID).Return(nil)
+			},
+			wantData: map[string]any{"deleteDevice": pixelID.String()},
+		},
+		{
+			name:      "Delete Device Internal Error Case",
+			query:     remove,
+			variables: map[string]any{"id": pixelID.String()},
+			setup: func(m testMocks) {
+				m.devices.EXPECT().Delete(gomock.Any(), pixelID).Return(assert.AnError)
+			},
+			wantErr:  "Internal server error",
+			wantCode: codeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, m := newTestSchema(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			data, errs := exec(t, schema, tt.query, tt.variables)
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				assert.Equal(t, tt.wantData, data)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantErr, errs[0]["message"])
+			assert.Equal(t, map[string]any{"code": tt.wantCode}, errs[0]["extensions"])
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package graphqlapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/samber/lo"
+	brandentity "github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	deviceerrors "github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	locationentity "github.com/tiagos4ntos/device-manager/internal/domain/location/entity"
+	modelentity "github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+	reservationentity "github.com/tiagos4ntos/device-manager/internal/domain/reservation/entity"
+)
+
+type deviceConnectionResolver struct {
+	nodes    []*deviceResolver
+	pageInfo pageInfoResolver
+}
+
+func (r *deviceConnectionResolver) Nodes() []*deviceResolver {
+	return r.nodes
+}
+
+func (r *deviceConnectionResolver) PageInfo() *pageInfoResolver {
+	return &r.pageInfo
+}
+
+type pageInfoResolver struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
+
+type deviceResolver struct {
+	device entity.Device
+}
+
+func (r *deviceResolver) ID() graphql.ID {
+	return graphql.ID(r.device.ID.String())
+}
+
+func (r *deviceResolver) Name() string {
+	return r.device.Name
+}
+
+func (r *deviceResolver) Brand(ctx context.Context) (*brandResolver, error) {
+	b, found, err := loadersFrom(ctx).brands.load(ctx, r.device.BrandID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	if !found {
+		return nil, toResolverError(deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "brand of device not found", nil))
+	}
+	return &brandResolver{brand: b}, nil
+}
+
+func (r *deviceResolver) Model(ctx context.Context) (*modelResolver, error) {
+	if r.device.ModelID == nil {
+		return nil, nil
+	}
+	m, found, err := loadersFrom(ctx).models.load(ctx, *r.device.ModelID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return &modelResolver{model: m}, nil
+}
+
+func (r *deviceResolver) Location(ctx context.Context) (*locationResolver, error) {
+	if r.device.LocationID == nil {
+		return nil, nil
+	}
+	return loadLocation(ctx, *r.device.LocationID)
+}
+
+func (r *deviceResolver) State() string {
+	return graphqlStates[r.device.State]
+}
+
+func (r *deviceResolver) SerialNumber() *string {
+	return r.device.SerialNumber
+}
+
+func (r *deviceResolver) IMEI() *string {
+	return r.device.IMEI
+}
+
+func (r *deviceResolver) ModelIdentifier() *string {
+	return r.device.ModelIdentifier
+}
+
+func (r *deviceResolver) OSVersion() *string {
+	return r.device.OSVersion
+}
+
+func (r *deviceResolver) PurchaseDate() *string {
+	return optionalDate(r.device.PurchaseDate)
+}
+
+func (r *deviceResolver) PurchasePrice() *float64 {
+	return r.device.PurchasePrice
+}
+
+func (r *deviceResolver) PurchaseCurrency() *string {
+	return r.device.PurchaseCurrency
+}
+
+func (r *deviceResolver) Supplier() *string {
+	return r.device.Supplier
+}
+
+func (r *deviceResolver) InvoiceReference() *string {
+	return r.device.InvoiceReference
+}
+
+func (r *deviceResolver) WarrantyEndsOn() *string {
+	return optionalDate(r.device.WarrantyEndsOn)
+}
+
+func (r *deviceResolver) Tags() []string {
+	if r.device.Tags == nil {
+		return []string{}
+	}
+	return r.device.Tags
+}
+
+func (r *deviceResolver) Attributes() attributes {
+	return attributes(r.device.Attributes)
+}
+
+func (r *deviceResolver) Assignment(ctx context.Context) (*reservationResolver, error) {
+	reservations, err := r.activeReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := loadersFrom(ctx).now
+	assignment, found := lo.Find(reservations, func(res reservationentity.Reservation) bool {
+		return res.Status == reservationentity.CheckedOut && !res.StartsAt.After(now)
+	})
+	if !found {
+		return nil, nil
+	}
+	return &reservationResolver{reservation: assignment}, nil
+}
+
+func (r *deviceResolver) Reservations(ctx context.Context) ([]*reservationResolver, error) {
+	reservations, err := r.activeReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(reservations, func(res reservationentity.Reservation, _ int) *reservationResolver {
+		return &reservationResolver{reservation: res}
+	}), nil
+}
+
+// activeReservations returns the reservations of the device that did not end
+// nor were cancelled, by start.
+func (r *deviceResolver) activeReservations(ctx context.Context) ([]reservationentity.Reservation, error) {
+	reservations, _, err := loadersFrom(ctx).reservations.load(ctx, r.device.ID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return lo.Reject(reservations, func(res reservationentity.Reservation, _ int) bool {
+		return res.Status == reservationentity.Cancelled
+	}), nil
+}
+
+func (r *deviceResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.device.CreatedAt}
+}
+
+func (r *deviceResolver) UpdatedAt() *graphql.Time {
+	return optionalGraphQLTime(r.device.UpdatedAt)
+}
+
+type brandResolver struct {
+	brand brandentity.Brand
+}
+
+func (r *brandResolver) ID() graphql.ID {
+	return graphql.ID(r.brand.ID.String())
+}
+
+func (r *brandResolver) Name() string {
+	return r.brand.Name
+}
+
+func (r *brandResolver) Aliases() []string {
+	if r.brand.Aliases == nil {
+		return []string{}
+	}
+	return r.brand.Aliases
+}
+
+type modelResolver struct {
+	model modelentity.Model
+}
+
+func (r *modelResolver) ID() graphql.ID {
+	return graphql.ID(r.model.ID.String())
+}
+
+func (r *modelResolver) Brand(ctx context.Context) (*brandResolver, error) {
+	b, found, err := loadersFrom(ctx).brands.load(ctx, r.model.BrandID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	if !found {
+		return nil, toResolverError(deviceerrors.NewDeviceError(deviceerrors.ErrInternal, "brand of model not found", nil))
+	}
+	return &brandResolver{brand: b}, nil
+}
+
+func (r *modelResolver) Name() string {
+	return r.model.Name
+}
+
+func (r *modelResolver) ReleaseYear() *int32 {
+	if r.model.ReleaseYear == nil {
+		return nil
+	}
+	return lo.ToPtr(int32(*r.model.ReleaseYear))
+}
+
+func (r *modelResolver) FormFactor() string {
+	return r.model.FormFactor.String()
+}
+
+func (r *modelResolver) OSFamily() string {
+	return r.model.OSFamily.String()
+}
+
+func (r *modelResolver) StorageVariants() []int32 {
+	if r.model.StorageVariants == nil {
+		return []int32{}
+	}
+	return r.model.StorageVariants
+}
+
+type locationResolver struct {
+	location locationentity.Location
+}
+
+func loadLocation(ctx context.Context, id uuid.UUID) (*locationResolver, error) {
+	loc, found, err := loadersFrom(ctx).locations.load(ctx, id)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return &locationResolver{location: loc}, nil
+}
+
+func (r *locationResolver) ID() graphql.ID {
+	return graphql.ID(r.location.ID.String())
+}
+
+func (r *locationResolver) Kind() string {
+	return r.location.Kind.String()
+}
+
+func (r *locationResolver) Name() string {
+	return r.location.Name
+}
+
+func (r *locationResolver) Parent(ctx context.Context) (*locationResolver, error) {
+	if r.location.ParentID == nil {
+		return nil, nil
+	}
+	return loadLocation(ctx, *r.location.ParentID)
+}
+
+type reservationResolver struct {
+	reservation reservationentity.Reservation
+}
+
+func (r *reservationResolver) ID() graphql.ID {
+	return graphql.ID(r.reservation.ID.String())
+}
+
+func (r *reservationResolver) ReservedBy() string {
+	return r.reservation.ReservedBy
+}
+
+func (r *reservationResolver) StartsAt() graphql.Time {
+	return graphql.Time{Time: r.reservation.StartsAt}
+}
+
+func (r *reservationResolver) EndsAt() graphql.Time {
+	return graphql.Time{Time: r.reservation.EndsAt}
+}
+
+func (r *reservationResolver) Note() *string {
+	return r.reservation.Note
+}
+
+func (r *reservationResolver) Status() string {
+	return r.reservation.Status.String()
+}
+
+func (r *reservationResolver) CheckedOutAt() *graphql.Time {
+	return optionalGraphQLTime(r.reservation.CheckedOutAt)
+}
+
+func optionalDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	return lo.ToPtr(t.Format(time.DateOnly))
+}
+
+func optionalGraphQLTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
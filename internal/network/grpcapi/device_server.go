@@ -0,0 +1,443 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	deviceerrors "github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/grpcapi/devicev1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+var protoStates = map[entity.DeviceState]devicev1.DeviceState{
+	entity.Available:   devicev1.DeviceState_DEVICE_STATE_AVAILABLE,
+	entity.InUse:       devicev1.DeviceState_DEVICE_STATE_IN_USE,
+	entity.Inactive:    devicev1.DeviceState_DEVICE_STATE_INACTIVE,
+	entity.Maintenance: devicev1.DeviceState_DEVICE_STATE_MAINTENANCE,
+	entity.Retired:     devicev1.DeviceState_DEVICE_STATE_RETIRED,
+}
+
+var protoChangeTypes = map[entity.ChangeType]devicev1.ChangeType{
+	entity.ChangeCreated: devicev1.ChangeType_CHANGE_TYPE_CREATED,
+	entity.ChangeUpdated: devicev1.ChangeType_CHANGE_TYPE_UPDATED,
+	entity.ChangeDeleted: devicev1.ChangeType_CHANGE_TYPE_DELETED,
+}
+
+type deviceServer struct {
+	devicev1.UnimplementedDeviceServiceServer
+	devices device.DeviceService
+	changes device.ChangeWatcher
+}
+
+func NewDeviceServer(devices device.DeviceService, changes device.ChangeWatcher) devicev1.DeviceServiceServer {
+	return &deviceServer{devices: devices, changes: changes}
+}
+
+func (s *deviceServer) ListDevices(ctx context.Context, req *devicev1.ListDevicesRequest) (*devicev1.ListDevicesResponse, error) {
+	ctx, err := tenantContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := parseListDevicesRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// one device more than the page tells whether there is a next page
+	pageSize := opts.Limit
+	opts.Limit++
+
+	devices, err := s.devices.List(ctx, opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := &devicev1.ListDevicesResponse{Devices: make([]*devicev1.Device, 0, len(devices))}
+	if len(devices) > pageSize {
+		devices = devices[:pageSize]
+		result.NextPageToken = encodePageToken(opts.Offset + pageSize)
+	}
+
+	for _, d := range devices {
+		protoDevice, err := toProtoDevice(d)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		result.Devices = append(result.Devices, protoDevice)
+	}
+
+	return result, nil
+}
+
+func (s *deviceServer) GetDevice(ctx context.Context, req *devicev1.GetDeviceRequest) (*devicev1.Device, error) {
+	deviceID, err := parseDeviceID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.devices.GetByID(ctx, deviceID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoDeviceOrStatus(d)
+}
+
+func (s *deviceServer) CreateDevice(ctx context.Context, req *devicev1.CreateDeviceRequest) (*devicev1.Device, error) {
+	ctx, err := tenantContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := parseDeviceFields(req.GetDevice())
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.devices.Create(ctx, d)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoDeviceOrStatus(created)
+}
+
+func (s *deviceServer) UpdateDevice(ctx context.Context, req *devicev1.UpdateDeviceRequest) (*devicev1.Device, error) {
+	ctx, err := tenantContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceID, err := parseDeviceID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := parseDeviceFields(req.GetDevice())
+	if err != nil {
+		return nil, err
+	}
+	d.ID = deviceID
+
+	updated, err := s.devices.Update(ctx, d)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoDeviceOrStatus(updated)
+}
+
+func (s *deviceServer) DeleteDevice(ctx context.Context, req *devicev1.DeleteDeviceRequest) (*emptypb.Empty, error) {
+	deviceID, err := parseDeviceID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.devices.Delete(ctx, deviceID); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// WatchDevices sends the changes as they come, each with the device read right
+// after. Changes to devices that are gone by then are skipped, their deletion
+// follows.
+func (s *deviceServer) WatchDevices(req *devicev1.WatchDevicesRequest, stream grpc.ServerStreamingServer[devicev1.DeviceChange]) error {
+	watched := map[uuid.UUID]bool{}
+	for _, id := range req.GetDeviceIds() {
+		deviceID, err := parseDeviceID(id)
+		if err != nil {
+			return err
+		}
+		watched[deviceID] = true
+	}
+
+	ctx := stream.Context()
+	changes := s.changes.Subscribe(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case change, ok := <-changes:
+			if !ok {
+				return status.Error(codes.Unavailable, "device changes are no longer watched, list the devices again before watching anew")
+			}
+			if len(watched) > 0 && !watched[change.DeviceID] {
+				continue
+			}
+
+			message := &devicev1.DeviceChange{
+				Type:      protoChangeTypes[change.Type],
+				DeviceId:  change.DeviceID.String(),
+				ChangedAt: timestamppb.New(change.ChangedAt),
+			}
+
+			if change.Type != entity.ChangeDeleted {
+				d, err := s.devices.GetByID(ctx, change.DeviceID)
+				if err != nil {
+					var e *deviceerrors.DeviceError
+					if errors.As(err, &e) && e.Type == deviceerrors.ErrNotFound {
+						continue
+					}
+					return toStatus(err)
+				}
+				if message.Device, err = toProtoDevice(d); err != nil {
+					return toStatus(err)
+				}
+			}
+
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func parseListDevicesRequest(req *devicev1.ListDevicesRequest) (entity.ListOptions, error) {
+	var opts entity.ListOptions
+
+	opts.Filter = entity.DeviceFilter{
+		Brand:          req.Brand,
+		Model:          req.Model,
+		IncludeRetired: req.GetIncludeRetired(),
+		NameContains:   req.NameContains,
+		CreatedAfter:   optionalTime(req.GetCreatedAfter()),
+		CreatedBefore:  optionalTime(req.GetCreatedBefore()),
+		UpdatedSince:   optionalTime(req.GetUpdatedSince()),
+		Tags:           req.GetTags(),
+	}
+
+	if req.LocationId != nil {
+		locationID, err := uuid.Parse(req.GetLocationId())
+		if err != nil {
+			return opts, invalidArgument("invalid location id format, must be an uuid")
+		}
+		opts.Filter.LocationID = &locationID
+	}
+
+	for _, protoState := range req.GetStates() {
+		state, ok := fromProtoState(protoState)
+		if !ok {
+			return opts, invalidArgument(fmt.Sprintf("invalid device state %s", protoState))
+		}
+		opts.Filter.States = append(opts.Filter.States, state)
+	}
+
+	if len(req.GetAttributes()) > 0 {
+		opts.Filter.Attributes = entity.Attributes{}
+		for name, value := range req.GetAttributes() {
+			opts.Filter.Attributes[name] = value
+		}
+	}
+
+	if opts.Filter.CreatedAfter != nil && opts.Filter.CreatedBefore != nil && !opts.Filter.CreatedAfter.Before(*opts.Filter.CreatedBefore) {
+		return opts, invalidArgument("invalid date range, created_after must be before created_before")
+	}
+
+	var err error
+	if opts.Sort, err = parseSortKeys(req.GetSort()); err != nil {
+		return opts, err
+	}
+
+	switch pageSize := int(req.GetPageSize()); {
+	case pageSize < 0:
+		return opts, invalidArgument("page_size must not be negative")
+	case pageSize == 0:
+		opts.Limit = defaultPageSize
+	default:
+		opts.Limit = min(pageSize, maxPageSize)
+	}
+
+	if opts.Offset, err = decodePageToken(req.GetPageToken()); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+// parseSortKeys reads the sort keys as the REST API does, a leading "-" sorts descending.
+func parseSortKeys(values []string) ([]entity.SortKey, error) {
+	keys, err := entity.ParseSortKeys(values)
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	return keys, nil
+}
+
+// encodePageToken hides the offset of the next page behind an opaque token.
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, invalidArgument("invalid page_token")
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, invalidArgument("invalid page_token")
+	}
+
+	return offset, nil
+}
+
+func parseDeviceID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, invalidArgument("you must inform the device id")
+	}
+	deviceID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, invalidArgument("invalid device id format, must be an uuid")
+	}
+	return deviceID, nil
+}
+
+func parseDeviceFields(fields *devicev1.DeviceFields) (entity.Device, error) {
+	if fields == nil {
+		return entity.Device{}, invalidArgument("you must inform the device")
+	}
+
+	state, ok := fromProtoState(fields.GetState())
+	if !ok || !state.Writable() {
+		return entity.Device{}, invalidArgument("invalid device state, must be one of: available, in-use, inactive")
+	}
+
+	d := entity.Device{
+		Name:             fields.GetName(),
+		Brand:            fields.GetBrand(),
+		State:            state,
+		SerialNumber:     fields.SerialNumber,
+		IMEI:             fields.Imei,
+		ModelIdentifier:  fields.ModelIdentifier,
+		OSVersion:        fields.OsVersion,
+		PurchasePrice:    fields.PurchasePrice,
+		PurchaseCurrency: fields.PurchaseCurrency,
+		Supplier:         fields.Supplier,
+		InvoiceReference: fields.InvoiceReference,
+		Tags:             fields.GetTags(),
+	}
+
+	if fields.ModelId != nil {
+		modelID, err := uuid.Parse(fields.GetModelId())
+		if err != nil {
+			return entity.Device{}, invalidArgument("invalid model id format, must be an uuid")
+		}
+		d.ModelID = &modelID
+	}
+
+	var err error
+	if d.PurchaseDate, err = parseOptionalDate("purchase_date", fields.PurchaseDate); err != nil {
+		return entity.Device{}, err
+	}
+	if d.WarrantyEndsOn, err = parseOptionalDate("warranty_ends_on", fields.WarrantyEndsOn); err != nil {
+		return entity.Device{}, err
+	}
+
+	if fields.GetAttributes() != nil {
+		d.Attributes = fields.GetAttributes().AsMap()
+	}
+
+	return d, nil
+}
+
+func parseOptionalDate(field string, value *string) (*time.Time, error) {
+	date, err := entity.ParseDate(field, value)
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	return date, nil
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	return lo.ToPtr(ts.AsTime())
+}
+
+func fromProtoState(protoState devicev1.DeviceState) (entity.DeviceState, bool) {
+	for state, candidate := range protoStates {
+		if candidate == protoState {
+			return state, true
+		}
+	}
+	return "", false
+}
+
+func toProtoDeviceOrStatus(d entity.Device) (*devicev1.Device, error) {
+	protoDevice, err := toProtoDevice(d)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return protoDevice, nil
+}
+
+func toProtoDevice(d entity.Device) (*devicev1.Device, error) {
+	attributes, err := structpb.NewStruct(d.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("device %s attributes: %w", d.ID, err)
+	}
+
+	protoDevice := &devicev1.Device{
+		Id:               d.ID.String(),
+		Name:             d.Name,
+		Brand:            d.Brand,
+		BrandId:          d.BrandID.String(),
+		State:            protoStates[d.State],
+		SerialNumber:     d.SerialNumber,
+		Imei:             d.IMEI,
+		ModelIdentifier:  d.ModelIdentifier,
+		OsVersion:        d.OSVersion,
+		PurchasePrice:    d.PurchasePrice,
+		PurchaseCurrency: d.PurchaseCurrency,
+		Supplier:         d.Supplier,
+		InvoiceReference: d.InvoiceReference,
+		Tags:             d.Tags,
+		Attributes:       attributes,
+		CreatedAt:        timestamppb.New(d.CreatedAt),
+	}
+
+	if d.ModelID != nil {
+		protoDevice.ModelId = lo.ToPtr(d.ModelID.String())
+	}
+	if d.LocationID != nil {
+		protoDevice.LocationId = lo.ToPtr(d.LocationID.String())
+	}
+	if d.PurchaseDate != nil {
+		protoDevice.PurchaseDate = lo.ToPtr(d.PurchaseDate.Format(time.DateOnly))
+	}
+	if d.WarrantyEndsOn != nil {
+		protoDevice.WarrantyEndsOn = lo.ToPtr(d.WarrantyEndsOn.Format(time.DateOnly))
+	}
+	if d.UpdatedAt != nil {
+		protoDevice.UpdatedAt = timestamppb.New(*d.UpdatedAt)
+	}
+
+	return protoDevice, nil
+}
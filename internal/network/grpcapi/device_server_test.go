@@ -0,0 +1,357 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	"github.com/tiagos4ntos/device-manager/internal/domain/mocks"
+	"github.com/tiagos4ntos/device-manager/internal/domain/tenant"
+	"github.com/tiagos4ntos/device-manager/internal/network/grpcapi/devicev1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	pixelID   = uuid.MustParse("b44ecc02-872e-4c18-8d2a-ac09dfc4b49a")
+	iphoneID  = uuid.MustParse("c60dceb7-60c8-4d74-8d7c-cd34a0b4ce19")
+	galaxyID  = uuid.MustParse("0d9c8b7a-6f5e-4d3c-8b2a-190817263544")
+	googleID  = uuid.MustParse("1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704")
+	createdAt = lo.Must(time.Parse(time.DateTime, "2025-08-31 15:01:02"))
+
+	pixel = entity.Device{
+		ID:           pixelID,
+		Name:         "Pixel 7",
+		Brand:        "Google",
+		BrandID:      googleID,
+		State:        entity.Available,
+		PurchaseDate: lo.ToPtr(lo.Must(time.Parse(time.DateOnly, "2025-03-10"))),
+		Tags:         []string{"qa"},
+		Attributes:   entity.Attributes{"carrier": "vodafone"},
+		CreatedAt:    createdAt,
+	}
+)
+
+// newTestClient serves the device service on an in-memory connection.
+func newTestClient(t *testing.T, devices device.DeviceService, changes device.ChangeWatcher) devicev1.DeviceServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(devices, changes)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return devicev1.NewDeviceServiceClient(conn)
+}
+
+func Test_List_Devices(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *devicev1.ListDevicesRequest
+		wantOpts      *entity.ListOptions
+		devices       []entity.Device
+		wantIDs       []string
+		wantNextToken string
+		wantCode      codes.Code
+	}{
+		{
+			name: "List Devices First Page Case",
+			request: &devicev1.ListDevicesRequest{
+				Brand:    lo.ToPtr("Google"),
+				States:   []devicev1.DeviceState{devicev1.DeviceState_DEVICE_STATE_AVAILABLE},
+				Sort:     []string{"-created_at"},
+				PageSize: 2,
+			},
+			wantOpts: &entity.ListOptions{
+				Filter: entity.DeviceFilter{Brand: lo.ToPtr("Google"), States: []entity.DeviceState{entity.Available}},
+				Sort:   []entity.SortKey{{Field: entity.SortByCreatedAt, Descending: true}},
+				Limit:  3,
+			},
+			devices:       []entity.Device{pixel, {ID: iphoneID}, {ID: galaxyID}},
+			wantIDs:       []string{pixelID.String(), iphoneID.String()},
+			wantNextToken: encodePageToken(2),
+		},
+		{
+			name:     "List Devices Last Page Case",
+			request:  &devicev1.ListDevicesRequest{PageToken: encodePageToken(2)},
+			wantOpts: &entity.ListOptions{Limit: defaultPageSize + 1, Offset: 2},
+			devices:  []entity.Device{{ID: galaxyID}},
+			wantIDs:  []string{galaxyID.String()},
+		},
+		{
+			name:     "List Devices Page Size Above Maximum Case",
+			request:  &devicev1.ListDevicesRequest{PageSize: 1000},
+			wantOpts: &entity.ListOptions{Limit: maxPageSize + 1},
+			wantIDs:  []string{},
+		},
+		{
+			name:     "List Devices Invalid Sort Field Case",
+			request:  &devicev1.ListDevicesRequest{Sort: []string{"imei"}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "List Devices Invalid Page Token Case",
+			request:  &devicev1.ListDevicesRequest{PageToken: "not a token"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "List Devices Unspecified State Case",
+			request:  &devicev1.ListDevicesRequest{States: []devicev1.DeviceState{devicev1.DeviceState_DEVICE_STATE_UNSPECIFIED}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockService := mocks.NewMockDeviceService(mockCtrl)
+			client := newTestClient(t, mockService, device.NewChangeFeed())
+
+			if tt.wantOpts != nil {
+				mockService.
+					EXPECT().
+					List(gomock.Any(), *tt.wantOpts).
+					Return(tt.devices, nil)
+			}
+
+			result, err := client.ListDevices(context.Background(), tt.request)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, tt.wantIDs, lo.Map(result.GetDevices(), func(d *devicev1.Device, _ int) string { return d.GetId() }))
+				assert.Equal(t, tt.wantNextToken, result.GetNextPageToken())
+			}
+		})
+	}
+}
+
+func Test_Get_Device(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockService := mocks.NewMockDeviceService(mockCtrl)
+	client := newTestClient(t, mockService, device.NewChangeFeed())
+
+	mockService.EXPECT().GetByID(gomock.Any(), pixelID).Return(pixel, nil)
+	mockService.EXPECT().GetByID(gomock.Any(), iphoneID).Return(entity.Device{}, errors.NewDeviceError(errors.ErrNotFound, "device not found", nil))
+
+	result, err := client.GetDevice(context.Background(), &devicev1.GetDeviceRequest{Id: pixelID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "Pixel 7", result.GetName())
+	assert.Equal(t, devicev1.DeviceState_DEVICE_STATE_AVAILABLE, result.GetState())
+	assert.Equal(t, "2025-03-10", result.GetPurchaseDate())
+	assert.Equal(t, "vodafone", result.GetAttributes().GetFields()["carrier"].GetStringValue())
+	assert.Nil(t, result.UpdatedAt)
+
+	_, err = client.GetDevice(context.Background(), &devicev1.GetDeviceRequest{Id: iphoneID.String()})
+	assert.Equal(t, status.Error(codes.NotFound, "device not found").Error(), err.Error())
+
+	_, err = client.GetDevice(context.Background(), &devicev1.GetDeviceRequest{Id: "pixel"})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "invalid device id format, must be an uuid").Error(), err.Error())
+}
+
+func Test_Create_Device(t *testing.T) {
+	attributes := lo.Must(structpb.NewStruct(map[string]any{"carrier": "vodafone"}))
+
+	tests := []struct {
+		name        string
+		fields      *devicev1.DeviceFields
+		tenantID    string
+		wantCreate  bool
+		wantErr     error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{
+			name: "Create Device Success Case",
+			fields: &devicev1.DeviceFields{
+				Name:         "Pixel 7",
+				Brand:        "Google",
+				State:        devicev1.DeviceState_DEVICE_STATE_AVAILABLE,
+				PurchaseDate: lo.ToPtr("2025-03-10"),
+				Tags:         []string{"qa"},
+				Attributes:   attributes,
+			},
+			tenantID:   "acme",
+			wantCreate: true,
+		},
+		{
+			name:        "Create Device Under Maintenance Case",
+			fields:      &devicev1.DeviceFields{Name: "Pixel 7", Brand: "Google", State: devicev1.DeviceState_DEVICE_STATE_MAINTENANCE},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "invalid device state, must be one of: available, in-use, inactive",
+		},
+		{
+			name:        "Create Device Invalid Purchase Date Case",
+			fields:      &devicev1.DeviceFields{Name: "Pixel 7", Brand: "Google", State: devicev1.DeviceState_DEVICE_STATE_AVAILABLE, PurchaseDate: lo.ToPtr("10/03/2025")},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "invalid purchase_date format, must be a YYYY-MM-DD date",
+		},
+		{
+			name:        "Create Device Invalid Tenant Case",
+			fields:      &devicev1.DeviceFields{Name: "Pixel 7", Brand: "Google", State: devicev1.DeviceState_DEVICE_STATE_AVAILABLE},
+			tenantID:    "-acme",
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "invalid x-tenant-id metadata, must start with a letter or digit and contain only letters, digits and _ . -, up to 64 characters",
+		},
+		{
+			name:        "Create Device Conflict Case",
+			fields:      &devicev1.DeviceFields{Name: "Pixel 7", Brand: "Google", State: devicev1.DeviceState_DEVICE_STATE_AVAILABLE},
+			wantCreate:  true,
+			wantErr:     errors.NewDeviceError(errors.ErrConflict, "a device with this serial number already exists", &pq.Error{Code: "23505"}),
+			wantCode:    codes.AlreadyExists,
+			wantMessage: "a device with this serial number already exists",
+		},
+		{
+			name:        "Create Device Wrapped Conflict Case",
+			fields:      &devicev1.DeviceFields{Name: "Pixel 7", Brand: "Google", State: devicev1.DeviceState_DEVICE_STATE_AVAILABLE},
+			wantCreate:  true,
+			wantErr:     fmt.Errorf("creating device: %w", errors.NewDeviceError(errors.ErrConflict, "model was renamed meanwhile", nil)),
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "model was renamed meanwhile",
+		},
+		{
+			name:        "Create Device Unexpected Error Case",
+			fields:      &devicev1.DeviceFields{Name: "Pixel 7", Brand: "Google", State: devicev1.DeviceState_DEVICE_STATE_AVAILABLE},
+			wantCreate:  true,
+			wantErr:     assert.AnError,
+			wantCode:    codes.Internal,
+			wantMessage: "Internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockService := mocks.NewMockDeviceService(mockCtrl)
+			client := newTestClient(t, mockService, device.NewChangeFeed())
+
+			if tt.wantCreate {
+				mockService.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, d entity.Device) (entity.Device, error) {
+						tenantID, _ := tenant.IDFromContext(ctx)
+						assert.Equal(t, tt.tenantID, tenantID)
+						d.ID = pixelID
+						d.CreatedAt = createdAt
+						return d, tt.wantErr
+					})
+			}
+
+			ctx := context.Background()
+			if tt.tenantID != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, TenantMetadataKey, tt.tenantID)
+			}
+
+			result, err := client.CreateDevice(ctx, &devicev1.CreateDeviceRequest{Device: tt.fields})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantMessage, status.Convert(err).Message())
+				return
+			}
+			assert.Equal(t, pixelID.String(), result.GetId())
+			assert.Equal(t, "2025-03-10", result.GetPurchaseDate())
+			assert.Equal(t, []string{"qa"}, result.GetTags())
+			assert.Equal(t, "vodafone", result.GetAttributes().GetFields()["carrier"].GetStringValue())
+		})
+	}
+}
+
+func Test_Delete_Device_In_Use(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockService := mocks.NewMockDeviceService(mockCtrl)
+	client := newTestClient(t, mockService, device.NewChangeFeed())
+
+	mockService.
+		EXPECT().
+		Delete(gomock.Any(), pixelID).
+		Return(errors.NewDeviceError(errors.ErrConflict, "device is in use and cannot be deleted", nil))
+
+	_, err := client.DeleteDevice(context.Background(), &devicev1.DeleteDeviceRequest{Id: pixelID.String()})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func Test_Watch_Devices(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockService := mocks.NewMockDeviceService(mockCtrl)
+	feed := device.NewChangeFeed()
+	watcher := subscriptionSignal{ChangeWatcher: feed, subscribed: make(chan struct{})}
+	client := newTestClient(t, mockService, watcher)
+
+	updated := pixel
+	updated.State = entity.InUse
+	mockService.EXPECT().GetByID(gomock.Any(), pixelID).Return(updated, nil)
+	mockService.EXPECT().GetByID(gomock.Any(), galaxyID).Return(entity.Device{}, errors.NewDeviceError(errors.ErrNotFound, "device not found", nil))
+
+	stream, err := client.WatchDevices(context.Background(), &devicev1.WatchDevicesRequest{
+		DeviceIds: []string{pixelID.String(), galaxyID.String()},
+	})
+	require.NoError(t, err)
+
+	<-watcher.subscribed
+
+	changedAt := time.Now().UTC()
+	feed.Publish(entity.DeviceChange{DeviceID: iphoneID, Type: entity.ChangeUpdated, ChangedAt: changedAt})
+	feed.Publish(entity.DeviceChange{DeviceID: galaxyID, Type: entity.ChangeUpdated, ChangedAt: changedAt})
+	feed.Publish(entity.DeviceChange{DeviceID: pixelID, Type: entity.ChangeUpdated, ChangedAt: changedAt})
+	feed.Publish(entity.DeviceChange{DeviceID: pixelID, Type: entity.ChangeDeleted, ChangedAt: changedAt})
+	feed.Close()
+
+	change, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, devicev1.ChangeType_CHANGE_TYPE_UPDATED, change.GetType())
+	assert.Equal(t, pixelID.String(), change.GetDeviceId())
+	assert.Equal(t, devicev1.DeviceState_DEVICE_STATE_IN_USE, change.GetDevice().GetState())
+	assert.True(t, changedAt.Equal(change.GetChangedAt().AsTime()))
+
+	change, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, devicev1.ChangeType_CHANGE_TYPE_DELETED, change.GetType())
+	assert.Nil(t, change.GetDevice())
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+// subscriptionSignal tells when the server subscribed to the changes, those
+// published before would not be watched.
+type subscriptionSignal struct {
+	device.ChangeWatcher
+	subscribed chan struct{}
+}
+
+func (s subscriptionSignal) Subscribe(ctx context.Context) <-chan entity.DeviceChange {
+	changes := s.ChangeWatcher.Subscribe(ctx)
+	close(s.subscribed)
+	return changes
+}
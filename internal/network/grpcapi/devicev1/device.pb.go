@@ -0,0 +1,1262 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: device/v1/device.proto
+
+package devicev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DeviceState int32
+
+const (
+	DeviceState_DEVICE_STATE_UNSPECIFIED DeviceState = 0
+	DeviceState_DEVICE_STATE_AVAILABLE   DeviceState = 1
+	DeviceState_DEVICE_STATE_IN_USE      DeviceState = 2
+	DeviceState_DEVICE_STATE_INACTIVE    DeviceState = 3
+	DeviceState_DEVICE_STATE_MAINTENANCE DeviceState = 4
+	DeviceState_DEVICE_STATE_RETIRED     DeviceState = 5
+)
+
+// Enum value maps for DeviceState.
+var (
+	DeviceState_name = map[int32]string{
+		0: "DEVICE_STATE_UNSPECIFIED",
+		1: "DEVICE_STATE_AVAILABLE",
+		2: "DEVICE_STATE_IN_USE",
+		3: "DEVICE_STATE_INACTIVE",
+		4: "DEVICE_STATE_MAINTENANCE",
+		5: "DEVICE_STATE_RETIRED",
+	}
+	DeviceState_value = map[string]int32{
+		"DEVICE_STATE_UNSPECIFIED": 0,
+		"DEVICE_STATE_AVAILABLE":   1,
+		"DEVICE_STATE_IN_USE":      2,
+		"DEVICE_STATE_INACTIVE":    3,
+		"DEVICE_STATE_MAINTENANCE": 4,
+		"DEVICE_STATE_RETIRED":     5,
+	}
+)
+
+func (x DeviceState) Enum() *DeviceState {
+	p := new(DeviceState)
+	*p = x
+	return p
+}
+
+func (x DeviceState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeviceState) Descriptor() protoreflect.EnumDescriptor {
+	return file_device_v1_device_proto_enumTypes[0].Descriptor()
+}
+
+func (DeviceState) Type() protoreflect.EnumType {
+	return &file_device_v1_device_proto_enumTypes[0]
+}
+
+func (x DeviceState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeviceState.Descriptor instead.
+func (DeviceState) EnumDescriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{0}
+}
+
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	ChangeType_CHANGE_TYPE_CREATED     ChangeType = 1
+	ChangeType_CHANGE_TYPE_UPDATED     ChangeType = 2
+	ChangeType_CHANGE_TYPE_DELETED     ChangeType = 3
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "CHANGE_TYPE_CREATED",
+		2: "CHANGE_TYPE_UPDATED",
+		3: "CHANGE_TYPE_DELETED",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"CHANGE_TYPE_CREATED":     1,
+		"CHANGE_TYPE_UPDATED":     2,
+		"CHANGE_TYPE_DELETED":     3,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_device_v1_device_proto_enumTypes[1].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_device_v1_device_proto_enumTypes[1]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{1}
+}
+
+type Device struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Brand           string                 `protobuf:"bytes,3,opt,name=brand,proto3" json:"brand,omitempty"`
+	BrandId         string                 `protobuf:"bytes,4,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	ModelId         *string                `protobuf:"bytes,5,opt,name=model_id,json=modelId,proto3,oneof" json:"model_id,omitempty"`
+	LocationId      *string                `protobuf:"bytes,6,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"`
+	State           DeviceState            `protobuf:"varint,7,opt,name=state,proto3,enum=devicemanager.device.v1.DeviceState" json:"state,omitempty"`
+	SerialNumber    *string                `protobuf:"bytes,8,opt,name=serial_number,json=serialNumber,proto3,oneof" json:"serial_number,omitempty"`
+	Imei            *string                `protobuf:"bytes,9,opt,name=imei,proto3,oneof" json:"imei,omitempty"`
+	ModelIdentifier *string                `protobuf:"bytes,10,opt,name=model_identifier,json=modelIdentifier,proto3,oneof" json:"model_identifier,omitempty"`
+	OsVersion       *string                `protobuf:"bytes,11,opt,name=os_version,json=osVersion,proto3,oneof" json:"os_version,omitempty"`
+	// purchase_date and warranty_ends_on are dates in the YYYY-MM-DD format.
+	PurchaseDate     *string                `protobuf:"bytes,12,opt,name=purchase_date,json=purchaseDate,proto3,oneof" json:"purchase_date,omitempty"`
+	PurchasePrice    *float64               `protobuf:"fixed64,13,opt,name=purchase_price,json=purchasePrice,proto3,oneof" json:"purchase_price,omitempty"`
+	PurchaseCurrency *string                `protobuf:"bytes,14,opt,name=purchase_currency,json=purchaseCurrency,proto3,oneof" json:"purchase_currency,omitempty"`
+	Supplier         *string                `protobuf:"bytes,15,opt,name=supplier,proto3,oneof" json:"supplier,omitempty"`
+	InvoiceReference *string                `protobuf:"bytes,16,opt,name=invoice_reference,json=invoiceReference,proto3,oneof" json:"invoice_reference,omitempty"`
+	WarrantyEndsOn   *string                `protobuf:"bytes,17,opt,name=warranty_ends_on,json=warrantyEndsOn,proto3,oneof" json:"warranty_ends_on,omitempty"`
+	Tags             []string               `protobuf:"bytes,18,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attributes       *structpb.Struct       `protobuf:"bytes,19,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_device_v1_device_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Device) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Device) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *Device) GetModelId() string {
+	if x != nil && x.ModelId != nil {
+		return *x.ModelId
+	}
+	return ""
+}
+
+func (x *Device) GetLocationId() string {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return ""
+}
+
+func (x *Device) GetState() DeviceState {
+	if x != nil {
+		return x.State
+	}
+	return DeviceState_DEVICE_STATE_UNSPECIFIED
+}
+
+func (x *Device) GetSerialNumber() string {
+	if x != nil && x.SerialNumber != nil {
+		return *x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Device) GetImei() string {
+	if x != nil && x.Imei != nil {
+		return *x.Imei
+	}
+	return ""
+}
+
+func (x *Device) GetModelIdentifier() string {
+	if x != nil && x.ModelIdentifier != nil {
+		return *x.ModelIdentifier
+	}
+	return ""
+}
+
+func (x *Device) GetOsVersion() string {
+	if x != nil && x.OsVersion != nil {
+		return *x.OsVersion
+	}
+	return ""
+}
+
+func (x *Device) GetPurchaseDate() string {
+	if x != nil && x.PurchaseDate != nil {
+		return *x.PurchaseDate
+	}
+	return ""
+}
+
+func (x *Device) GetPurchasePrice() float64 {
+	if x != nil && x.PurchasePrice != nil {
+		return *x.PurchasePrice
+	}
+	return 0
+}
+
+func (x *Device) GetPurchaseCurrency() string {
+	if x != nil && x.PurchaseCurrency != nil {
+		return *x.PurchaseCurrency
+	}
+	return ""
+}
+
+func (x *Device) GetSupplier() string {
+	if x != nil && x.Supplier != nil {
+		return *x.Supplier
+	}
+	return ""
+}
+
+func (x *Device) GetInvoiceReference() string {
+	if x != nil && x.InvoiceReference != nil {
+		return *x.InvoiceReference
+	}
+	return ""
+}
+
+func (x *Device) GetWarrantyEndsOn() string {
+	if x != nil && x.WarrantyEndsOn != nil {
+		return *x.WarrantyEndsOn
+	}
+	return ""
+}
+
+func (x *Device) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Device) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Device) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Device) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// DeviceFields holds the device data informed on create and update. The state
+// must be available, in use or inactive.
+type DeviceFields struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Brand           string                 `protobuf:"bytes,2,opt,name=brand,proto3" json:"brand,omitempty"`
+	ModelId         *string                `protobuf:"bytes,3,opt,name=model_id,json=modelId,proto3,oneof" json:"model_id,omitempty"`
+	State           DeviceState            `protobuf:"varint,4,opt,name=state,proto3,enum=devicemanager.device.v1.DeviceState" json:"state,omitempty"`
+	SerialNumber    *string                `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber,proto3,oneof" json:"serial_number,omitempty"`
+	Imei            *string                `protobuf:"bytes,6,opt,name=imei,proto3,oneof" json:"imei,omitempty"`
+	ModelIdentifier *string                `protobuf:"bytes,7,opt,name=model_identifier,json=modelIdentifier,proto3,oneof" json:"model_identifier,omitempty"`
+	OsVersion       *string                `protobuf:"bytes,8,opt,name=os_version,json=osVersion,proto3,oneof" json:"os_version,omitempty"`
+	// purchase_date and warranty_ends_on are dates in the YYYY-MM-DD format.
+	PurchaseDate     *string          `protobuf:"bytes,9,opt,name=purchase_date,json=purchaseDate,proto3,oneof" json:"purchase_date,omitempty"`
+	PurchasePrice    *float64         `protobuf:"fixed64,10,opt,name=purchase_price,json=purchasePrice,proto3,oneof" json:"purchase_price,omitempty"`
+	PurchaseCurrency *string          `protobuf:"bytes,11,opt,name=purchase_currency,json=purchaseCurrency,proto3,oneof" json:"purchase_currency,omitempty"`
+	Supplier         *string          `protobuf:"bytes,12,opt,name=supplier,proto3,oneof" json:"supplier,omitempty"`
+	InvoiceReference *string          `protobuf:"bytes,13,opt,name=invoice_reference,json=invoiceReference,proto3,oneof" json:"invoice_reference,omitempty"`
+	WarrantyEndsOn   *string          `protobuf:"bytes,14,opt,name=warranty_ends_on,json=warrantyEndsOn,proto3,oneof" json:"warranty_ends_on,omitempty"`
+	Tags             []string         `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attributes       *structpb.Struct `protobuf:"bytes,16,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeviceFields) Reset() {
+	*x = DeviceFields{}
+	mi := &file_device_v1_device_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceFields) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceFields) ProtoMessage() {}
+
+func (x *DeviceFields) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceFields.ProtoReflect.Descriptor instead.
+func (*DeviceFields) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeviceFields) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetModelId() string {
+	if x != nil && x.ModelId != nil {
+		return *x.ModelId
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetState() DeviceState {
+	if x != nil {
+		return x.State
+	}
+	return DeviceState_DEVICE_STATE_UNSPECIFIED
+}
+
+func (x *DeviceFields) GetSerialNumber() string {
+	if x != nil && x.SerialNumber != nil {
+		return *x.SerialNumber
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetImei() string {
+	if x != nil && x.Imei != nil {
+		return *x.Imei
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetModelIdentifier() string {
+	if x != nil && x.ModelIdentifier != nil {
+		return *x.ModelIdentifier
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetOsVersion() string {
+	if x != nil && x.OsVersion != nil {
+		return *x.OsVersion
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetPurchaseDate() string {
+	if x != nil && x.PurchaseDate != nil {
+		return *x.PurchaseDate
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetPurchasePrice() float64 {
+	if x != nil && x.PurchasePrice != nil {
+		return *x.PurchasePrice
+	}
+	return 0
+}
+
+func (x *DeviceFields) GetPurchaseCurrency() string {
+	if x != nil && x.PurchaseCurrency != nil {
+		return *x.PurchaseCurrency
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetSupplier() string {
+	if x != nil && x.Supplier != nil {
+		return *x.Supplier
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetInvoiceReference() string {
+	if x != nil && x.InvoiceReference != nil {
+		return *x.InvoiceReference
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetWarrantyEndsOn() string {
+	if x != nil && x.WarrantyEndsOn != nil {
+		return *x.WarrantyEndsOn
+	}
+	return ""
+}
+
+func (x *DeviceFields) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DeviceFields) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type ListDevicesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// brand is a brand name or alias, model a model ID or name, as in the REST API.
+	Brand *string `protobuf:"bytes,1,opt,name=brand,proto3,oneof" json:"brand,omitempty"`
+	Model *string `protobuf:"bytes,2,opt,name=model,proto3,oneof" json:"model,omitempty"`
+	// location_id matches the devices kept at the location or any location below it.
+	LocationId *string `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"`
+	// states matches any of the states, retired devices are left out unless
+	// asked for here or by include_retired.
+	States         []DeviceState          `protobuf:"varint,4,rep,packed,name=states,proto3,enum=devicemanager.device.v1.DeviceState" json:"states,omitempty"`
+	IncludeRetired bool                   `protobuf:"varint,5,opt,name=include_retired,json=includeRetired,proto3" json:"include_retired,omitempty"`
+	NameContains   *string                `protobuf:"bytes,6,opt,name=name_contains,json=nameContains,proto3,oneof" json:"name_contains,omitempty"`
+	CreatedAfter   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UpdatedSince   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	Tags           []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attributes     map[string]string      `protobuf:"bytes,11,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// sort lists the sort keys applied in order: name, brand, state, created_at
+	// or updated_at, prefixed with - for descending.
+	Sort []string `protobuf:"bytes,12,rep,name=sort,proto3" json:"sort,omitempty"`
+	// page_size defaults to 50 and is at most 500.
+	PageSize int32 `protobuf:"varint,13,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, the other fields
+	// must be the same as on the request for that page.
+	PageToken     string `protobuf:"bytes,14,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_device_v1_device_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDevicesRequest) GetBrand() string {
+	if x != nil && x.Brand != nil {
+		return *x.Brand
+	}
+	return ""
+}
+
+func (x *ListDevicesRequest) GetModel() string {
+	if x != nil && x.Model != nil {
+		return *x.Model
+	}
+	return ""
+}
+
+func (x *ListDevicesRequest) GetLocationId() string {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return ""
+}
+
+func (x *ListDevicesRequest) GetStates() []DeviceState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetIncludeRetired() bool {
+	if x != nil {
+		return x.IncludeRetired
+	}
+	return false
+}
+
+func (x *ListDevicesRequest) GetNameContains() string {
+	if x != nil && x.NameContains != nil {
+		return *x.NameContains
+	}
+	return ""
+}
+
+func (x *ListDevicesRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetSort() []string {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDevicesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDevicesResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Devices []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_device_v1_device_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *ListDevicesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeviceRequest) Reset() {
+	*x = GetDeviceRequest{}
+	mi := &file_device_v1_device_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceRequest) ProtoMessage() {}
+
+func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDeviceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *DeviceFields          `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDeviceRequest) Reset() {
+	*x = CreateDeviceRequest{}
+	mi := &file_device_v1_device_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDeviceRequest) ProtoMessage() {}
+
+func (x *CreateDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDeviceRequest.ProtoReflect.Descriptor instead.
+func (*CreateDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateDeviceRequest) GetDevice() *DeviceFields {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type UpdateDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Device        *DeviceFields          `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDeviceRequest) Reset() {
+	*x = UpdateDeviceRequest{}
+	mi := &file_device_v1_device_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDeviceRequest) ProtoMessage() {}
+
+func (x *UpdateDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDeviceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateDeviceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateDeviceRequest) GetDevice() *DeviceFields {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type DeleteDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDeviceRequest) Reset() {
+	*x = DeleteDeviceRequest{}
+	mi := &file_device_v1_device_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDeviceRequest) ProtoMessage() {}
+
+func (x *DeleteDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDeviceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteDeviceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchDevicesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// device_ids narrows the changes to those devices, all devices are watched
+	// when empty.
+	DeviceIds     []string `protobuf:"bytes,1,rep,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchDevicesRequest) Reset() {
+	*x = WatchDevicesRequest{}
+	mi := &file_device_v1_device_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDevicesRequest) ProtoMessage() {}
+
+func (x *WatchDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDevicesRequest.ProtoReflect.Descriptor instead.
+func (*WatchDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchDevicesRequest) GetDeviceIds() []string {
+	if x != nil {
+		return x.DeviceIds
+	}
+	return nil
+}
+
+type DeviceChange struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Type     ChangeType             `protobuf:"varint,1,opt,name=type,proto3,enum=devicemanager.device.v1.ChangeType" json:"type,omitempty"`
+	DeviceId string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// device is the device as read after the change, absent on deletes.
+	Device        *Device                `protobuf:"bytes,3,opt,name=device,proto3" json:"device,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceChange) Reset() {
+	*x = DeviceChange{}
+	mi := &file_device_v1_device_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceChange) ProtoMessage() {}
+
+func (x *DeviceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_device_v1_device_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceChange.ProtoReflect.Descriptor instead.
+func (*DeviceChange) Descriptor() ([]byte, []int) {
+	return file_device_v1_device_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeviceChange) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *DeviceChange) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceChange) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *DeviceChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+var File_device_v1_device_proto protoreflect.FileDescriptor
+
+const file_device_v1_device_proto_rawDesc = "" +
+	"\n" +
+	"\x16device/v1/device.proto\x12\x17devicemanager.device.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x92\b\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05brand\x18\x03 \x01(\tR\x05brand\x12\x19\n" +
+	"\bbrand_id\x18\x04 \x01(\tR\abrandId\x12\x1e\n" +
+	"\bmodel_id\x18\x05 \x01(\tH\x00R\amodelId\x88\x01\x01\x12$\n" +
+	"\vlocation_id\x18\x06 \x01(\tH\x01R\n" +
+	"locationId\x88\x01\x01\x12:\n" +
+	"\x05state\x18\a \x01(\x0e2$.devicemanager.device.v1.DeviceStateR\x05state\x12(\n" +
+	"\rserial_number\x18\b \x01(\tH\x02R\fserialNumber\x88\x01\x01\x12\x17\n" +
+	"\x04imei\x18\t \x01(\tH\x03R\x04imei\x88\x01\x01\x12.\n" +
+	"\x10model_identifier\x18\n" +
+	" \x01(\tH\x04R\x0fmodelIdentifier\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"os_version\x18\v \x01(\tH\x05R\tosVersion\x88\x01\x01\x12(\n" +
+	"\rpurchase_date\x18\f \x01(\tH\x06R\fpurchaseDate\x88\x01\x01\x12*\n" +
+	"\x0epurchase_price\x18\r \x01(\x01H\aR\rpurchasePrice\x88\x01\x01\x120\n" +
+	"\x11purchase_currency\x18\x0e \x01(\tH\bR\x10purchaseCurrency\x88\x01\x01\x12\x1f\n" +
+	"\bsupplier\x18\x0f \x01(\tH\tR\bsupplier\x88\x01\x01\x120\n" +
+	"\x11invoice_reference\x18\x10 \x01(\tH\n" +
+	"R\x10invoiceReference\x88\x01\x01\x12-\n" +
+	"\x10warranty_ends_on\x18\x11 \x01(\tH\vR\x0ewarrantyEndsOn\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x12 \x03(\tR\x04tags\x127\n" +
+	"\n" +
+	"attributes\x18\x13 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x129\n" +
+	"\n" +
+	"created_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\v\n" +
+	"\t_model_idB\x0e\n" +
+	"\f_location_idB\x10\n" +
+	"\x0e_serial_numberB\a\n" +
+	"\x05_imeiB\x13\n" +
+	"\x11_model_identifierB\r\n" +
+	"\v_os_versionB\x10\n" +
+	"\x0e_purchase_dateB\x11\n" +
+	"\x0f_purchase_priceB\x14\n" +
+	"\x12_purchase_currencyB\v\n" +
+	"\t_supplierB\x14\n" +
+	"\x12_invoice_referenceB\x13\n" +
+	"\x11_warranty_ends_on\"\xc1\x06\n" +
+	"\fDeviceFields\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05brand\x18\x02 \x01(\tR\x05brand\x12\x1e\n" +
+	"\bmodel_id\x18\x03 \x01(\tH\x00R\amodelId\x88\x01\x01\x12:\n" +
+	"\x05state\x18\x04 \x01(\x0e2$.devicemanager.device.v1.DeviceStateR\x05state\x12(\n" +
+	"\rserial_number\x18\x05 \x01(\tH\x01R\fserialNumber\x88\x01\x01\x12\x17\n" +
+	"\x04imei\x18\x06 \x01(\tH\x02R\x04imei\x88\x01\x01\x12.\n" +
+	"\x10model_identifier\x18\a \x01(\tH\x03R\x0fmodelIdentifier\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"os_version\x18\b \x01(\tH\x04R\tosVersion\x88\x01\x01\x12(\n" +
+	"\rpurchase_date\x18\t \x01(\tH\x05R\fpurchaseDate\x88\x01\x01\x12*\n" +
+	"\x0epurchase_price\x18\n" +
+	" \x01(\x01H\x06R\rpurchasePrice\x88\x01\x01\x120\n" +
+	"\x11purchase_currency\x18\v \x01(\tH\aR\x10purchaseCurrency\x88\x01\x01\x12\x1f\n" +
+	"\bsupplier\x18\f \x01(\tH\bR\bsupplier\x88\x01\x01\x120\n" +
+	"\x11invoice_reference\x18\r \x01(\tH\tR\x10invoiceReference\x88\x01\x01\x12-\n" +
+	"\x10warranty_ends_on\x18\x0e \x01(\tH\n" +
+	"R\x0ewarrantyEndsOn\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x0f \x03(\tR\x04tags\x127\n" +
+	"\n" +
+	"attributes\x18\x10 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributesB\v\n" +
+	"\t_model_idB\x10\n" +
+	"\x0e_serial_numberB\a\n" +
+	"\x05_imeiB\x13\n" +
+	"\x11_model_identifierB\r\n" +
+	"\v_os_versionB\x10\n" +
+	"\x0e_purchase_dateB\x11\n" +
+	"\x0f_purchase_priceB\x14\n" +
+	"\x12_purchase_currencyB\v\n" +
+	"\t_supplierB\x14\n" +
+	"\x12_invoice_referenceB\x13\n" +
+	"\x11_warranty_ends_on\"\xfc\x05\n" +
+	"\x12ListDevicesRequest\x12\x19\n" +
+	"\x05brand\x18\x01 \x01(\tH\x00R\x05brand\x88\x01\x01\x12\x19\n" +
+	"\x05model\x18\x02 \x01(\tH\x01R\x05model\x88\x01\x01\x12$\n" +
+	"\vlocation_id\x18\x03 \x01(\tH\x02R\n" +
+	"locationId\x88\x01\x01\x12<\n" +
+	"\x06states\x18\x04 \x03(\x0e2$.devicemanager.device.v1.DeviceStateR\x06states\x12'\n" +
+	"\x0finclude_retired\x18\x05 \x01(\bR\x0eincludeRetired\x12(\n" +
+	"\rname_contains\x18\x06 \x01(\tH\x03R\fnameContains\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rupdated_since\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12[\n" +
+	"\n" +
+	"attributes\x18\v \x03(\v2;.devicemanager.device.v1.ListDevicesRequest.AttributesEntryR\n" +
+	"attributes\x12\x12\n" +
+	"\x04sort\x18\f \x03(\tR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\r \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x0e \x01(\tR\tpageToken\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_brandB\b\n" +
+	"\x06_modelB\x0e\n" +
+	"\f_location_idB\x10\n" +
+	"\x0e_name_contains\"x\n" +
+	"\x13ListDevicesResponse\x129\n" +
+	"\adevices\x18\x01 \x03(\v2\x1f.devicemanager.device.v1.DeviceR\adevices\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\"\n" +
+	"\x10GetDeviceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"T\n" +
+	"\x13CreateDeviceRequest\x12=\n" +
+	"\x06device\x18\x01 \x01(\v2%.devicemanager.device.v1.DeviceFieldsR\x06device\"d\n" +
+	"\x13UpdateDeviceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\x06device\x18\x02 \x01(\v2%.devicemanager.device.v1.DeviceFieldsR\x06device\"%\n" +
+	"\x13DeleteDeviceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
+	"\x13WatchDevicesRequest\x12\x1d\n" +
+	"\n" +
+	"device_ids\x18\x01 \x03(\tR\tdeviceIds\"\xd8\x01\n" +
+	"\fDeviceChange\x127\n" +
+	"\x04type\x18\x01 \x01(\x0e2#.devicemanager.device.v1.ChangeTypeR\x04type\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x127\n" +
+	"\x06device\x18\x03 \x01(\v2\x1f.devicemanager.device.v1.DeviceR\x06device\x129\n" +
+	"\n" +
+	"changed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt*\xb3\x01\n" +
+	"\vDeviceState\x12\x1c\n" +
+	"\x18DEVICE_STATE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16DEVICE_STATE_AVAILABLE\x10\x01\x12\x17\n" +
+	"\x13DEVICE_STATE_IN_USE\x10\x02\x12\x19\n" +
+	"\x15DEVICE_STATE_INACTIVE\x10\x03\x12\x1c\n" +
+	"\x18DEVICE_STATE_MAINTENANCE\x10\x04\x12\x18\n" +
+	"\x14DEVICE_STATE_RETIRED\x10\x05*t\n" +
+	"\n" +
+	"ChangeType\x12\x1b\n" +
+	"\x17CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CHANGE_TYPE_CREATED\x10\x01\x12\x17\n" +
+	"\x13CHANGE_TYPE_UPDATED\x10\x02\x12\x17\n" +
+	"\x13CHANGE_TYPE_DELETED\x10\x032\xcd\x04\n" +
+	"\rDeviceService\x12h\n" +
+	"\vListDevices\x12+.devicemanager.device.v1.ListDevicesRequest\x1a,.devicemanager.device.v1.ListDevicesResponse\x12W\n" +
+	"\tGetDevice\x12).devicemanager.device.v1.GetDeviceRequest\x1a\x1f.devicemanager.device.v1.Device\x12]\n" +
+	"\fCreateDevice\x12,.devicemanager.device.v1.CreateDeviceRequest\x1a\x1f.devicemanager.device.v1.Device\x12]\n" +
+	"\fUpdateDevice\x12,.devicemanager.device.v1.UpdateDeviceRequest\x1a\x1f.devicemanager.device.v1.Device\x12T\n" +
+	"\fDeleteDevice\x12,.devicemanager.device.v1.DeleteDeviceRequest\x1a\x16.google.protobuf.Empty\x12e\n" +
+	"\fWatchDevices\x12,.devicemanager.device.v1.WatchDevicesRequest\x1a%.devicemanager.device.v1.DeviceChange0\x01BRZPgithub.com/tiagos4ntos/device-manager/internal/network/grpcapi/devicev1;devicev1b\x06proto3"
+
+var (
+	file_device_v1_device_proto_rawDescOnce sync.Once
+	file_device_v1_device_proto_rawDescData []byte
+)
+
+func file_device_v1_device_proto_rawDescGZIP() []byte {
+	file_device_v1_device_proto_rawDescOnce.Do(func() {
+		file_device_v1_device_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_device_v1_device_proto_rawDesc), len(file_device_v1_device_proto_rawDesc)))
+	})
+	return file_device_v1_device_proto_rawDescData
+}
+
+var file_device_v1_device_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_device_v1_device_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_device_v1_device_proto_goTypes = []any{
+	(DeviceState)(0),              // 0: devicemanager.device.v1.DeviceState
+	(ChangeType)(0),               // 1: devicemanager.device.v1.ChangeType
+	(*Device)(nil),                // 2: devicemanager.device.v1.Device
+	(*DeviceFields)(nil),          // 3: devicemanager.device.v1.DeviceFields
+	(*ListDevicesRequest)(nil),    // 4: devicemanager.device.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),   // 5: devicemanager.device.v1.ListDevicesResponse
+	(*GetDeviceRequest)(nil),      // 6: devicemanager.device.v1.GetDeviceRequest
+	(*CreateDeviceRequest)(nil),   // 7: devicemanager.device.v1.CreateDeviceRequest
+	(*UpdateDeviceRequest)(nil),   // 8: devicemanager.device.v1.UpdateDeviceRequest
+	(*DeleteDeviceRequest)(nil),   // 9: devicemanager.device.v1.DeleteDeviceRequest
+	(*WatchDevicesRequest)(nil),   // 10: devicemanager.device.v1.WatchDevicesRequest
+	(*DeviceChange)(nil),          // 11: devicemanager.device.v1.DeviceChange
+	nil,                           // 12: devicemanager.device.v1.ListDevicesRequest.AttributesEntry
+	(*structpb.Struct)(nil),       // 13: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
+}
+var file_device_v1_device_proto_depIdxs = []int32{
+	0,  // 0: devicemanager.device.v1.Device.state:type_name -> devicemanager.device.v1.DeviceState
+	13, // 1: devicemanager.device.v1.Device.attributes:type_name -> google.protobuf.Struct
+	14, // 2: devicemanager.device.v1.Device.created_at:type_name -> google.protobuf.Timestamp
+	14, // 3: devicemanager.device.v1.Device.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: devicemanager.device.v1.DeviceFields.state:type_name -> devicemanager.device.v1.DeviceState
+	13, // 5: devicemanager.device.v1.DeviceFields.attributes:type_name -> google.protobuf.Struct
+	0,  // 6: devicemanager.device.v1.ListDevicesRequest.states:type_name -> devicemanager.device.v1.DeviceState
+	14, // 7: devicemanager.device.v1.ListDevicesRequest.created_after:type_name -> google.protobuf.Timestamp
+	14, // 8: devicemanager.device.v1.ListDevicesRequest.created_before:type_name -> google.protobuf.Timestamp
+	14, // 9: devicemanager.device.v1.ListDevicesRequest.updated_since:type_name -> google.protobuf.Timestamp
+	12, // 10: devicemanager.device.v1.ListDevicesRequest.attributes:type_name -> devicemanager.device.v1.ListDevicesRequest.AttributesEntry
+	2,  // 11: devicemanager.device.v1.ListDevicesResponse.devices:type_name -> devicemanager.device.v1.Device
+	3,  // 12: devicemanager.device.v1.CreateDeviceRequest.device:type_name -> devicemanager.device.v1.DeviceFields
+	3,  // 13: devicemanager.device.v1.UpdateDeviceRequest.device:type_name -> devicemanager.device.v1.DeviceFields
+	1,  // 14: devicemanager.device.v1.DeviceChange.type:type_name -> devicemanager.device.v1.ChangeType
+	2,  // 15: devicemanager.device.v1.DeviceChange.device:type_name -> devicemanager.device.v1.Device
+	14, // 16: devicemanager.device.v1.DeviceChange.changed_at:type_name -> google.protobuf.Timestamp
+	4,  // 17: devicemanager.device.v1.DeviceService.ListDevices:input_type -> devicemanager.device.v1.ListDevicesRequest
+	6,  // 18: devicemanager.device.v1.DeviceService.GetDevice:input_type -> devicemanager.device.v1.GetDeviceRequest
+	7,  // 19: devicemanager.device.v1.DeviceService.CreateDevice:input_type -> devicemanager.device.v1.CreateDeviceRequest
+	8,  // 20: devicemanager.device.v1.DeviceService.UpdateDevice:input_type -> devicemanager.device.v1.UpdateDeviceRequest
+	9,  // 21: devicemanager.device.v1.DeviceService.DeleteDevice:input_type -> devicemanager.device.v1.DeleteDeviceRequest
+	10, // 22: devicemanager.device.v1.DeviceService.WatchDevices:input_type -> devicemanager.device.v1.WatchDevicesRequest
+	5,  // 23: devicemanager.device.v1.DeviceService.ListDevices:output_type -> devicemanager.device.v1.ListDevicesResponse
+	2,  // 24: devicemanager.device.v1.DeviceService.GetDevice:output_type -> devicemanager.device.v1.Device
+	2,  // 25: devicemanager.device.v1.DeviceService.CreateDevice:output_type -> devicemanager.device.v1.Device
+	2,  // 26: devicemanager.device.v1.DeviceService.UpdateDevice:output_type -> devicemanager.device.v1.Device
+	15, // 27: devicemanager.device.v1.DeviceService.DeleteDevice:output_type -> google.protobuf.Empty
+	11, // 28: devicemanager.device.v1.DeviceService.WatchDevices:output_type -> devicemanager.device.v1.DeviceChange
+	23, // [23:29] is the sub-list for method output_type
+	17, // [17:23] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_device_v1_device_proto_init() }
+func file_device_v1_device_proto_init() {
+	if File_device_v1_device_proto != nil {
+		return
+	}
+	file_device_v1_device_proto_msgTypes[0].OneofWrappers = []any{}
+	file_device_v1_device_proto_msgTypes[1].OneofWrappers = []any{}
+	file_device_v1_device_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_device_v1_device_proto_rawDesc), len(file_device_v1_device_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_device_v1_device_proto_goTypes,
+		DependencyIndexes: file_device_v1_device_proto_depIdxs,
+		EnumInfos:         file_device_v1_device_proto_enumTypes,
+		MessageInfos:      file_device_v1_device_proto_msgTypes,
+	}.Build()
+	File_device_v1_device_proto = out.File
+	file_device_v1_device_proto_goTypes = nil
+	file_device_v1_device_proto_depIdxs = nil
+}
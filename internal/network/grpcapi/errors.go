@@ -0,0 +1,68 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	deviceerrors "github.com/tiagos4ntos/device-manager/internal/domain/device/errors"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus turns a domain error into a gRPC status, the counterpart of the
+// HTTP status the REST API answers with. Only the server errors are logged,
+// as the REST error handler does.
+func toStatus(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, "Call canceled by the client")
+	}
+	if errorhandler.IsTimeout(err) {
+		log.Printf("grpc: %v", err)
+		return status.Error(codes.DeadlineExceeded, "Call timed out, retry later")
+	}
+	if errorhandler.IsUnavailable(err) {
+		log.Printf("grpc: %v", err)
+		return status.Error(codes.Unavailable, "Service temporarily unavailable, retry later")
+	}
+	if errorhandler.IsConcurrentUpdate(err) {
+		return status.Error(codes.Aborted, "Changed by a concurrent call, retry")
+	}
+
+	var e *deviceerrors.DeviceError
+	if !errors.As(err, &e) {
+		log.Printf("grpc: %v", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	code := mapDomainErrorsToCode(e)
+	if code == codes.Internal {
+		log.Printf("grpc: %v", err)
+	}
+	return status.Error(code, e.Message)
+}
+
+// mapDomainErrorsToCode maps the conflicts with an existing device, as a
+// duplicate serial number, to AlreadyExists and the other ones, as a device in
+// use, to FailedPrecondition.
+func mapDomainErrorsToCode(e *deviceerrors.DeviceError) codes.Code {
+	switch e.Type {
+	case deviceerrors.ErrNotFound:
+		return codes.NotFound
+	case deviceerrors.ErrInvalid:
+		return codes.InvalidArgument
+	case deviceerrors.ErrConflict:
+		if database.IsUniqueViolation(e.Err) {
+			return codes.AlreadyExists
+		}
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
+
+func invalidArgument(msg string) error {
+	return status.Error(codes.InvalidArgument, msg)
+}
@@ -0,0 +1,34 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"google.golang.org/grpc"
+)
+
+// UnaryOperationTimeout bounds each database operation made on behalf of a
+// call to the timeout in effect, zero leaving them bounded by the call.
+func UnaryOperationTimeout(timeout func() time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(database.WithOperationTimeout(ctx, timeout()), req)
+	}
+}
+
+// StreamOperationTimeout is UnaryOperationTimeout for the streaming calls.
+func StreamOperationTimeout(timeout func() time.Duration) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: database.WithOperationTimeout(ss.Context(), timeout())})
+	}
+}
+
+// contextStream is a server stream with its context replaced.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
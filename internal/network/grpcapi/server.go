@@ -0,0 +1,43 @@
+// Package grpcapi serves the device operations over gRPC, next to the REST API.
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/tiagos4ntos/device-manager/internal/domain/device"
+	"github.com/tiagos4ntos/device-manager/internal/domain/tenant"
+	"github.com/tiagos4ntos/device-manager/internal/network/grpcapi/devicev1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// TenantMetadataKey identifies the tenant a call is made on behalf of, as the
+// X-Tenant-ID header does on the REST API.
+const TenantMetadataKey = "x-tenant-id"
+
+// NewServer returns a gRPC server with the device service registered, along
+// with server reflection so tools such as grpcurl can discover it. opts, such
+// as the TLS credentials, configure the server.
+func NewServer(devices device.DeviceService, changes device.ChangeWatcher, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	devicev1.RegisterDeviceServiceServer(server, NewDeviceServer(devices, changes))
+	reflection.Register(server)
+	return server
+}
+
+// tenantContext returns a copy of ctx carrying the tenant informed on the call metadata, if any.
+func tenantContext(ctx context.Context) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, TenantMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return ctx, nil
+	}
+
+	if !tenant.ValidID(values[0]) {
+		return nil, status.Error(codes.InvalidArgument, "invalid "+TenantMetadataKey+" metadata, must start with a letter or digit and contain only letters, digits and _ . -, up to 64 characters")
+	}
+
+	return tenant.WithID(ctx, values[0]), nil
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+)
+
+type AttributeDefinitionHandler interface {
+	List() echo.HandlerFunc
+	Put() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+}
+
+type attributeDefinitionHandler struct {
+	attributeDefinitionService device.AttributeDefinitionService
+}
+
+func NewAttributeDefinitionHandler(service device.AttributeDefinitionService) AttributeDefinitionHandler {
+	return &attributeDefinitionHandler{
+		attributeDefinitionService: service,
+	}
+}
+
+// List godoc
+// @Summary      List attribute definitions
+// @Description  Returns the custom attribute schema of the tenant, ordered by name
+// @Tags         attribute-definitions
+// @Produce      json
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"
+// @Success      200  {array}   dto.AttributeDefinitionResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /attribute-definitions [get]
+func (h *attributeDefinitionHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, err := tenantContext(c)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		definitions, err := h.attributeDefinitionService.List(ctx)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		result := make([]dto.AttributeDefinitionResponse, 0)
+		for _, definition := range definitions {
+			result = append(result, toAttributeDefinitionResponse(definition))
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// Put godoc
+// @Summary      Create or replace an attribute definition
+// @Description  Declares the type of a custom attribute of the tenant devices, whether it is required and, for strings, its allowed values. Devices are validated against the schema when they are created or updated.
+// @Tags         attribute-definitions
+// @Accept       json
+// @Produce      json
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"
+// @Param        name         path    string  true  "Attribute name"
+// @Param        definition   body    dto.PutAttributeDefinitionRequest  true  "Attribute definition payload"
+// @Success      200  {object}  dto.AttributeDefinitionResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /attribute-definitions/{name} [put]
+func (h *attributeDefinitionHandler) Put() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.PutAttributeDefinitionRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", err))
+		}
+
+		if err := req.Validate(); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "validation error", err))
+		}
+
+		ctx, err := tenantContext(c)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		definition, err := h.attributeDefinitionService.Put(ctx, entity.AttributeDefinition{
+			Name:     c.Param("name"),
+			Type:     entity.AttributeType(req.Type),
+			Required: req.Required,
+			Enum:     req.Enum,
+		})
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toAttributeDefinitionResponse(definition))
+	}
+}
+
+// Delete godoc
+// @Summary      Delete an attribute definition
+// @Description  Removes an attribute from the tenant schema, the values already stored on devices are kept
+// @Tags         attribute-definitions
+// @Produce      json
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"
+// @Param        name         path    string  true  "Attribute name"
+// @Success      204  "No Content"
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /attribute-definitions/{name} [delete]
+func (h *attributeDefinitionHandler) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, err := tenantContext(c)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		err = h.attributeDefinitionService.Delete(ctx, c.Param("name"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusNoContent, nil)
+	}
+}
+
+func toAttributeDefinitionResponse(definition entity.AttributeDefinition) dto.AttributeDefinitionResponse {
+	enum := definition.Enum
+	if enum == nil {
+		enum = []string{}
+	}
+
+	return dto.AttributeDefinitionResponse{
+		Name:      definition.Name,
+		Type:      definition.Type.String(),
+		Required:  definition.Required,
+		Enum:      enum,
+		CreatedAt: definition.CreatedAt,
+		UpdatedAt: definition.UpdatedAt,
+	}
+}
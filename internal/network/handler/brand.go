@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand"
+	"github.com/tiagos4ntos/device-manager/internal/domain/brand/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+)
+
+type BrandHandler interface {
+	List() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	Create() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+}
+
+type brandHandler struct {
+	brandService brand.BrandService
+}
+
+func NewBrandHandler(service brand.BrandService) BrandHandler {
+	return &brandHandler{
+		brandService: service,
+	}
+}
+
+// List godoc
+// @Summary      List brands
+// @Description  Returns the brand catalogue ordered by name
+// @Tags         brands
+// @Produce      json
+// @Success      200  {array}   dto.BrandResponse
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /brands [get]
+func (h *brandHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		brands, err := h.brandService.List(c.Request().Context())
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		result := make([]dto.BrandResponse, 0)
+		for _, b := range brands {
+			result = append(result, toBrandResponse(b))
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetByID godoc
+// @Summary      Get brand by ID
+// @Description  Returns a single brand with its aliases
+// @Tags         brands
+// @Produce      json
+// @Param        id   path      string  true  "Brand ID"
+// @Success      200  {object}  dto.BrandResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /brands/{id} [get]
+func (h *brandHandler) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		brandID, err := validateAndParseBrandId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		b, err := h.brandService.GetByID(c.Request().Context(), brandID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toBrandResponse(b))
+	}
+}
+
+// Create godoc
+// @Summary      Create a brand
+// @Description  Registers a brand in the catalogue. Names and aliases are matched case insensitively and without company suffixes such as Inc or Ltd, so they must not resolve to another brand.
+// @Tags         brands
+// @Accept       json
+// @Produce      json
+// @Param        brand  body      dto.BrandRequest  true  "Brand payload"
+// @Success      201    {object}  dto.BrandResponse
+// @Failure      400    {object}  errors.DefaultErrorResult
+// @Failure      409    {object}  errors.DefaultErrorResult
+// @Failure      500    {object}  errors.DefaultErrorResult
+// @Router       /brands [post]
+func (h *brandHandler) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.BrandRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", nil))
+		}
+
+		b, err := h.brandService.Create(c.Request().Context(), entity.Brand{Name: req.Name, Aliases: req.Aliases})
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, toBrandResponse(b))
+	}
+}
+
+// Update godoc
+// @Summary      Update a brand
+// @Description  Renames a brand and replaces its aliases, the devices of the brand are renamed too
+// @Tags         brands
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true  "Brand ID"
+// @Param        brand  body      dto.BrandRequest  true  "Brand payload"
+// @Success      200    {object}  dto.BrandResponse
+// @Failure      400    {object}  errors.DefaultErrorResult
+// @Failure      404    {object}  errors.DefaultErrorResult
+// @Failure      409    {object}  errors.DefaultErrorResult
+// @Failure      500    {object}  errors.DefaultErrorResult
+// @Router       /brands/{id} [put]
+func (h *brandHandler) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		brandID, err := validateAndParseBrandId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var req dto.BrandRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", err))
+		}
+
+		b, err := h.brandService.Update(c.Request().Context(), entity.Brand{ID: brandID, Name: req.Name, Aliases: req.Aliases})
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toBrandResponse(b))
+	}
+}
+
+// Delete godoc
+// @Summary      Delete a brand
+// @Description  Removes a brand from the catalogue, only brands without devices can be deleted
+// @Tags         brands
+// @Produce      json
+// @Param        id   path      string  true  "Brand ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      409  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /brands/{id} [delete]
+func (h *brandHandler) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		brandID, err := validateAndParseBrandId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		if err = h.brandService.Delete(c.Request().Context(), brandID); err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusNoContent, nil)
+	}
+}
+
+func validateAndParseBrandId(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform the brand id", nil)
+	}
+	brandID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid brand id format, must be an uuid", nil)
+	}
+	return brandID, nil
+}
+
+func toBrandResponse(b entity.Brand) dto.BrandResponse {
+	aliases := b.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return dto.BrandResponse{
+		ID:        b.ID.String(),
+		Name:      b.Name,
+		Aliases:   aliases,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
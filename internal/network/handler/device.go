@@ -2,14 +2,12 @@ package handler
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"net/url"
-	"regexp"
-	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device"
 	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
 	"github.com/tiagos4ntos/device-manager/internal/network/dto"
@@ -19,9 +17,13 @@ import (
 type DeviceHandler interface {
 	List() echo.HandlerFunc
 	GetByID() echo.HandlerFunc
+	GetBySerialNumber() echo.HandlerFunc
 	Create() echo.HandlerFunc
 	Update() echo.HandlerFunc
 	Delete() echo.HandlerFunc
+	Move() echo.HandlerFunc
+	History() echo.HandlerFunc
+	Stats() echo.HandlerFunc
 }
 
 type deviceHandler struct {
@@ -36,44 +38,71 @@ func NewDeviceHandler(service device.DeviceService) DeviceHandler {
 
 // List godoc
 // @Summary List devices
-// @Description Get all devices
+// @Description Get all devices, optionally filtered and sorted. When the "q" parameter is informed a full-text and fuzzy search is made on name and brand, results are ranked by relevance and carry the highlighted matches. Custom attributes are filtered with one attr.<name> parameter per attribute, eg. attr.carrier=vodafone.
 // @Tags devices
 // @Accept json
 // @Produce json
-// @Param        brand   path      string  false  "Brand name: eg. Apple"
-// @Param        state   path      string  false  "State, must be one of: available, in-use, inactive"
-// @Success 200 {array} dto.DeviceResponse
+// @Param X-Tenant-ID header string false "Tenant whose attribute schema types the attr.<name> filters"
+// @Success 200 {array} dto.DeviceResponse "Devices ordered by name, or dto.DeviceSearchResponse items ordered by rank when q is informed"
+// @Failure 400 {object} errors.DefaultErrorResult
 // @Failure 500 {object} errors.DefaultErrorResult
 // @Router /devices [get]
 func (h *deviceHandler) List() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		params, err := validateAndParseListParams(c)
+		opts, err := validateAndParseListParams(c)
 		if err != nil {
 			return errorhandler.Handle(c, err)
 		}
 
-		devices, err := h.deviceService.List(context.Background(), params)
+		return listDevices(c, h.deviceService, opts)
+	}
+}
 
-		if err != nil {
-			return errorhandler.Handle(c, err)
-		}
+// listDevices answers a device listing, searching devices instead when the "q"
+// parameter is informed.
+func listDevices(c echo.Context, deviceService device.DeviceService, opts entity.ListOptions) error {
+	ctx, err := tenantContext(c)
+	if err != nil {
+		return errorhandler.Handle(c, err)
+	}
 
-		result := make([]dto.DeviceResponse, 0)
-		for _, device := range devices {
-			d := dto.DeviceResponse{
-				ID:        device.ID.String(),
-				Name:      device.Name,
-				Brand:     device.Brand,
-				State:     string(device.State),
-				CreatedAt: device.CreatedAt,
-				UpdatedAt: device.UpdatedAt,
-				DeletedAt: device.DeletedAt,
-			}
-			result = append(result, d)
-		}
+	if c.QueryParams().Has("q") {
+		return searchDevices(ctx, c, deviceService, c.QueryParam("q"), opts)
+	}
 
-		return c.JSON(http.StatusOK, result)
+	devices, err := deviceService.List(ctx, opts)
+
+	if err != nil {
+		return errorhandler.Handle(c, err)
+	}
+
+	result := make([]dto.DeviceResponse, 0)
+	for _, device := range devices {
+		result = append(result, toDeviceResponse(device))
 	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func searchDevices(ctx context.Context, c echo.Context, deviceService device.DeviceService, term string, opts entity.ListOptions) error {
+	results, err := deviceService.Search(ctx, term, opts)
+	if err != nil {
+		return errorhandler.Handle(c, err)
+	}
+
+	response := make([]dto.DeviceSearchResponse, 0)
+	for _, r := range results {
+		response = append(response, dto.DeviceSearchResponse{
+			DeviceResponse: toDeviceResponse(r.Device),
+			Rank:           r.Rank,
+			Highlights: dto.DeviceSearchHighlights{
+				Name:  r.NameHighlight,
+				Brand: r.BrandHighlight,
+			},
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
 }
 
 // GetDeviceByID godoc
@@ -98,22 +127,44 @@ func (h *deviceHandler) GetByID() echo.HandlerFunc {
 		}
 
 		// fetch device by id
-		device, err := h.deviceService.GetByID(context.Background(), deviceID)
+		device, err := h.deviceService.GetByID(c.Request().Context(), deviceID)
 
 		if err != nil {
 			return errorhandler.Handle(c, err)
 		}
 
-		result := dto.DeviceResponse{
-			ID:        device.ID.String(),
-			Name:      device.Name,
-			Brand:     device.Brand,
-			State:     string(device.State),
-			CreatedAt: device.CreatedAt,
-			UpdatedAt: device.UpdatedAt,
-			DeletedAt: device.DeletedAt,
+		result := toDeviceResponse(device)
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetDeviceBySerialNumber godoc
+// @Summary      Get device by serial number
+// @Description  Returns a single device by its serial number, the lookup is case insensitive
+// @Tags         devices
+// @Produce      json
+// @Param        serial   path      string  true  "Device serial number"
+// @Success      200  {object}  dto.DeviceResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /devices/by-serial/{serial} [get]
+func (h *deviceHandler) GetBySerialNumber() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		serialNumber := c.Param("serial")
+		if serialNumber == "" {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform the device serial number", nil))
+		}
+
+		device, err := h.deviceService.GetBySerialNumber(c.Request().Context(), serialNumber)
+
+		if err != nil {
+			return errorhandler.Handle(c, err)
 		}
 
+		result := toDeviceResponse(device)
+
 		return c.JSON(http.StatusOK, result)
 	}
 }
@@ -124,9 +175,11 @@ func (h *deviceHandler) GetByID() echo.HandlerFunc {
 // @Tags         devices
 // @Accept       json
 // @Produce      json
+// @Param        X-Tenant-ID  header  string  false  "Tenant whose attribute schema the device attributes must follow"
 // @Param        device  body      dto.CreateDeviceRequest  true  "Device payload"
 // @Success      201     {object}  dto.DeviceResponse
 // @Failure      400     {object}  errors.DefaultErrorResult
+// @Failure      409     {object}  errors.DefaultErrorResult
 // @Failure      500     {object}  errors.DefaultErrorResult
 // @Router       /devices [post]
 func (h *deviceHandler) Create() echo.HandlerFunc {
@@ -140,26 +193,53 @@ func (h *deviceHandler) Create() echo.HandlerFunc {
 			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "validation error", err))
 		}
 
-		device := entity.Device{
-			Name:  req.Name,
-			Brand: req.Brand,
-			State: entity.DeviceState(req.State),
+		modelID, err := validateAndParseDeviceModelId(req.ModelID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		purchaseDate, err := parseOptionalDate("purchase_date", req.PurchaseDate)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		warrantyEndsOn, err := parseOptionalDate("warranty_ends_on", req.WarrantyEndsOn)
+		if err != nil {
+			return errorhandler.Handle(c, err)
 		}
 
-		deviceCreated, err := h.deviceService.Create(context.Background(), device)
+		device := entity.Device{
+			Name:             req.Name,
+			Brand:            req.Brand,
+			ModelID:          modelID,
+			State:            entity.DeviceState(req.State),
+			SerialNumber:     req.SerialNumber,
+			IMEI:             req.IMEI,
+			ModelIdentifier:  req.ModelIdentifier,
+			OSVersion:        req.OSVersion,
+			PurchaseDate:     purchaseDate,
+			PurchasePrice:    req.PurchasePrice,
+			PurchaseCurrency: req.PurchaseCurrency,
+			Supplier:         req.Supplier,
+			InvoiceReference: req.InvoiceReference,
+			WarrantyEndsOn:   warrantyEndsOn,
+			Tags:             req.Tags,
+			Attributes:       req.Attributes,
+		}
 
+		ctx, err := tenantContext(c)
 		if err != nil {
 			return errorhandler.Handle(c, err)
 		}
 
-		result := dto.DeviceResponse{
-			ID:        deviceCreated.ID.String(),
-			Name:      deviceCreated.Name,
-			Brand:     deviceCreated.Brand,
-			State:     deviceCreated.State.String(),
-			CreatedAt: deviceCreated.CreatedAt,
+		deviceCreated, err := h.deviceService.Create(ctx, device)
+
+		if err != nil {
+			return errorhandler.Handle(c, err)
 		}
 
+		result := toDeviceResponse(deviceCreated)
+
 		return c.JSON(http.StatusCreated, result)
 	}
 }
@@ -171,9 +251,11 @@ func (h *deviceHandler) Create() echo.HandlerFunc {
 // @Accept       json
 // @Produce      json
 // @Param        id      path      string  true  "Device ID"
+// @Param        X-Tenant-ID  header  string  false  "Tenant whose attribute schema the device attributes must follow"
 // @Param        device  body      dto.UpdateDeviceRequest  true  "Updated device payload"
 // @Success      200     {object}  dto.DeviceResponse
 // @Failure      400     {object}  errors.DefaultErrorResult
+// @Failure      409     {object}  errors.DefaultErrorResult
 // @Failure      500     {object}  errors.DefaultErrorResult
 // @Router       /devices/{id} [put]
 func (h *deviceHandler) Update() echo.HandlerFunc {
@@ -194,40 +276,66 @@ func (h *deviceHandler) Update() echo.HandlerFunc {
 			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "validation error", err))
 		}
 
-		device := entity.Device{
-			ID:    deviceID,
-			Name:  req.Name,
-			Brand: req.Brand,
-			State: entity.DeviceState(req.State),
+		modelID, err := validateAndParseDeviceModelId(req.ModelID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
 		}
 
-		updatedDevice, err := h.deviceService.Update(context.Background(), device)
+		purchaseDate, err := parseOptionalDate("purchase_date", req.PurchaseDate)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
 
+		warrantyEndsOn, err := parseOptionalDate("warranty_ends_on", req.WarrantyEndsOn)
 		if err != nil {
 			return errorhandler.Handle(c, err)
 		}
 
-		result := dto.DeviceResponse{
-			ID:        updatedDevice.ID.String(),
-			Name:      updatedDevice.Name,
-			Brand:     updatedDevice.Brand,
-			State:     updatedDevice.State.String(),
-			CreatedAt: updatedDevice.CreatedAt,
-			UpdatedAt: updatedDevice.UpdatedAt,
-			DeletedAt: updatedDevice.DeletedAt,
+		device := entity.Device{
+			ID:               deviceID,
+			Name:             req.Name,
+			Brand:            req.Brand,
+			ModelID:          modelID,
+			State:            entity.DeviceState(req.State),
+			SerialNumber:     req.SerialNumber,
+			IMEI:             req.IMEI,
+			ModelIdentifier:  req.ModelIdentifier,
+			OSVersion:        req.OSVersion,
+			PurchaseDate:     purchaseDate,
+			PurchasePrice:    req.PurchasePrice,
+			PurchaseCurrency: req.PurchaseCurrency,
+			Supplier:         req.Supplier,
+			InvoiceReference: req.InvoiceReference,
+			WarrantyEndsOn:   warrantyEndsOn,
+			Tags:             req.Tags,
+			Attributes:       req.Attributes,
 		}
+
+		ctx, err := tenantContext(c)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		updatedDevice, err := h.deviceService.Update(ctx, device)
+
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		result := toDeviceResponse(updatedDevice)
 		return c.JSON(http.StatusOK, result)
 	}
 }
 
 // DeleteDevice godoc
 // @Summary      Delete a device
-// @Description  Removes a device by ID, only devices that are not "in-use" can be deleted
+// @Description  Removes a device by ID, only devices that are not "in-use" nor under "maintenance" can be deleted
 // @Tags         devices
 // @Produce      json
 // @Param        id   path      string  true  "Device ID"
 // @Success      204  "No Content"
 // @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      409  {object}  errors.DefaultErrorResult
 // @Failure      500  {object}  errors.DefaultErrorResult
 // @Router       /devices/{id} [delete]
 func (h *deviceHandler) Delete() echo.HandlerFunc {
@@ -241,7 +349,7 @@ func (h *deviceHandler) Delete() echo.HandlerFunc {
 		}
 
 		// fetch device by id
-		err = h.deviceService.Delete(context.Background(), deviceID)
+		err = h.deviceService.Delete(c.Request().Context(), deviceID)
 
 		if err != nil {
 			return errorhandler.Handle(c, err)
@@ -251,44 +359,84 @@ func (h *deviceHandler) Delete() echo.HandlerFunc {
 	}
 }
 
-func validateAndParseListParams(c echo.Context) (map[string]any, error) {
-	allowedParams := map[string]bool{
-		"brand": true,
-		"state": true,
-	}
-
-	parsedQuery, err := url.ParseQuery(c.Request().URL.RawQuery)
-	if err != nil {
-		fmt.Println(err)
-		return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid query string: %v", err.Error()), nil)
-	}
+// Move godoc
+// @Summary      Move a device
+// @Description  Takes a device to another location, whatever its state, and records the move in the device history
+// @Tags         devices
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Device ID"
+// @Param        move  body      dto.MoveDeviceRequest  true  "Destination location"
+// @Success      200   {object}  dto.DeviceResponse
+// @Failure      400   {object}  errors.DefaultErrorResult
+// @Failure      404   {object}  errors.DefaultErrorResult
+// @Failure      500   {object}  errors.DefaultErrorResult
+// @Router       /devices/{id}/move [post]
+func (h *deviceHandler) Move() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		deviceID, err := validateAndParseDeviceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
 
-	for param := range parsedQuery {
-		if !allowedParams[param] {
-			return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid parameter: %s", param), nil)
+		var req dto.MoveDeviceRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", err))
 		}
-	}
 
-	if len(c.QueryParams()) <= 0 {
-		return map[string]any{}, nil
-	}
+		locationID, err := validateAndParseLocationId(req.LocationID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
 
-	brandParam := c.QueryParam("brand")
-	stateParam := c.QueryParam("state")
+		device, err := h.deviceService.Move(c.Request().Context(), deviceID, locationID, req.Note)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
 
-	if strings.Contains(c.Request().URL.String(), "brand=") && (brandParam == "" || !hasValidValue(brandParam)) {
-		return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid brand filter", nil)
+		return c.JSON(http.StatusOK, toDeviceResponse(device))
 	}
+}
 
-	if stateParam != "" && !validateListDeviceStateFilter(stateParam) {
-		return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid state filter, must be one of: available, in-use, inactive", nil)
-	}
+// History godoc
+// @Summary      Get device history
+// @Description  Returns what happened to a device, newest events first
+// @Tags         devices
+// @Produce      json
+// @Param        id   path      string  true  "Device ID"
+// @Success      200  {array}   dto.DeviceHistoryEventResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /devices/{id}/history [get]
+func (h *deviceHandler) History() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		deviceID, err := validateAndParseDeviceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		events, err := h.deviceService.History(c.Request().Context(), deviceID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
 
-	return map[string]any{
-		"brand": getStringOrNull(brandParam),
-		"state": getStringOrNull(stateParam),
-	}, nil
+		result := make([]dto.DeviceHistoryEventResponse, 0)
+		for _, e := range events {
+			result = append(result, dto.DeviceHistoryEventResponse{
+				ID:             e.ID.String(),
+				Event:          e.Event.String(),
+				FromLocationID: uuidString(e.FromLocationID),
+				ToLocationID:   uuidString(e.ToLocationID),
+				FromState:      stateString(e.FromState),
+				ToState:        stateString(e.ToState),
+				Note:           e.Note,
+				CreatedAt:      e.CreatedAt,
+			})
+		}
 
+		return c.JSON(http.StatusOK, result)
+	}
 }
 
 func validateAndParseDeviceId(id string) (uuid.UUID, error) {
@@ -302,23 +450,74 @@ func validateAndParseDeviceId(id string) (uuid.UUID, error) {
 	return deviceID, nil
 }
 
-func validateListDeviceStateFilter(stateParam string) bool {
-	validStates := map[string]bool{
-		"available": true,
-		"in-use":    true,
-		"inactive":  true,
+// validateAndParseDeviceModelId parses the optional model of a device payload.
+func validateAndParseDeviceModelId(id *string) (*uuid.UUID, error) {
+	if id == nil {
+		return nil, nil
+	}
+	modelID, err := validateAndParseModelId(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &modelID, nil
+}
+
+// parseOptionalDate parses a YYYY-MM-DD date informed in the field of a request body.
+func parseOptionalDate(field string, value *string) (*time.Time, error) {
+	date, err := entity.ParseDate(field, value)
+	if err != nil {
+		return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, err.Error(), nil)
+	}
+	return date, nil
+}
+
+// dateString formats an optional date as YYYY-MM-DD, nil stays nil.
+func dateString(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	return lo.ToPtr(date.Format(time.DateOnly))
+}
+
+// stateString formats an optional state, nil stays nil.
+func stateString(state *entity.DeviceState) *string {
+	if state == nil {
+		return nil
 	}
-	return validStates[stateParam]
+	return lo.ToPtr(state.String())
 }
 
-func getStringOrNull(value string) interface{} {
-	if value == "" {
+// uuidString formats an optional ID, nil stays nil.
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
 		return nil
 	}
-	return value
+	return lo.ToPtr(id.String())
 }
 
-func hasValidValue(param string) bool {
-	validParam := regexp.MustCompile(`^[a-zA-Z0-9]+$`)
-	return validParam.MatchString(param)
+func toDeviceResponse(device entity.Device) dto.DeviceResponse {
+	return dto.DeviceResponse{
+		ID:               device.ID.String(),
+		Name:             device.Name,
+		Brand:            device.Brand,
+		BrandID:          device.BrandID.String(),
+		ModelID:          uuidString(device.ModelID),
+		LocationID:       uuidString(device.LocationID),
+		State:            device.State.String(),
+		SerialNumber:     device.SerialNumber,
+		IMEI:             device.IMEI,
+		ModelIdentifier:  device.ModelIdentifier,
+		OSVersion:        device.OSVersion,
+		PurchaseDate:     dateString(device.PurchaseDate),
+		PurchasePrice:    device.PurchasePrice,
+		PurchaseCurrency: device.PurchaseCurrency,
+		Supplier:         device.Supplier,
+		InvoiceReference: device.InvoiceReference,
+		WarrantyEndsOn:   dateString(device.WarrantyEndsOn),
+		Tags:             device.Tags,
+		Attributes:       device.Attributes,
+		CreatedAt:        device.CreatedAt,
+		UpdatedAt:        device.UpdatedAt,
+		DeletedAt:        device.DeletedAt,
+	}
 }
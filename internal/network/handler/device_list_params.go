@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+// validBrandParam accepts brand names such as "Sony Ericsson", "HTC-One" or "AT&T".
+var validBrandParam = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} .&'-]*$`)
+
+// searchableTerm requires at least one letter or digit, the only characters used by the search.
+var searchableTerm = regexp.MustCompile(`[\p{L}\p{N}]`)
+
+var validListDeviceStates = []string{entity.Available.String(), entity.InUse.String(), entity.Inactive.String(), entity.Maintenance.String(), entity.Retired.String()}
+
+// ListDevicesQuerySchema declares the query string accepted by GET /devices.
+var ListDevicesQuerySchema = queryparam.NewSchema(
+	queryparam.Param{
+		Name:        "q",
+		Description: "Search term matched by prefix and similarity against name and brand: eg. sony eric",
+		Pattern:     searchableTerm,
+		PatternHint: "must contain at least one letter or digit",
+		MaxLength:   100,
+	},
+	queryparam.Param{
+		Name:        "brand",
+		Description: "Brand name or alias from the brand catalogue, case insensitive and ignoring company suffixes: eg. Apple",
+		Pattern:     validBrandParam,
+		PatternHint: "must start with a letter or digit and contain only letters, digits, spaces and . & ' -",
+		MaxLength:   100,
+	},
+	queryparam.Param{
+		Name:        "model",
+		Description: "Model ID, or model name from the model catalogue, case insensitive: eg. Pixel 7",
+		MaxLength:   100,
+	},
+	queryparam.Param{
+		Name:        "state",
+		Description: "State, several may be informed using the in operator: eg. in,available,in-use",
+		Multi:       true,
+		InOperator:  true,
+		Enum:        validListDeviceStates,
+	},
+	queryparam.Param{
+		Name:        "include_retired",
+		Type:        queryparam.TypeBoolean,
+		Description: "Also list retired devices, which are left out unless asked for by state: eg. true",
+	},
+	queryparam.Param{
+		Name:        "name_contains",
+		Description: "Case insensitive part of the device name: eg. galaxy",
+		MaxLength:   100,
+	},
+	queryparam.Param{
+		Name:        "created_after",
+		Type:        queryparam.TypeDateTime,
+		Description: "Devices created after an RFC 3339 timestamp or date: eg. 2025-08-31T21:00:00Z",
+	},
+	queryparam.Param{
+		Name:        "created_before",
+		Type:        queryparam.TypeDateTime,
+		Description: "Devices created before an RFC 3339 timestamp or date: eg. 2025-09-30",
+	},
+	queryparam.Param{
+		Name:        "updated_since",
+		Type:        queryparam.TypeDateTime,
+		Description: "Devices updated at or after an RFC 3339 timestamp or date: eg. 2025-09-01",
+	},
+	queryparam.Param{
+		Name:        "tag",
+		Description: "Tags the devices must carry, all of them: eg. qa,lab",
+		Multi:       true,
+		MaxLength:   50,
+	},
+	queryparam.Param{
+		Name:        "attr.",
+		Description: "Custom attribute the devices must have, one parameter per attribute: eg. attr.carrier=vodafone",
+		Prefix:      true,
+		MaxLength:   100,
+	},
+	queryparam.Param{
+		Name:        "sort",
+		Description: "Sort keys applied in order, prefix with - for descending: eg. -created_at,name",
+		Multi:       true,
+		Enum:        sortParamValues(entity.SortFields),
+	},
+)
+
+func validateAndParseListParams(c echo.Context) (entity.ListOptions, error) {
+	var opts entity.ListOptions
+
+	values, err := ListDevicesQuerySchema.Parse(c.Request().URL.RawQuery)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.Filter = entity.DeviceFilter{
+		Brand:         values.String("brand"),
+		Model:         values.String("model"),
+		NameContains:  values.String("name_contains"),
+		CreatedAfter:  values.Time("created_after"),
+		CreatedBefore: values.Time("created_before"),
+		UpdatedSince:  values.Time("updated_since"),
+	}
+
+	for _, state := range values.List("state") {
+		opts.Filter.States = append(opts.Filter.States, entity.DeviceState(state))
+	}
+
+	if includeRetired := values.Bool("include_retired"); includeRetired != nil {
+		opts.Filter.IncludeRetired = *includeRetired
+	}
+
+	opts.Filter.Tags = values.List("tag")
+
+	if attributes := values.Prefixed("attr."); len(attributes) > 0 {
+		opts.Filter.Attributes = entity.Attributes{}
+		for name, value := range attributes {
+			opts.Filter.Attributes[name] = value
+		}
+	}
+
+	if opts.Filter.CreatedAfter != nil && opts.Filter.CreatedBefore != nil && !opts.Filter.CreatedAfter.Before(*opts.Filter.CreatedBefore) {
+		return opts, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid date range, created_after must be before created_before", nil)
+	}
+
+	if opts.Sort, err = parseSortKeys(values.List("sort")); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+// parseSortKeys turns the validated sort values into sort keys, a leading "-" sorts descending.
+func parseSortKeys(values []string) ([]entity.SortKey, error) {
+	keys, err := entity.ParseSortKeys(values)
+	if err != nil {
+		return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, err.Error(), nil)
+	}
+	return keys, nil
+}
+
+func sortParamValues(fields []entity.SortField) []string {
+	return lo.FlatMap(fields, func(field entity.SortField, _ int) []string {
+		return []string{field.String(), "-" + field.String()}
+	})
+}
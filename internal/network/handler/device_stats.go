@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+// defaultStatsDays is the length of the daily series when no period is informed.
+const defaultStatsDays = 30
+
+// DeviceStatsQuerySchema declares the query string accepted by GET /devices/stats.
+var DeviceStatsQuerySchema = queryparam.NewSchema(
+	queryparam.Param{
+		Name:        "by_location",
+		Type:        queryparam.TypeBoolean,
+		Description: "Also count the devices per location: eg. true",
+	},
+	queryparam.Param{
+		Name:        "from",
+		Type:        queryparam.TypeDateTime,
+		Description: "First day of the daily series, 29 days before to when not informed: eg. 2025-09-01",
+	},
+	queryparam.Param{
+		Name:        "to",
+		Type:        queryparam.TypeDateTime,
+		Description: "Last day of the daily series, today when not informed: eg. 2025-09-30",
+	},
+)
+
+// Stats godoc
+// @Summary      Inventory stats
+// @Description  Counts the devices by state, brand and optionally location, with the number of devices in each state at the end of every day of a period, rebuilt from the device history
+// @Tags         devices
+// @Produce      json
+// @Success      200  {object}  dto.DeviceStatsResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /devices/stats [get]
+func (h *deviceHandler) Stats() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values, err := DeviceStatsQuerySchema.Parse(c.Request().URL.RawQuery)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		opts := entity.StatsOptions{To: time.Now()}
+		if byLocation := values.Bool("by_location"); byLocation != nil {
+			opts.ByLocation = *byLocation
+		}
+		if to := values.Time("to"); to != nil {
+			opts.To = *to
+		}
+		opts.From = opts.To.AddDate(0, 0, 1-defaultStatsDays)
+		if from := values.Time("from"); from != nil {
+			opts.From = *from
+		}
+
+		stats, err := h.deviceService.Stats(c.Request().Context(), opts)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toDeviceStatsResponse(stats, opts.ByLocation))
+	}
+}
+
+func toDeviceStatsResponse(stats entity.DeviceStats, byLocation bool) dto.DeviceStatsResponse {
+	result := dto.DeviceStatsResponse{
+		Total:   stats.Total,
+		ByState: stateCounts(stats.ByState),
+		ByBrand: make([]dto.BrandCountResponse, 0),
+		Daily:   make([]dto.DailyStateCountResponse, 0),
+	}
+
+	for _, b := range stats.ByBrand {
+		result.ByBrand = append(result.ByBrand, dto.BrandCountResponse{
+			BrandID: b.BrandID.String(),
+			Brand:   b.Brand,
+			Devices: b.Devices,
+		})
+	}
+
+	if byLocation {
+		result.ByLocation = make([]dto.LocationCountResponse, 0)
+		for _, l := range stats.ByLocation {
+			result.ByLocation = append(result.ByLocation, dto.LocationCountResponse{
+				LocationID: uuidString(l.LocationID),
+				Name:       l.Name,
+				Devices:    l.Devices,
+			})
+		}
+	}
+
+	for _, d := range stats.Daily {
+		result.Daily = append(result.Daily, dto.DailyStateCountResponse{
+			Day:     d.Day.Format(time.DateOnly),
+			ByState: stateCounts(d.ByState),
+		})
+	}
+
+	return result
+}
+
+func stateCounts(counts map[entity.DeviceState]int) map[string]int {
+	result := make(map[string]int, len(counts))
+	for state, devices := range counts {
+		result[state.String()] = devices
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal"
+	"github.com/tiagos4ntos/device-manager/internal/domain/disposal/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+)
+
+type DisposalHandler interface {
+	Retire() echo.HandlerFunc
+	GetByDevice() echo.HandlerFunc
+}
+
+type disposalHandler struct {
+	disposalService disposal.DisposalService
+}
+
+func NewDisposalHandler(disposalService disposal.DisposalService) DisposalHandler {
+	return &disposalHandler{
+		disposalService: disposalService,
+	}
+}
+
+// Retire godoc
+// @Summary      Retire a device
+// @Description  Disposes of an available or inactive device, devices in use can only be retired when lost or stolen. Recycled and sold devices must have had their data wiped. Retired devices cannot be updated, moved nor reserved and their upcoming reservations are cancelled
+// @Tags         disposal
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string  true  "Device ID"
+// @Param        disposal  body      dto.RetireDeviceRequest  true  "Disposal payload"
+// @Success      201       {object}  dto.DisposalResponse
+// @Failure      400       {object}  errors.DefaultErrorResult
+// @Failure      404       {object}  errors.DefaultErrorResult
+// @Failure      409       {object}  errors.DefaultErrorResult
+// @Failure      500       {object}  errors.DefaultErrorResult
+// @Router       /devices/{id}/retire [post]
+func (h *disposalHandler) Retire() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		deviceID, err := validateAndParseDeviceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var req dto.RetireDeviceRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", err))
+		}
+
+		record, err := h.disposalService.Retire(c.Request().Context(), entity.DisposalRecord{
+			DeviceID:             deviceID,
+			Reason:               entity.DisposalReason(req.Reason),
+			DataWiped:            req.DataWiped,
+			CertificateReference: req.CertificateReference,
+			Note:                 req.Note,
+		})
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, toDisposalResponse(record))
+	}
+}
+
+// GetByDevice godoc
+// @Summary      Get the disposal record of a device
+// @Description  Returns how and when a retired device left the inventory
+// @Tags         disposal
+// @Produce      json
+// @Param        id   path      string  true  "Device ID"
+// @Success      200  {object}  dto.DisposalResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /devices/{id}/disposal [get]
+func (h *disposalHandler) GetByDevice() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		deviceID, err := validateAndParseDeviceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		record, err := h.disposalService.GetByDeviceID(c.Request().Context(), deviceID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toDisposalResponse(record))
+	}
+}
+
+func toDisposalResponse(r entity.DisposalRecord) dto.DisposalResponse {
+	return dto.DisposalResponse{
+		ID:                   r.ID.String(),
+		DeviceID:             r.DeviceID.String(),
+		Reason:               r.Reason.String(),
+		DataWiped:            r.DataWiped,
+		CertificateReference: r.CertificateReference,
+		Note:                 r.Note,
+		DisposedAt:           r.DisposedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/graphqlapi"
+)
+
+type GraphQLHandler interface {
+	Query() echo.HandlerFunc
+}
+
+type graphQLHandler struct {
+	schema *graphqlapi.Schema
+}
+
+func NewGraphQLHandler(schema *graphqlapi.Schema) GraphQLHandler {
+	return &graphQLHandler{
+		schema: schema,
+	}
+}
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Query godoc
+// @Summary      Run a GraphQL operation
+// @Description  Queries devices along with their brand, model, location and reservations, or creates, updates and deletes devices. The schema is described in docs/graphql.md and can be introspected. Errors of the operation are answered with 200 on the errors of the response, each with a code extension
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Param        X-Tenant-ID  header    string  false  "Tenant whose attribute schema applies to the devices"
+// @Param        operation    body      object  true   "query, operationName and variables of the operation"
+// @Success      200          {object}  object
+// @Failure      400          {object}  errors.DefaultErrorResult
+// @Router       /graphql [post]
+func (h *graphQLHandler) Query() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, err := tenantContext(c)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var req graphQLRequest
+		if err := c.Bind(&req); err != nil || req.Query == "" {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform the query of the operation", err))
+		}
+
+		return c.JSON(http.StatusOK, h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+)
+
+type HealthHandler interface {
+	Live() echo.HandlerFunc
+	Ready() echo.HandlerFunc
+}
+
+type healthHandler struct {
+	ready func() bool
+}
+
+// NewHealthHandler returns the handler of the probes, ready telling whether the
+// application accepts requests.
+func NewHealthHandler(ready func() bool) HealthHandler {
+	return &healthHandler{
+		ready: ready,
+	}
+}
+
+// Live godoc
+// @Summary      Liveness probe
+// @Description  Answers 200 while the application is running, including while it shuts down
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  dto.HealthResponse
+// @Router       /health/live [get]
+func (h *healthHandler) Live() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, dto.HealthResponse{Status: "live"})
+	}
+}
+
+// Ready godoc
+// @Summary      Readiness probe
+// @Description  Answers 200 once the application is started, and 503 as soon as it starts shutting down so no new requests are sent while the ones in flight are drained
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  dto.HealthResponse
+// @Failure      503  {object}  dto.HealthResponse
+// @Router       /health/ready [get]
+func (h *healthHandler) Ready() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !h.ready() {
+			return c.JSON(http.StatusServiceUnavailable, dto.HealthResponse{Status: "draining"})
+		}
+		return c.JSON(http.StatusOK, dto.HealthResponse{Status: "ready"})
+	}
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+	"github.com/tiagos4ntos/device-manager/internal/domain/device"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location"
+	"github.com/tiagos4ntos/device-manager/internal/domain/location/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+// ListLocationsQuerySchema declares the query string accepted by GET /locations.
+var ListLocationsQuerySchema = queryparam.NewSchema(
+	queryparam.Param{
+		Name:        "parent_id",
+		Description: "Parent location ID, lists the locations right inside it: eg. 4c0d1e20-3f4a-4b5c-9d6e-7f8091a2b300",
+		Pattern:     validUUIDParam,
+		PatternHint: "must be an uuid",
+	},
+	queryparam.Param{
+		Name:        "kind",
+		Description: "Location kind",
+		Enum:        []string{entity.Site.String(), entity.Room.String(), entity.Shelf.String()},
+	},
+)
+
+type LocationHandler interface {
+	List() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	Create() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	ListDevices() echo.HandlerFunc
+}
+
+type locationHandler struct {
+	locationService location.LocationService
+	deviceService   device.DeviceService
+}
+
+func NewLocationHandler(locationService location.LocationService, deviceService device.DeviceService) LocationHandler {
+	return &locationHandler{
+		locationService: locationService,
+		deviceService:   deviceService,
+	}
+}
+
+// List godoc
+// @Summary      List locations
+// @Description  Returns the locations ordered by name, each with the number of devices per state kept at it or below it
+// @Tags         locations
+// @Produce      json
+// @Success      200  {array}   dto.LocationResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /locations [get]
+func (h *locationHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values, err := ListLocationsQuerySchema.Parse(c.Request().URL.RawQuery)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var filter entity.LocationFilter
+		if parentID := values.String("parent_id"); parentID != nil {
+			filter.ParentID = lo.ToPtr(uuid.MustParse(*parentID))
+		}
+		if kind := values.String("kind"); kind != nil {
+			filter.Kind = lo.ToPtr(entity.LocationKind(*kind))
+		}
+
+		locations, err := h.locationService.List(c.Request().Context(), filter)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		result := make([]dto.LocationResponse, 0)
+		for _, l := range locations {
+			result = append(result, toLocationResponse(l))
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetByID godoc
+// @Summary      Get location by ID
+// @Description  Returns a single location with the number of devices per state kept at it or below it
+// @Tags         locations
+// @Produce      json
+// @Param        id   path      string  true  "Location ID"
+// @Success      200  {object}  dto.LocationResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /locations/{id} [get]
+func (h *locationHandler) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		locationID, err := validateAndParseLocationId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		l, err := h.locationService.GetByID(c.Request().Context(), locationID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toLocationResponse(l))
+	}
+}
+
+// Create godoc
+// @Summary      Create a location
+// @Description  Registers a site, a room inside a site or a shelf inside a room, names are unique within the parent location regardless of case
+// @Tags         locations
+// @Accept       json
+// @Produce      json
+// @Param        location  body      dto.LocationRequest  true  "Location payload"
+// @Success      201       {object}  dto.LocationResponse
+// @Failure      400       {object}  errors.DefaultErrorResult
+// @Failure      409       {object}  errors.DefaultErrorResult
+// @Failure      500       {object}  errors.DefaultErrorResult
+// @Router       /locations [post]
+func (h *locationHandler) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.LocationRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", nil))
+		}
+
+		l, err := toLocation(req)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		created, err := h.locationService.Create(c.Request().Context(), l)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, toLocationResponse(created))
+	}
+}
+
+// Update godoc
+// @Summary      Update a location
+// @Description  Renames a location or moves it, with everything inside it, to another parent location. The kind of a location cannot be changed
+// @Tags         locations
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string  true  "Location ID"
+// @Param        location  body      dto.LocationRequest  true  "Location payload"
+// @Success      200       {object}  dto.LocationResponse
+// @Failure      400       {object}  errors.DefaultErrorResult
+// @Failure      404       {object}  errors.DefaultErrorResult
+// @Failure      409       {object}  errors.DefaultErrorResult
+// @Failure      500       {object}  errors.DefaultErrorResult
+// @Router       /locations/{id} [put]
+func (h *locationHandler) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		locationID, err := validateAndParseLocationId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var req dto.LocationRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", err))
+		}
+
+		l, err := toLocation(req)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+		l.ID = locationID
+
+		updated, err := h.locationService.Update(c.Request().Context(), l)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toLocationResponse(updated))
+	}
+}
+
+// Delete godoc
+// @Summary      Delete a location
+// @Description  Removes a location, only locations without locations or devices inside can be deleted
+// @Tags         locations
+// @Produce      json
+// @Param        id   path      string  true  "Location ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      409  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /locations/{id} [delete]
+func (h *locationHandler) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		locationID, err := validateAndParseLocationId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		if err = h.locationService.Delete(c.Request().Context(), locationID); err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusNoContent, nil)
+	}
+}
+
+// ListDevices godoc
+// @Summary      List the devices of a location
+// @Description  Returns the devices kept at a location or any location below it, accepting the same filters, sorting and search as GET /devices
+// @Tags         locations
+// @Produce      json
+// @Param        id           path    string  true   "Location ID"
+// @Param        X-Tenant-ID  header  string  false  "Tenant whose attribute schema types the attr.<name> filters"
+// @Success      200  {array}   dto.DeviceResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /locations/{id}/devices [get]
+func (h *locationHandler) ListDevices() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		locationID, err := validateAndParseLocationId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		opts, err := validateAndParseListParams(c)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		if _, err := h.locationService.GetByID(c.Request().Context(), locationID); err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		opts.Filter.LocationID = &locationID
+		return listDevices(c, h.deviceService, opts)
+	}
+}
+
+func validateAndParseLocationId(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform the location id", nil)
+	}
+	locationID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid location id format, must be an uuid", nil)
+	}
+	return locationID, nil
+}
+
+func toLocation(req dto.LocationRequest) (entity.Location, error) {
+	l := entity.Location{
+		Kind: entity.LocationKind(req.Kind),
+		Name: req.Name,
+	}
+
+	if req.ParentID != nil {
+		parentID, err := validateAndParseLocationId(*req.ParentID)
+		if err != nil {
+			return entity.Location{}, err
+		}
+		l.ParentID = &parentID
+	}
+
+	return l, nil
+}
+
+func toLocationResponse(l entity.Location) dto.LocationResponse {
+	// every state is listed, states without devices count zero
+	deviceCounts := make(map[string]int, len(validListDeviceStates))
+	for _, state := range validListDeviceStates {
+		deviceCounts[state] = l.DeviceCounts[state]
+	}
+
+	return dto.LocationResponse{
+		ID:           l.ID.String(),
+		ParentID:     uuidString(l.ParentID),
+		Kind:         l.Kind.String(),
+		Name:         l.Name,
+		DeviceCounts: deviceCounts,
+		CreatedAt:    l.CreatedAt,
+		UpdatedAt:    l.UpdatedAt,
+	}
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance"
+	"github.com/tiagos4ntos/device-manager/internal/domain/maintenance/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+// ListMaintenanceQuerySchema declares the query string accepted by GET /maintenance.
+var ListMaintenanceQuerySchema = queryparam.NewSchema(
+	queryparam.Param{
+		Name:        "device_id",
+		Description: "Device ID: eg. b44ecc02-872e-4c18-8d2a-ac09dfc4b49a",
+		Pattern:     validUUIDParam,
+		PatternHint: "must be an uuid",
+	},
+	queryparam.Param{
+		Name:        "vendor",
+		Description: "Repair vendor, case insensitive: eg. ifix lisboa",
+		MaxLength:   100,
+	},
+	queryparam.Param{
+		Name:        "status",
+		Description: "Whether the maintenance is still going on",
+		Enum:        []string{"open", "closed"},
+	},
+	queryparam.Param{
+		Name:        "overdue",
+		Type:        queryparam.TypeBoolean,
+		Description: "Open maintenance past its expected return date: eg. true",
+	},
+)
+
+type MaintenanceHandler interface {
+	Open() echo.HandlerFunc
+	List() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	Close() echo.HandlerFunc
+}
+
+type maintenanceHandler struct {
+	maintenanceService maintenance.MaintenanceService
+}
+
+func NewMaintenanceHandler(maintenanceService maintenance.MaintenanceService) MaintenanceHandler {
+	return &maintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// Open godoc
+// @Summary      Send a device to maintenance
+// @Description  Opens a maintenance record for an available or inactive device, the device stays under maintenance until the record is closed
+// @Tags         maintenance
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string  true  "Device ID"
+// @Param        maintenance  body      dto.OpenMaintenanceRequest  true  "Maintenance payload"
+// @Success      201          {object}  dto.MaintenanceResponse
+// @Failure      400          {object}  errors.DefaultErrorResult
+// @Failure      404          {object}  errors.DefaultErrorResult
+// @Failure      409          {object}  errors.DefaultErrorResult
+// @Failure      500          {object}  errors.DefaultErrorResult
+// @Router       /devices/{id}/maintenance [post]
+func (h *maintenanceHandler) Open() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		deviceID, err := validateAndParseDeviceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var req dto.OpenMaintenanceRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", err))
+		}
+
+		record := entity.MaintenanceRecord{
+			DeviceID: deviceID,
+			Reason:   req.Reason,
+			Vendor:   req.Vendor,
+			Cost:     req.Cost,
+		}
+
+		if req.ExpectedReturnDate != nil {
+			date, err := time.Parse(time.DateOnly, *req.ExpectedReturnDate)
+			if err != nil {
+				return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid expected_return_date format, must be a YYYY-MM-DD date", nil))
+			}
+			record.ExpectedReturnDate = &date
+		}
+
+		opened, err := h.maintenanceService.Open(c.Request().Context(), record)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, toMaintenanceResponse(opened))
+	}
+}
+
+// List godoc
+// @Summary      List maintenance records
+// @Description  Returns the maintenance records, most recently opened first
+// @Tags         maintenance
+// @Produce      json
+// @Success      200  {array}   dto.MaintenanceResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /maintenance [get]
+func (h *maintenanceHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values, err := ListMaintenanceQuerySchema.Parse(c.Request().URL.RawQuery)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		filter := entity.MaintenanceFilter{
+			Vendor:  values.String("vendor"),
+			Overdue: values.Bool("overdue"),
+		}
+		if deviceID := values.String("device_id"); deviceID != nil {
+			filter.DeviceID = lo.ToPtr(uuid.MustParse(*deviceID))
+		}
+		if status := values.String("status"); status != nil {
+			filter.Open = lo.ToPtr(*status == "open")
+		}
+
+		records, err := h.maintenanceService.List(c.Request().Context(), filter)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		result := make([]dto.MaintenanceResponse, 0)
+		for _, r := range records {
+			result = append(result, toMaintenanceResponse(r))
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetByID godoc
+// @Summary      Get maintenance record by ID
+// @Description  Returns a single maintenance record
+// @Tags         maintenance
+// @Produce      json
+// @Param        id   path      string  true  "Maintenance record ID"
+// @Success      200  {object}  dto.MaintenanceResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /maintenance/{id} [get]
+func (h *maintenanceHandler) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		recordID, err := validateAndParseMaintenanceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		record, err := h.maintenanceService.GetByID(c.Request().Context(), recordID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toMaintenanceResponse(record))
+	}
+}
+
+// Close godoc
+// @Summary      Close a maintenance record
+// @Description  Ends the maintenance of a device, which becomes available again. The final cost replaces the estimated one when informed
+// @Tags         maintenance
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string  true  "Maintenance record ID"
+// @Param        maintenance  body      dto.CloseMaintenanceRequest  false  "Resolution and final cost"
+// @Success      200          {object}  dto.MaintenanceResponse
+// @Failure      400          {object}  errors.DefaultErrorResult
+// @Failure      404          {object}  errors.DefaultErrorResult
+// @Failure      409          {object}  errors.DefaultErrorResult
+// @Failure      500          {object}  errors.DefaultErrorResult
+// @Router       /maintenance/{id}/close [post]
+func (h *maintenanceHandler) Close() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		recordID, err := validateAndParseMaintenanceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var req dto.CloseMaintenanceRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid close maintenance payload", err))
+		}
+
+		record, err := h.maintenanceService.Close(c.Request().Context(), recordID, req.Resolution, req.Cost)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toMaintenanceResponse(record))
+	}
+}
+
+func validateAndParseMaintenanceId(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform the maintenance record id", nil)
+	}
+	recordID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid maintenance record id format, must be an uuid", nil)
+	}
+	return recordID, nil
+}
+
+func toMaintenanceResponse(r entity.MaintenanceRecord) dto.MaintenanceResponse {
+	var expectedReturnDate *string
+	if r.ExpectedReturnDate != nil {
+		expectedReturnDate = lo.ToPtr(r.ExpectedReturnDate.Format(time.DateOnly))
+	}
+
+	return dto.MaintenanceResponse{
+		ID:                 r.ID.String(),
+		DeviceID:           r.DeviceID.String(),
+		Reason:             r.Reason,
+		Vendor:             r.Vendor,
+		Cost:               r.Cost,
+		ExpectedReturnDate: expectedReturnDate,
+		Resolution:         r.Resolution,
+		Overdue:            r.Overdue,
+		OpenedAt:           r.OpenedAt,
+		ClosedAt:           r.ClosedAt,
+	}
+}
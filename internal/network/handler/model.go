@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model"
+	"github.com/tiagos4ntos/device-manager/internal/domain/model/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+var validUUIDParam = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ListModelsQuerySchema declares the query string accepted by GET /models.
+var ListModelsQuerySchema = queryparam.NewSchema(
+	queryparam.Param{
+		Name:        "brand_id",
+		Description: "Brand ID: eg. 1b2c3d4e-5f60-4a71-8b92-a3b4c5d6e704",
+		Pattern:     validUUIDParam,
+		PatternHint: "must be an uuid",
+	},
+	queryparam.Param{
+		Name:        "name",
+		Description: "Model name, case insensitive: eg. pixel 7",
+		MaxLength:   100,
+	},
+)
+
+type ModelHandler interface {
+	List() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	Create() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+}
+
+type modelHandler struct {
+	modelService model.ModelService
+}
+
+func NewModelHandler(service model.ModelService) ModelHandler {
+	return &modelHandler{
+		modelService: service,
+	}
+}
+
+// List godoc
+// @Summary      List models
+// @Description  Returns the model catalogue ordered by brand and name
+// @Tags         models
+// @Produce      json
+// @Success      200  {array}   dto.ModelResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /models [get]
+func (h *modelHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values, err := ListModelsQuerySchema.Parse(c.Request().URL.RawQuery)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		filter := entity.ModelFilter{Name: values.String("name")}
+		if brandID := values.String("brand_id"); brandID != nil {
+			filter.BrandID = lo.ToPtr(uuid.MustParse(*brandID))
+		}
+
+		models, err := h.modelService.List(c.Request().Context(), filter)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		result := make([]dto.ModelResponse, 0)
+		for _, m := range models {
+			result = append(result, toModelResponse(m))
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetByID godoc
+// @Summary      Get model by ID
+// @Description  Returns a single model with its specs
+// @Tags         models
+// @Produce      json
+// @Param        id   path      string  true  "Model ID"
+// @Success      200  {object}  dto.ModelResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /models/{id} [get]
+func (h *modelHandler) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		modelID, err := validateAndParseModelId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		m, err := h.modelService.GetByID(c.Request().Context(), modelID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toModelResponse(m))
+	}
+}
+
+// Create godoc
+// @Summary      Create a model
+// @Description  Registers a device model of a brand in the catalogue, model names are unique within a brand regardless of case and spacing
+// @Tags         models
+// @Accept       json
+// @Produce      json
+// @Param        model  body      dto.ModelRequest  true  "Model payload"
+// @Success      201    {object}  dto.ModelResponse
+// @Failure      400    {object}  errors.DefaultErrorResult
+// @Failure      409    {object}  errors.DefaultErrorResult
+// @Failure      500    {object}  errors.DefaultErrorResult
+// @Router       /models [post]
+func (h *modelHandler) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req dto.ModelRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", nil))
+		}
+
+		m, err := toModel(req)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		created, err := h.modelService.Create(c.Request().Context(), m)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, toModelResponse(created))
+	}
+}
+
+// Update godoc
+// @Summary      Update a model
+// @Description  Replaces the specs of a model, when the model moves to another brand its devices follow it
+// @Tags         models
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true  "Model ID"
+// @Param        model  body      dto.ModelRequest  true  "Model payload"
+// @Success      200    {object}  dto.ModelResponse
+// @Failure      400    {object}  errors.DefaultErrorResult
+// @Failure      404    {object}  errors.DefaultErrorResult
+// @Failure      409    {object}  errors.DefaultErrorResult
+// @Failure      500    {object}  errors.DefaultErrorResult
+// @Router       /models/{id} [put]
+func (h *modelHandler) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		modelID, err := validateAndParseModelId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var req dto.ModelRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters", err))
+		}
+
+		m, err := toModel(req)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+		m.ID = modelID
+
+		updated, err := h.modelService.Update(c.Request().Context(), m)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toModelResponse(updated))
+	}
+}
+
+// Delete godoc
+// @Summary      Delete a model
+// @Description  Removes a model from the catalogue, only models without devices can be deleted
+// @Tags         models
+// @Produce      json
+// @Param        id   path      string  true  "Model ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      409  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /models/{id} [delete]
+func (h *modelHandler) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		modelID, err := validateAndParseModelId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		if err = h.modelService.Delete(c.Request().Context(), modelID); err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusNoContent, nil)
+	}
+}
+
+func validateAndParseModelId(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform the model id", nil)
+	}
+	modelID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid model id format, must be an uuid", nil)
+	}
+	return modelID, nil
+}
+
+func toModel(req dto.ModelRequest) (entity.Model, error) {
+	brandID, err := validateAndParseBrandId(req.BrandID)
+	if err != nil {
+		return entity.Model{}, err
+	}
+
+	return entity.Model{
+		BrandID:         brandID,
+		Name:            req.Name,
+		ReleaseYear:     req.ReleaseYear,
+		FormFactor:      entity.FormFactor(req.FormFactor),
+		OSFamily:        entity.OSFamily(req.OSFamily),
+		StorageVariants: req.StorageVariants,
+	}, nil
+}
+
+func toModelResponse(m entity.Model) dto.ModelResponse {
+	storageVariants := m.StorageVariants
+	if storageVariants == nil {
+		storageVariants = []int32{}
+	}
+
+	return dto.ModelResponse{
+		ID:              m.ID.String(),
+		BrandID:         m.BrandID.String(),
+		Brand:           m.Brand,
+		Name:            m.Name,
+		ReleaseYear:     m.ReleaseYear,
+		FormFactor:      m.FormFactor.String(),
+		OSFamily:        m.OSFamily.String(),
+		StorageVariants: storageVariants,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
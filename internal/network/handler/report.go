@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/domain/report"
+	"github.com/tiagos4ntos/device-manager/internal/domain/report/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+const (
+	defaultWarrantyWindowDays = 30
+	defaultUsefulLifeMonths   = 36
+)
+
+var validDaysParam = regexp.MustCompile(`^[1-9][0-9]{0,3}d$`)
+
+// WarrantyReportQuerySchema declares the query string accepted by GET /reports/warranty.
+var WarrantyReportQuerySchema = queryparam.NewSchema(
+	queryparam.Param{
+		Name:        "expiring_within",
+		Description: "Warranties ending in the next number of days, 30d when not informed: eg. 90d",
+		Pattern:     validDaysParam,
+		PatternHint: "must be a number of days like 30d",
+	},
+	queryparam.Param{
+		Name:        "include_expired",
+		Type:        queryparam.TypeBoolean,
+		Description: "Also list the warranties that already ended: eg. true",
+	},
+)
+
+// DepreciationReportQuerySchema declares the query string accepted by GET /reports/depreciation.
+var DepreciationReportQuerySchema = queryparam.NewSchema(
+	queryparam.Param{
+		Name:        "useful_life_months",
+		Type:        queryparam.TypeInteger,
+		Description: "Months until a device is fully depreciated, 36 when not informed: eg. 24",
+	},
+	queryparam.Param{
+		Name:        "as_of",
+		Type:        queryparam.TypeDateTime,
+		Description: "Date the book values are computed at, today when not informed: eg. 2025-12-31",
+	},
+)
+
+type ReportHandler interface {
+	Warranty() echo.HandlerFunc
+	Depreciation() echo.HandlerFunc
+}
+
+type reportHandler struct {
+	reportService report.ReportService
+}
+
+func NewReportHandler(reportService report.ReportService) ReportHandler {
+	return &reportHandler{
+		reportService: reportService,
+	}
+}
+
+// Warranty godoc
+// @Summary      List expiring warranties
+// @Description  Returns the devices whose warranty ends in the next days, the ones ending first come first
+// @Tags         reports
+// @Produce      json
+// @Success      200  {array}   dto.WarrantyEntryResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /reports/warranty [get]
+func (h *reportHandler) Warranty() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values, err := WarrantyReportQuerySchema.Parse(c.Request().URL.RawQuery)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		days := defaultWarrantyWindowDays
+		if within := values.String("expiring_within"); within != nil {
+			// the pattern guarantees a number followed by the d unit
+			days, _ = strconv.Atoi(strings.TrimSuffix(*within, "d"))
+		}
+
+		includeExpired := false
+		if expired := values.Bool("include_expired"); expired != nil {
+			includeExpired = *expired
+		}
+
+		entries, err := h.reportService.Warranty(c.Request().Context(), days, includeExpired)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		result := make([]dto.WarrantyEntryResponse, 0)
+		for _, e := range entries {
+			result = append(result, toWarrantyEntryResponse(e))
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// Depreciation godoc
+// @Summary      Device book values
+// @Description  Depreciates the purchase price of every device in a straight line to zero over its useful life, and totals the book values per brand and currency
+// @Tags         reports
+// @Produce      json
+// @Success      200  {object}  dto.DepreciationReportResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /reports/depreciation [get]
+func (h *reportHandler) Depreciation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values, err := DepreciationReportQuerySchema.Parse(c.Request().URL.RawQuery)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		usefulLifeMonths := defaultUsefulLifeMonths
+		if months := values.Int("useful_life_months"); months != nil {
+			usefulLifeMonths = *months
+		}
+
+		asOf := time.Now()
+		if date := values.Time("as_of"); date != nil {
+			asOf = *date
+		}
+
+		depreciation, err := h.reportService.Depreciation(c.Request().Context(), asOf, usefulLifeMonths)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toDepreciationReportResponse(depreciation))
+	}
+}
+
+func toWarrantyEntryResponse(e entity.WarrantyEntry) dto.WarrantyEntryResponse {
+	return dto.WarrantyEntryResponse{
+		DeviceID:       e.DeviceID.String(),
+		Name:           e.Name,
+		Brand:          e.Brand,
+		SerialNumber:   e.SerialNumber,
+		Supplier:       e.Supplier,
+		WarrantyEndsOn: e.WarrantyEndsOn.Format(time.DateOnly),
+		DaysLeft:       e.DaysLeft,
+	}
+}
+
+func toDepreciationReportResponse(r entity.DepreciationReport) dto.DepreciationReportResponse {
+	result := dto.DepreciationReportResponse{
+		AsOf:             r.AsOf.Format(time.DateOnly),
+		UsefulLifeMonths: r.UsefulLifeMonths,
+		Devices:          make([]dto.DeviceBookValueResponse, 0),
+		Brands:           make([]dto.BrandBookValueResponse, 0),
+	}
+
+	for _, d := range r.Devices {
+		result.Devices = append(result.Devices, dto.DeviceBookValueResponse{
+			DeviceID:      d.DeviceID.String(),
+			Name:          d.Name,
+			Brand:         d.Brand,
+			PurchaseDate:  d.PurchaseDate.Format(time.DateOnly),
+			PurchasePrice: d.PurchasePrice,
+			Currency:      d.Currency,
+			BookValue:     d.BookValue,
+		})
+	}
+	for _, b := range r.Brands {
+		result.Brands = append(result.Brands, dto.BrandBookValueResponse(b))
+	}
+
+	return result
+}
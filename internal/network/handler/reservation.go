@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation"
+	"github.com/tiagos4ntos/device-manager/internal/domain/reservation/entity"
+	"github.com/tiagos4ntos/device-manager/internal/network/dto"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+	"github.com/tiagos4ntos/device-manager/internal/network/queryparam"
+)
+
+var (
+	reservationFromParam = queryparam.Param{
+		Name:        "from",
+		Type:        queryparam.TypeDateTime,
+		Description: "Reservations going on at or after an RFC 3339 timestamp or date: eg. 2025-09-01",
+	}
+	reservationToParam = queryparam.Param{
+		Name:        "to",
+		Type:        queryparam.TypeDateTime,
+		Description: "Reservations starting before an RFC 3339 timestamp or date: eg. 2025-09-08",
+	}
+)
+
+// ListDeviceReservationsQuerySchema declares the query string accepted by GET /devices/{id}/reservations.
+var ListDeviceReservationsQuerySchema = queryparam.NewSchema(
+	reservationFromParam,
+	reservationToParam,
+)
+
+// ListReservationsQuerySchema declares the query string accepted by GET /reservations.
+var ListReservationsQuerySchema = queryparam.NewSchema(
+	queryparam.Param{
+		Name:        "reserved_by",
+		Description: "Who booked the devices, case insensitive: eg. ana lima",
+		Required:    true,
+		MaxLength:   100,
+	},
+	reservationFromParam,
+	reservationToParam,
+)
+
+type ReservationHandler interface {
+	Create() echo.HandlerFunc
+	ListByDevice() echo.HandlerFunc
+	List() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	CheckOut() echo.HandlerFunc
+	Cancel() echo.HandlerFunc
+}
+
+type reservationHandler struct {
+	reservationService reservation.ReservationService
+}
+
+func NewReservationHandler(reservationService reservation.ReservationService) ReservationHandler {
+	return &reservationHandler{
+		reservationService: reservationService,
+	}
+}
+
+// Create godoc
+// @Summary      Reserve a device
+// @Description  Books a device for a period, the periods booked for a device never overlap and inactive or retired devices cannot be booked
+// @Tags         reservations
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string  true  "Device ID"
+// @Param        reservation  body      dto.ReservationRequest  true  "Reservation payload"
+// @Success      201          {object}  dto.ReservationResponse
+// @Failure      400          {object}  errors.DefaultErrorResult
+// @Failure      404          {object}  errors.DefaultErrorResult
+// @Failure      409          {object}  errors.DefaultErrorResult
+// @Failure      500          {object}  errors.DefaultErrorResult
+// @Router       /devices/{id}/reservations [post]
+func (h *reservationHandler) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		deviceID, err := validateAndParseDeviceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		var req dto.ReservationRequest
+		if err := c.Bind(&req); err != nil {
+			return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform all required parameters, starts_at and ends_at as RFC 3339 timestamps", err))
+		}
+
+		created, err := h.reservationService.Create(c.Request().Context(), entity.Reservation{
+			DeviceID:   deviceID,
+			ReservedBy: req.ReservedBy,
+			StartsAt:   req.StartsAt,
+			EndsAt:     req.EndsAt,
+			Note:       req.Note,
+		})
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, toReservationResponse(created))
+	}
+}
+
+// ListByDevice godoc
+// @Summary      List the reservations of a device
+// @Description  Returns the reservations of a device ordered by start, optionally only those overlapping a period
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      string  true  "Device ID"
+// @Success      200  {array}   dto.ReservationResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /devices/{id}/reservations [get]
+func (h *reservationHandler) ListByDevice() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		deviceID, err := validateAndParseDeviceId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		values, err := ListDeviceReservationsQuerySchema.Parse(c.Request().URL.RawQuery)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return h.list(c, entity.ReservationFilter{
+			DeviceID: &deviceID,
+			From:     values.Time("from"),
+			To:       values.Time("to"),
+		})
+	}
+}
+
+// List godoc
+// @Summary      List the reservations of a user
+// @Description  Returns the reservations made by someone ordered by start, optionally only those overlapping a period
+// @Tags         reservations
+// @Produce      json
+// @Success      200  {array}   dto.ReservationResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /reservations [get]
+func (h *reservationHandler) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values, err := ListReservationsQuerySchema.Parse(c.Request().URL.RawQuery)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return h.list(c, entity.ReservationFilter{
+			ReservedBy: values.String("reserved_by"),
+			From:       values.Time("from"),
+			To:         values.Time("to"),
+		})
+	}
+}
+
+func (h *reservationHandler) list(c echo.Context, filter entity.ReservationFilter) error {
+	reservations, err := h.reservationService.List(c.Request().Context(), filter)
+	if err != nil {
+		return errorhandler.Handle(c, err)
+	}
+
+	result := make([]dto.ReservationResponse, 0)
+	for _, r := range reservations {
+		result = append(result, toReservationResponse(r))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetByID godoc
+// @Summary      Get reservation by ID
+// @Description  Returns a single reservation
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      string  true  "Reservation ID"
+// @Success      200  {object}  dto.ReservationResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /reservations/{id} [get]
+func (h *reservationHandler) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		reservationID, err := validateAndParseReservationId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		r, err := h.reservationService.GetByID(c.Request().Context(), reservationID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toReservationResponse(r))
+	}
+}
+
+// CheckOut godoc
+// @Summary      Check out a reservation
+// @Description  Hands the reserved device over during the reservation period, the device goes from available to in-use
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      string  true  "Reservation ID"
+// @Success      200  {object}  dto.ReservationResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      409  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /reservations/{id}/checkout [post]
+func (h *reservationHandler) CheckOut() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		reservationID, err := validateAndParseReservationId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		r, err := h.reservationService.CheckOut(c.Request().Context(), reservationID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toReservationResponse(r))
+	}
+}
+
+// Cancel godoc
+// @Summary      Cancel a reservation
+// @Description  Cancels a booked reservation, freeing its period for other bookings
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      string  true  "Reservation ID"
+// @Success      200  {object}  dto.ReservationResponse
+// @Failure      400  {object}  errors.DefaultErrorResult
+// @Failure      404  {object}  errors.DefaultErrorResult
+// @Failure      409  {object}  errors.DefaultErrorResult
+// @Failure      500  {object}  errors.DefaultErrorResult
+// @Router       /reservations/{id} [delete]
+func (h *reservationHandler) Cancel() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		reservationID, err := validateAndParseReservationId(c.Param("id"))
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		r, err := h.reservationService.Cancel(c.Request().Context(), reservationID)
+		if err != nil {
+			return errorhandler.Handle(c, err)
+		}
+
+		return c.JSON(http.StatusOK, toReservationResponse(r))
+	}
+}
+
+func validateAndParseReservationId(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "you must inform the reservation id", nil)
+	}
+	reservationID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid reservation id format, must be an uuid", nil)
+	}
+	return reservationID, nil
+}
+
+func toReservationResponse(r entity.Reservation) dto.ReservationResponse {
+	return dto.ReservationResponse{
+		ID:           r.ID.String(),
+		DeviceID:     r.DeviceID.String(),
+		ReservedBy:   r.ReservedBy,
+		StartsAt:     r.StartsAt,
+		EndsAt:       r.EndsAt,
+		Note:         r.Note,
+		Status:       r.Status.String(),
+		CheckedOutAt: r.CheckedOutAt,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
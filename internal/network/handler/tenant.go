@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/domain/tenant"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+)
+
+// TenantHeader identifies the tenant a request is made on behalf of, its
+// attribute schema is applied to the devices of the request.
+const TenantHeader = "X-Tenant-ID"
+
+// tenantContext returns a context carrying the tenant informed on the request, if any.
+func tenantContext(c echo.Context) (context.Context, error) {
+	tenantID := c.Request().Header.Get(TenantHeader)
+	if tenantID == "" {
+		return c.Request().Context(), nil
+	}
+
+	if !tenant.ValidID(tenantID) {
+		return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid "+TenantHeader+" header, must start with a letter or digit and contain only letters, digits and _ . -, up to 64 characters", nil)
+	}
+
+	return tenant.WithID(c.Request().Context(), tenantID), nil
+}
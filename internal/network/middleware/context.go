@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/tiagos4ntos/device-manager/internal/config"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/requestid"
+)
+
+// RequestID identifies each request by the X-Request-ID header, generating one
+// when missing, and carries the ID on the request context so it reaches the
+// services and the logs.
+func RequestID() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, id string) {
+			req := c.Request()
+			c.SetRequest(req.WithContext(requestid.WithID(req.Context(), id)))
+		},
+	})
+}
+
+// OperationTimeout bounds each database operation made on behalf of a request
+// to the database operation timeout, on top of the request deadline.
+func OperationTimeout(cfg *config.Config) echo.MiddlewareFunc {
+	timeout := time.Duration(cfg.DatabaseOperationTimeout) * time.Second
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(database.WithOperationTimeout(req.Context(), timeout)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/config"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+	"github.com/tiagos4ntos/device-manager/internal/domain/requestid"
+)
+
+func Test_RequestID(t *testing.T) {
+	var id string
+	e := echo.New()
+	e.Use(RequestID())
+	e.GET("/", func(c echo.Context) error {
+		id, _ = requestid.IDFromContext(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	t.Run("Informed Request ID Case", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderXRequestID, "f3a1")
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, "f3a1", id)
+		assert.Equal(t, "f3a1", rec.Header().Get(echo.HeaderXRequestID))
+	})
+
+	t.Run("Generated Request ID Case", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.NotEmpty(t, id)
+		assert.Equal(t, id, rec.Header().Get(echo.HeaderXRequestID))
+	})
+}
+
+func Test_OperationTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      int
+		wantDeadline bool
+	}{
+		{name: "Operation Timeout Case", timeout: 5, wantDeadline: true},
+		{name: "Without Operation Timeout Case", timeout: 0, wantDeadline: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline time.Time
+			var hasDeadline bool
+			e := echo.New()
+			e.Use(OperationTimeout(&config.Config{DatabaseOperationTimeout: tt.timeout}))
+			e.GET("/", func(c echo.Context) error {
+				ctx, cancel := database.OperationContext(c.Request().Context())
+				defer cancel()
+				deadline, hasDeadline = ctx.Deadline()
+				return c.NoContent(http.StatusNoContent)
+			})
+
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.wantDeadline, hasDeadline)
+			if tt.wantDeadline {
+				assert.WithinDuration(t, time.Now().Add(5*time.Second), deadline, time.Second)
+			}
+		})
+	}
+}
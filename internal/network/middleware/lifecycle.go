@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/lifecycle"
+)
+
+// TrackRequests counts the requests in flight on requests.
+func TrackRequests(requests *lifecycle.Requests) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			defer requests.Begin()()
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/config"
+	"github.com/tiagos4ntos/device-manager/internal/network/ratelimit"
+)
+
+// RateLimit returns the builder of the middleware limiting the requests of the
+// clients as told by the configuration, counting them on store. The API
+// documentation and the health probes are not limited.
+func RateLimit(store ratelimit.Store) func(cfg *config.Config) echo.MiddlewareFunc {
+	return func(cfg *config.Config) echo.MiddlewareFunc {
+		return ratelimit.Middleware(store, ratelimit.Config{
+			Skipper: func(c echo.Context) bool {
+				path := c.Request().URL.Path
+				return strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/health/")
+			},
+			Read:    ratelimit.Limit{PerMinute: cfg.RateLimitReadPerMinute, Burst: cfg.RateLimitReadBurst},
+			Write:   ratelimit.Limit{PerMinute: cfg.RateLimitWritePerMinute, Burst: cfg.RateLimitWriteBurst},
+			APIKeys: cfg.RateLimitAPIKeys,
+		})
+	}
+}
+
+// IPExtractor returns how the client IP is told: by the X-Forwarded-For
+// header set by the trusted proxies, or by the connection when none is.
+func IPExtractor(cfg *config.Config) echo.IPExtractor {
+	if len(cfg.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range cfg.TrustedProxies {
+		// validated by the configuration
+		_, ipRange, _ := net.ParseCIDR(proxy)
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
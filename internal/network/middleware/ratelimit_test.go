@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/config"
+	"github.com/tiagos4ntos/device-manager/internal/network/ratelimit"
+)
+
+func Test_RateLimit(t *testing.T) {
+	cfg := &config.Config{RateLimitReadPerMinute: 60, RateLimitReadBurst: 1}
+	mw := RateLimit(ratelimit.NewMemoryStore())(cfg)
+
+	send := func(path string) int {
+		e := echo.New()
+		e.Use(mw)
+		e.GET("/*", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send("/devices"))
+	assert.Equal(t, http.StatusTooManyRequests, send("/devices"))
+	assert.Equal(t, http.StatusOK, send("/api/index.html"))
+	assert.Equal(t, http.StatusOK, send("/health/ready"))
+}
+
+func Test_IPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		wantIP         string
+	}{
+		{
+			name:       "IP From Connection Case",
+			remoteAddr: "10.0.0.5:4000",
+			wantIP:     "10.0.0.5",
+		},
+		{
+			name:           "IP From Trusted Proxy Case",
+			trustedProxies: []string{"10.0.0.0/24"},
+			remoteAddr:     "10.0.0.5:4000",
+			wantIP:         "198.51.100.7",
+		},
+		{
+			name:           "IP From Untrusted Proxy Case",
+			trustedProxies: []string{"10.0.1.0/24"},
+			remoteAddr:     "10.0.0.5:4000",
+			wantIP:         "10.0.0.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/devices", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.7")
+
+			ip := IPExtractor(&config.Config{TrustedProxies: tt.trustedProxies})(req)
+
+			assert.Equal(t, tt.wantIP, ip)
+		})
+	}
+}
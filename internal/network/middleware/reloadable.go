@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/config"
+)
+
+// Reloadable applies the middleware built from the configuration in effect,
+// building it again whenever the configuration is reloaded.
+func Reloadable(watcher *config.Watcher, build func(cfg *config.Config) echo.MiddlewareFunc) echo.MiddlewareFunc {
+	type built struct {
+		cfg        *config.Config
+		middleware echo.MiddlewareFunc
+	}
+	var current atomic.Pointer[built]
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			cfg := watcher.Config()
+			b := current.Load()
+			if b == nil || b.cfg != cfg {
+				b = &built{cfg: cfg, middleware: build(cfg)}
+				current.Store(b)
+			}
+			return b.middleware(next)(c)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/config"
+)
+
+func Test_Reloadable(t *testing.T) {
+	t.Chdir(t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("database_pass: secret\ncors_allow_origins: https://a.example.com\n"), 0o600))
+
+	args := []string{"--config", path}
+	cfg, err := config.Load(args)
+	assert.NoError(t, err)
+	watcher := config.NewWatcher(cfg, args)
+
+	builds := 0
+	e := echo.New()
+	e.Use(Reloadable(watcher, func(cfg *config.Config) echo.MiddlewareFunc {
+		builds++
+		origin := cfg.CORSAllowOrigins[0]
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				c.Response().Header().Set("X-Origin", origin)
+				return next(c)
+			}
+		}
+	}))
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	serve := func() string {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec.Header().Get("X-Origin")
+	}
+
+	assert.Equal(t, "https://a.example.com", serve())
+	assert.Equal(t, "https://a.example.com", serve())
+	assert.Equal(t, 1, builds)
+
+	assert.NoError(t, os.WriteFile(path, []byte("database_pass: secret\ncors_allow_origins: https://b.example.com\n"), 0o600))
+	assert.NoError(t, watcher.Reload())
+
+	assert.Equal(t, "https://b.example.com", serve())
+	assert.Equal(t, 2, builds)
+}
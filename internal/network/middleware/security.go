@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/tiagos4ntos/device-manager/internal/config"
+)
+
+// CORS answers the preflight requests of the browsers and sets the CORS headers
+// of the responses, as told by cfg.
+func CORS(cfg *config.Config) echo.MiddlewareFunc {
+	return echomiddleware.CORSWithConfig(echomiddleware.CORSConfig{
+		AllowOrigins:     cfg.CORSAllowOrigins,
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:     cfg.CORSAllowHeaders,
+		ExposeHeaders:    cfg.CORSExposeHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	})
+}
+
+// Secure sets the security headers of the responses, Strict-Transport-Security
+// being sent on the HTTPS ones only.
+func Secure(cfg *config.Config) echo.MiddlewareFunc {
+	secure := echomiddleware.DefaultSecureConfig
+	secure.HSTSMaxAge = cfg.HSTSMaxAge
+	secure.HSTSExcludeSubdomains = !cfg.HSTSIncludeSubdomains
+	secure.HSTSPreloadEnabled = cfg.HSTSPreload
+	secure.ContentSecurityPolicy = cfg.ContentSecurityPolicy
+	return echomiddleware.SecureWithConfig(secure)
+}
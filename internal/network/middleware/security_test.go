@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/config"
+)
+
+func serve(mw echo.MiddlewareFunc, req *http.Request) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.Use(mw)
+	e.Any("/devices", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_CORS(t *testing.T) {
+	cfg := &config.Config{
+		CORSAllowOrigins:     []string{"https://app.example.com"},
+		CORSAllowHeaders:     []string{"Content-Type", "X-Tenant-ID"},
+		CORSExposeHeaders:    []string{"Retry-After"},
+		CORSAllowCredentials: true,
+		CORSMaxAge:           600,
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		origin      string
+		wantHeaders map[string]string
+	}{
+		{
+			name:   "CORS Preflight Allowed Origin Case",
+			method: http.MethodOptions,
+			origin: "https://app.example.com",
+			wantHeaders: map[string]string{
+				echo.HeaderAccessControlAllowOrigin:      "https://app.example.com",
+				echo.HeaderAccessControlAllowHeaders:     "Content-Type,X-Tenant-ID",
+				echo.HeaderAccessControlAllowCredentials: "true",
+				echo.HeaderAccessControlMaxAge:           "600",
+			},
+		},
+		{
+			name:   "CORS Request Allowed Origin Case",
+			method: http.MethodGet,
+			origin: "https://app.example.com",
+			wantHeaders: map[string]string{
+				echo.HeaderAccessControlAllowOrigin:   "https://app.example.com",
+				echo.HeaderAccessControlExposeHeaders: "Retry-After",
+			},
+		},
+		{
+			name:   "CORS Request Unknown Origin Case",
+			method: http.MethodGet,
+			origin: "https://evil.example.com",
+			wantHeaders: map[string]string{
+				echo.HeaderAccessControlAllowOrigin: "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/devices", nil)
+			req.Header.Set(echo.HeaderOrigin, tt.origin)
+			if tt.method == http.MethodOptions {
+				req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+			}
+
+			rec := serve(CORS(cfg), req)
+
+			for header, want := range tt.wantHeaders {
+				assert.Equal(t, want, rec.Header().Get(header), header)
+			}
+		})
+	}
+}
+
+func Test_Secure(t *testing.T) {
+	cfg := &config.Config{
+		HSTSMaxAge:            31536000,
+		HSTSIncludeSubdomains: true,
+		HSTSPreload:           true,
+		ContentSecurityPolicy: "default-src 'self'",
+	}
+
+	t.Run("Secure HTTPS Request Case", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/devices", nil)
+		req.TLS = &tls.ConnectionState{}
+
+		rec := serve(Secure(cfg), req)
+
+		assert.Equal(t, "max-age=31536000; includeSubdomains; preload", rec.Header().Get(echo.HeaderStrictTransportSecurity))
+		assert.Equal(t, "default-src 'self'", rec.Header().Get(echo.HeaderContentSecurityPolicy))
+		assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+	})
+
+	t.Run("Secure HTTP Request Case", func(t *testing.T) {
+		rec := serve(Secure(cfg), httptest.NewRequest(http.MethodGet, "/devices", nil))
+
+		assert.Empty(t, rec.Header().Get(echo.HeaderStrictTransportSecurity))
+		assert.Equal(t, "default-src 'self'", rec.Header().Get(echo.HeaderContentSecurityPolicy))
+	})
+
+	t.Run("Secure Without HSTS Case", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/devices", nil)
+		req.TLS = &tls.ConnectionState{}
+
+		rec := serve(Secure(&config.Config{}), req)
+
+		assert.Empty(t, rec.Header().Get(echo.HeaderStrictTransportSecurity))
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentSecurityPolicy))
+	})
+}
@@ -0,0 +1,246 @@
+package queryparam
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+)
+
+type Type string
+
+const (
+	TypeString   Type = "string"
+	TypeInteger  Type = "integer"
+	TypeBoolean  Type = "boolean"
+	TypeDateTime Type = "date-time"
+)
+
+// inOperator may prefix the values of the parameters declared with InOperator,
+// eg. state=in,available,in-use
+const inOperator = "in,"
+
+// Param declares a query string parameter, the same declaration drives parsing,
+// validation and the Swagger documentation of the endpoint.
+type Param struct {
+	Name        string
+	Type        Type
+	Description string
+	Required    bool
+	// Multi accepts several values, either repeating the parameter or separating
+	// them by commas.
+	Multi bool
+	// InOperator lets the values of a Multi parameter be preceded by the "in"
+	// operator, for the others "in" is a value like any other.
+	InOperator bool
+	// Prefix makes Name a prefix matching a family of parameters, eg. "attr."
+	// matches attr.carrier and attr.colour, each informed at most once.
+	Prefix bool
+	// Enum lists the allowed values, when empty any value of the type is accepted.
+	Enum []string
+	// Pattern every value must match, PatternHint explains it on validation errors.
+	Pattern     *regexp.Regexp
+	PatternHint string
+	MaxLength   int
+}
+
+type Schema struct {
+	params []Param
+	byName map[string]Param
+}
+
+func NewSchema(params ...Param) *Schema {
+	byName := make(map[string]Param, len(params))
+	for _, p := range params {
+		if p.Type == "" {
+			p.Type = TypeString
+		}
+		byName[p.Name] = p
+	}
+
+	return &Schema{
+		params: params,
+		byName: byName,
+	}
+}
+
+// Params returns the declared parameters in declaration order.
+func (s *Schema) Params() []Param {
+	params := make([]Param, 0, len(s.params))
+	for _, p := range s.params {
+		params = append(params, s.byName[p.Name])
+	}
+	return params
+}
+
+// Parse validates a raw query string against the schema. Parameters are checked
+// in declaration order, so the same invalid input always reports the same error.
+func (s *Schema) Parse(rawQuery string) (Values, error) {
+	values := Values{
+		values:   map[string][]string{},
+		times:    map[string]time.Time{},
+		prefixed: map[string]map[string]string{},
+	}
+
+	parsedQuery, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return values, errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid query string: %v", err.Error()), nil)
+	}
+
+	names := make([]string, 0, len(parsedQuery))
+	for name := range parsedQuery {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if p, ok := s.byName[name]; (!ok || p.Prefix) && s.prefixParam(name) == nil {
+			return values, errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid parameter: %s", name), nil)
+		}
+	}
+
+	for _, p := range s.Params() {
+		if p.Prefix {
+			matched, err := p.parsePrefixed(names, parsedQuery)
+			if err != nil {
+				return values, err
+			}
+			values.prefixed[p.Name] = matched
+			continue
+		}
+
+		rawValues, ok := parsedQuery[p.Name]
+		if !ok {
+			if p.Required {
+				return values, errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("parameter %s is required", p.Name), nil)
+			}
+			continue
+		}
+
+		parsed, err := p.parse(rawValues)
+		if err != nil {
+			return values, err
+		}
+
+		for _, value := range parsed {
+			if p.Type == TypeDateTime {
+				values.times[p.Name], _ = parseDateTime(value)
+			}
+		}
+		values.values[p.Name] = parsed
+	}
+
+	return values, nil
+}
+
+// prefixParam returns the prefix parameter matching name, if any. The name must
+// be longer than the prefix, a bare prefix is not a parameter.
+func (s *Schema) prefixParam(name string) *Param {
+	for _, p := range s.Params() {
+		if p.Prefix && len(name) > len(p.Name) && strings.HasPrefix(name, p.Name) {
+			return &p
+		}
+	}
+	return nil
+}
+
+// parsePrefixed validates every parameter of the family, keyed by the name
+// without the prefix. Errors report the full parameter name.
+func (p Param) parsePrefixed(names []string, parsedQuery url.Values) (map[string]string, error) {
+	matched := map[string]string{}
+
+	for _, name := range names {
+		if len(name) <= len(p.Name) || !strings.HasPrefix(name, p.Name) {
+			continue
+		}
+
+		member := p
+		member.Name = name
+		member.Prefix = false
+
+		parsed, err := member.parse(parsedQuery[name])
+		if err != nil {
+			return nil, err
+		}
+		matched[strings.TrimPrefix(name, p.Name)] = parsed[0]
+	}
+
+	if p.Required && len(matched) == 0 {
+		return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("parameter %s<name> is required", p.Name), nil)
+	}
+
+	return matched, nil
+}
+
+func (p Param) parse(rawValues []string) ([]string, error) {
+	if !p.Multi && len(rawValues) > 1 {
+		return nil, errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("parameter %s must be informed only once", p.Name), nil)
+	}
+
+	values := rawValues
+	if p.Multi {
+		values = make([]string, 0, len(rawValues))
+		for _, raw := range rawValues {
+			if p.InOperator {
+				raw = strings.TrimPrefix(raw, inOperator)
+			}
+			values = append(values, strings.Split(raw, ",")...)
+		}
+	}
+
+	for _, value := range values {
+		if err := p.validate(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (p Param) validate(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("parameter %s must not be empty", p.Name), nil)
+	}
+
+	if len(p.Enum) > 0 && !slices.Contains(p.Enum, value) {
+		return errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid %s value %q, must be one of: %s", p.Name, value, strings.Join(p.Enum, ", ")), nil)
+	}
+
+	if p.MaxLength > 0 && len(value) > p.MaxLength {
+		return errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid %s value, must have at most %d characters", p.Name, p.MaxLength), nil)
+	}
+
+	if p.Pattern != nil && !p.Pattern.MatchString(value) {
+		return errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid %s value %q, %s", p.Name, value, p.PatternHint), nil)
+	}
+
+	switch p.Type {
+	case TypeInteger:
+		if _, err := strconv.Atoi(value); err != nil {
+			return errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid %s value %q, must be an integer", p.Name, value), nil)
+		}
+	case TypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid %s value %q, must be true or false", p.Name, value), nil)
+		}
+	case TypeDateTime:
+		if _, err := parseDateTime(value); err != nil {
+			return errorhandler.NewApiError(errorhandler.ErrInvalid, fmt.Sprintf("invalid %s value %q, must be an RFC 3339 timestamp (2025-08-31T21:00:00Z) or a date (2025-08-31)", p.Name, value), nil)
+		}
+	}
+
+	return nil
+}
+
+// parseDateTime accepts RFC 3339 timestamps or plain dates, which are taken as midnight UTC.
+func parseDateTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
@@ -0,0 +1,184 @@
+package queryparam
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+)
+
+func makeTestSchema() *Schema {
+	return NewSchema(
+		Param{Name: "brand", Pattern: regexp.MustCompile(`^[a-z]+$`), PatternHint: "must contain only lowercase letters", MaxLength: 5},
+		Param{Name: "state", Multi: true, InOperator: true, Enum: []string{"available", "in-use", "inactive"}},
+		Param{Name: "since", Type: TypeDateTime},
+		Param{Name: "limit", Type: TypeInteger},
+		Param{Name: "deleted", Type: TypeBoolean},
+		Param{Name: "tenant", Required: true},
+		Param{Name: "attr.", Prefix: true, MaxLength: 5},
+	)
+}
+
+func Test_Schema_Parse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		rawQuery  string
+		wantedErr error
+		check     func(t *testing.T, values Values)
+	}{
+		{
+			name:     "Parse Every Type Success Case",
+			rawQuery: "tenant=acme&brand=sony&state=available&since=2025-08-31&limit=10&deleted=true",
+			check: func(t *testing.T, values Values) {
+				assert.Equal(t, lo.ToPtr("sony"), values.String("brand"))
+				assert.Equal(t, []string{"available"}, values.List("state"))
+				assert.Equal(t, lo.ToPtr(lo.Must(time.Parse(time.DateOnly, "2025-08-31"))), values.Time("since"))
+				assert.Equal(t, lo.ToPtr(10), values.Int("limit"))
+				assert.Equal(t, lo.ToPtr(true), values.Bool("deleted"))
+				assert.Nil(t, values.String("missing"))
+			},
+		},
+		{
+			name:     "Parse Multi Value with In Operator Success Case",
+			rawQuery: "tenant=acme&state=in,available,in-use",
+			check: func(t *testing.T, values Values) {
+				assert.Equal(t, []string{"available", "in-use"}, values.List("state"))
+			},
+		},
+		{
+			name:     "Parse Repeated Multi Value Success Case",
+			rawQuery: "tenant=acme&state=available&state=inactive",
+			check: func(t *testing.T, values Values) {
+				assert.Equal(t, []string{"available", "inactive"}, values.List("state"))
+			},
+		},
+		{
+			name:     "Parse Prefix Parameters Success Case",
+			rawQuery: "tenant=acme&attr.carrier=vf&attr.colour=red",
+			check: func(t *testing.T, values Values) {
+				assert.Equal(t, map[string]string{"carrier": "vf", "colour": "red"}, values.Prefixed("attr."))
+			},
+		},
+		{
+			name:      "Parse Fails on Bare Prefix",
+			rawQuery:  "tenant=acme&attr.=red",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid parameter: attr.", nil),
+		},
+		{
+			name:      "Parse Fails on Repeated Prefix Parameter",
+			rawQuery:  "tenant=acme&attr.colour=red&attr.colour=blue",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, "parameter attr.colour must be informed only once", nil),
+		},
+		{
+			name:      "Parse Fails on Prefix Parameter Max Length",
+			rawQuery:  "tenant=acme&attr.carrier=vodafone",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid attr.carrier value, must have at most 5 characters", nil),
+		},
+		{
+			name:      "Parse Fails on Unknown Parameter",
+			rawQuery:  "tenant=acme&xbrand=sony",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid parameter: xbrand", nil),
+		},
+		{
+			name:      "Parse Fails on Missing Required Parameter",
+			rawQuery:  "brand=sony",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, "parameter tenant is required", nil),
+		},
+		{
+			name:      "Parse Fails on Empty Value",
+			rawQuery:  "tenant=acme&brand=",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, "parameter brand must not be empty", nil),
+		},
+		{
+			name:      "Parse Fails on Repeated Single Value",
+			rawQuery:  "tenant=acme&brand=sony&brand=lg",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, "parameter brand must be informed only once", nil),
+		},
+		{
+			name:      "Parse Fails on Value Outside Enum",
+			rawQuery:  "tenant=acme&state=in,available,broken",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, `invalid state value "broken", must be one of: available, in-use, inactive`, nil),
+		},
+		{
+			name:      "Parse Fails on Pattern Mismatch",
+			rawQuery:  "tenant=acme&brand=Sony",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, `invalid brand value "Sony", must contain only lowercase letters`, nil),
+		},
+		{
+			name:      "Parse Fails on Max Length",
+			rawQuery:  "tenant=acme&brand=motorola",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, "invalid brand value, must have at most 5 characters", nil),
+		},
+		{
+			name:      "Parse Fails on Invalid Date",
+			rawQuery:  "tenant=acme&since=yesterday",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, `invalid since value "yesterday", must be an RFC 3339 timestamp (2025-08-31T21:00:00Z) or a date (2025-08-31)`, nil),
+		},
+		{
+			name:      "Parse Fails on Invalid Integer",
+			rawQuery:  "tenant=acme&limit=ten",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, `invalid limit value "ten", must be an integer`, nil),
+		},
+		{
+			name:      "Parse Fails on Invalid Boolean",
+			rawQuery:  "tenant=acme&deleted=maybe",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, `invalid deleted value "maybe", must be true or false`, nil),
+		},
+		{
+			name:      "Parse Fails on Malformed Query String",
+			rawQuery:  "tenant=%zz",
+			wantedErr: errorhandler.NewApiError(errorhandler.ErrInvalid, `invalid query string: invalid URL escape "%zz"`, nil),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := makeTestSchema().Parse(tt.rawQuery)
+
+			assert.Equal(t, tt.wantedErr, err)
+			if tt.check != nil {
+				tt.check(t, values)
+			}
+		})
+	}
+}
+
+func Test_Schema_Parse_Without_In_Operator(t *testing.T) {
+	schema := NewSchema(
+		Param{Name: "tag", Multi: true},
+		Param{Name: "sort", Multi: true, Enum: []string{"name", "-name"}},
+	)
+
+	values, err := schema.Parse("tag=in,qa")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"in", "qa"}, values.List("tag"))
+
+	_, err = schema.Parse("sort=in,name")
+	assert.Equal(t, errorhandler.NewApiError(errorhandler.ErrInvalid, `invalid sort value "in", must be one of: name, -name`, nil), err)
+}
+
+func Test_Schema_Swagger_Parameters(t *testing.T) {
+	assert := assert.New(t)
+
+	parameters := makeTestSchema().SwaggerParameters()
+
+	assert.Len(parameters, 6)
+
+	assert.Equal("brand", parameters[0].Name)
+	assert.Equal("query", parameters[0].In)
+	assert.Equal("string", parameters[0].Type)
+	assert.Equal(lo.ToPtr(int64(5)), parameters[0].MaxLength)
+
+	assert.Equal("array", parameters[1].Type)
+	assert.Equal("csv", parameters[1].CollectionFormat)
+	assert.Equal([]interface{}{"available", "in-use", "inactive"}, parameters[1].Items.Enum)
+
+	assert.Equal("string", parameters[2].Type)
+	assert.Equal("date-time", parameters[2].Format)
+	assert.Equal("integer", parameters[3].Type)
+	assert.Equal("boolean", parameters[4].Type)
+	assert.True(parameters[5].Required)
+}
@@ -0,0 +1,66 @@
+package queryparam
+
+import (
+	"github.com/go-openapi/spec"
+)
+
+// SwaggerParameters describes the schema as Swagger 2.0 query parameters.
+// Prefix parameters cannot be expressed in Swagger 2.0, they are left to the
+// description of the operation.
+func (s *Schema) SwaggerParameters() []spec.Parameter {
+	parameters := make([]spec.Parameter, 0, len(s.params))
+
+	for _, p := range s.Params() {
+		if p.Prefix {
+			continue
+		}
+
+		typeName, format := p.Type.swaggerType()
+		parameter := spec.QueryParam(p.Name).WithDescription(p.Description)
+
+		if p.Multi {
+			items := spec.NewItems().Typed(typeName, format)
+			if len(p.Enum) > 0 {
+				items.WithEnum(toInterfaces(p.Enum)...)
+			}
+			parameter.CollectionOf(items, "csv")
+		} else {
+			parameter.Typed(typeName, format)
+			if len(p.Enum) > 0 {
+				parameter.WithEnum(toInterfaces(p.Enum)...)
+			}
+			if p.MaxLength > 0 {
+				parameter.WithMaxLength(int64(p.MaxLength))
+			}
+		}
+
+		if p.Required {
+			parameter.AsRequired()
+		}
+
+		parameters = append(parameters, *parameter)
+	}
+
+	return parameters
+}
+
+func (t Type) swaggerType() (string, string) {
+	switch t {
+	case TypeInteger:
+		return "integer", ""
+	case TypeBoolean:
+		return "boolean", ""
+	case TypeDateTime:
+		return "string", "date-time"
+	default:
+		return "string", ""
+	}
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
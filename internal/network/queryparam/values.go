@@ -0,0 +1,71 @@
+package queryparam
+
+import (
+	"strconv"
+	"time"
+)
+
+// Values holds the query parameters accepted by a Schema, already validated
+// against their declared type.
+type Values struct {
+	values   map[string][]string
+	times    map[string]time.Time
+	prefixed map[string]map[string]string
+}
+
+func (v Values) Has(name string) bool {
+	_, ok := v.values[name]
+	return ok
+}
+
+// Get returns the first value of the parameter, or an empty string when it was not informed.
+func (v Values) Get(name string) string {
+	if values := v.values[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// List returns every value of a multi-value parameter in the order they were informed.
+func (v Values) List(name string) []string {
+	return v.values[name]
+}
+
+// String returns a pointer to the value of the parameter, or nil when it was not informed.
+func (v Values) String(name string) *string {
+	if !v.Has(name) {
+		return nil
+	}
+	value := v.Get(name)
+	return &value
+}
+
+// Prefixed returns the values of a prefix parameter family keyed by the name
+// without the prefix, eg. attr.carrier=vodafone is returned as carrier: vodafone.
+func (v Values) Prefixed(prefix string) map[string]string {
+	return v.prefixed[prefix]
+}
+
+func (v Values) Time(name string) *time.Time {
+	value, ok := v.times[name]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+func (v Values) Int(name string) *int {
+	if !v.Has(name) {
+		return nil
+	}
+	value, _ := strconv.Atoi(v.Get(name))
+	return &value
+}
+
+func (v Values) Bool(name string) *bool {
+	if !v.Has(name) {
+		return nil
+	}
+	value, _ := strconv.ParseBool(v.Get(name))
+	return &value
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket holding up to Burst requests, refilled with
+// PerMinute requests a minute. A zero PerMinute means no limit.
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+// Unlimited tells whether requests are let through without counting them.
+func (l Limit) Unlimited() bool {
+	return l.PerMinute <= 0
+}
+
+// Result tells whether a request was allowed, along with the state of its
+// bucket reported to the client.
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is the wait until a request is allowed again, zero when allowed.
+	RetryAfter time.Duration
+	// ResetAfter is the wait until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// Store keeps the buckets of the clients.
+type Store interface {
+	// Take takes a request from the bucket of key, filling it first with the
+	// requests refilled since it was last taken from.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+	// Sweep forgets the buckets untouched for longer than idle, which are full
+	// again by then for any reasonable limit.
+	Sweep(ctx context.Context, idle time.Duration) error
+}
+
+// bucket is the state of a token bucket, tokens being the requests left as of
+// updatedAt.
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// fullBucket returns a bucket holding a burst of requests as of now.
+func fullBucket(limit Limit, now time.Time) bucket {
+	return bucket{tokens: float64(limit.Burst), updatedAt: now}
+}
+
+// take refills b up to now and takes a request from it when there is one left,
+// returning the bucket updated along with the result.
+func take(b bucket, limit Limit, now time.Time) (bucket, Result) {
+	perSecond := float64(limit.PerMinute) / 60
+	burst := float64(limit.Burst)
+
+	if elapsed := now.Sub(b.updatedAt).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*perSecond)
+		b.updatedAt = now
+	}
+
+	result := Result{Allowed: b.tokens >= 1}
+	if result.Allowed {
+		b.tokens--
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / perSecond)
+	}
+	result.Remaining = int(b.tokens)
+	result.ResetAfter = seconds((burst - b.tokens) / perSecond)
+
+	return b, result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
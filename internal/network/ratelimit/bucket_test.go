@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Take(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	// a request a second, five at once
+	limit := Limit{PerMinute: 60, Burst: 5}
+
+	tests := []struct {
+		name       string
+		bucket     bucket
+		wantBucket bucket
+		wantResult Result
+	}{
+		{
+			name:       "Take From Full Bucket Case",
+			bucket:     fullBucket(limit, now),
+			wantBucket: bucket{tokens: 4, updatedAt: now},
+			wantResult: Result{Allowed: true, Remaining: 4, ResetAfter: time.Second},
+		},
+		{
+			name:       "Take Last Request Case",
+			bucket:     bucket{tokens: 1, updatedAt: now},
+			wantBucket: bucket{tokens: 0, updatedAt: now},
+			wantResult: Result{Allowed: true, Remaining: 0, ResetAfter: 5 * time.Second},
+		},
+		{
+			name:       "Take From Empty Bucket Case",
+			bucket:     bucket{tokens: 0.5, updatedAt: now},
+			wantBucket: bucket{tokens: 0.5, updatedAt: now},
+			wantResult: Result{Allowed: false, Remaining: 0, RetryAfter: 500 * time.Millisecond, ResetAfter: 4500 * time.Millisecond},
+		},
+		{
+			name:       "Take Refilled Bucket Case",
+			bucket:     bucket{tokens: 0, updatedAt: now.Add(-2 * time.Second)},
+			wantBucket: bucket{tokens: 1, updatedAt: now},
+			wantResult: Result{Allowed: true, Remaining: 1, ResetAfter: 4 * time.Second},
+		},
+		{
+			name:       "Take Refilled Up To Burst Case",
+			bucket:     bucket{tokens: 0, updatedAt: now.Add(-time.Hour)},
+			wantBucket: bucket{tokens: 4, updatedAt: now},
+			wantResult: Result{Allowed: true, Remaining: 4, ResetAfter: time.Second},
+		},
+		{
+			name:       "Take Updated Later Case",
+			bucket:     bucket{tokens: 2, updatedAt: now.Add(time.Second)},
+			wantBucket: bucket{tokens: 1, updatedAt: now.Add(time.Second)},
+			wantResult: Result{Allowed: true, Remaining: 1, ResetAfter: 4 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, result := take(tt.bucket, limit, now)
+
+			assert.Equal(t, tt.wantBucket, b)
+			assert.Equal(t, tt.wantResult, result)
+		})
+	}
+}
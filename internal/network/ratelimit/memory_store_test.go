@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Memory_Store(t *testing.T) {
+	limit := Limit{PerMinute: 60, Burst: 2}
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	takeAllowed := func(key string) bool {
+		result, err := store.Take(context.Background(), key, limit)
+		assert.NoError(t, err)
+		return result.Allowed
+	}
+
+	t.Run("Take Burst Case", func(t *testing.T) {
+		assert.True(t, takeAllowed("read:ip:192.0.2.1"))
+		assert.True(t, takeAllowed("read:ip:192.0.2.1"))
+		assert.False(t, takeAllowed("read:ip:192.0.2.1"))
+		assert.True(t, takeAllowed("read:ip:192.0.2.2"))
+	})
+
+	t.Run("Take Refilled Case", func(t *testing.T) {
+		now = now.Add(time.Second)
+
+		assert.True(t, takeAllowed("read:ip:192.0.2.1"))
+		assert.False(t, takeAllowed("read:ip:192.0.2.1"))
+	})
+
+	t.Run("Sweep Idle Buckets Case", func(t *testing.T) {
+		now = now.Add(30 * time.Minute)
+		assert.True(t, takeAllowed("read:ip:192.0.2.3"))
+		now = now.Add(31 * time.Minute)
+
+		assert.NoError(t, store.Sweep(context.Background(), time.Hour))
+
+		assert.NotContains(t, store.buckets, "read:ip:192.0.2.1")
+		assert.NotContains(t, store.buckets, "read:ip:192.0.2.2")
+		assert.Contains(t, store.buckets, "read:ip:192.0.2.3")
+	})
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	errorhandler "github.com/tiagos4ntos/device-manager/internal/network/errors"
+)
+
+// APIKeyHeader identifies the client a request is limited for when it holds
+// one of the configured API keys, its IP being used otherwise.
+const APIKeyHeader = "X-API-Key"
+
+// Response headers reporting the bucket of the client.
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+)
+
+// Config tells the limits of the read requests, GET and HEAD ones, and of the
+// write requests, the other ones. Only the APIKeys are trusted to identify a
+// client, as any other key sent would give a fresh bucket to whoever sent it.
+type Config struct {
+	Skipper middleware.Skipper
+	Read    Limit
+	Write   Limit
+	APIKeys []string
+}
+
+// Middleware limits the requests of each client, answering 429 Too Many
+// Requests once its bucket is empty. Requests are let through when store
+// fails, limiting clients being less important than serving them.
+func Middleware(store Store, config Config) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = middleware.DefaultSkipper
+	}
+	apiKeys := make(map[string]bool, len(config.APIKeys))
+	for _, key := range config.APIKeys {
+		apiKeys[hashKey(key)] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			kind, limit := "write", config.Write
+			if method := c.Request().Method; method == http.MethodGet || method == http.MethodHead {
+				kind, limit = "read", config.Read
+			}
+			if limit.Unlimited() {
+				return next(c)
+			}
+
+			result, err := store.Take(c.Request().Context(), kind+":"+clientKey(c, apiKeys), limit)
+			if err != nil {
+				c.Logger().Errorf("rate limit: %v", err)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderLimit, strconv.Itoa(limit.Burst))
+			header.Set(HeaderRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderReset, ceilSeconds(result.ResetAfter))
+
+			if !result.Allowed {
+				header.Set(echo.HeaderRetryAfter, ceilSeconds(result.RetryAfter))
+				return errorhandler.Handle(c, errorhandler.NewApiError(errorhandler.ErrTooManyRequests, "Too many requests, retry later", nil))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// clientKey identifies the client of the request by its API key, hashed not to
+// keep it, when it is one of apiKeys, or by its IP.
+func clientKey(c echo.Context, apiKeys map[string]bool) string {
+	if key := c.Request().Header.Get(APIKeyHeader); key != "" {
+		if hashed := hashKey(key); apiKeys[hashed] {
+			return "key:" + hashed
+		}
+	}
+	return "ip:" + c.RealIP()
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore fails to take from the buckets, as when the database is down.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func (failingStore) Sweep(context.Context, time.Duration) error {
+	return nil
+}
+
+func newTestServer(store Store, config Config) *echo.Echo {
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+	e.Use(Middleware(store, config))
+	e.GET("/devices", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.POST("/devices", func(c echo.Context) error { return c.NoContent(http.StatusCreated) })
+	return e
+}
+
+func send(e *echo.Echo, method, remoteAddr, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/devices", nil)
+	req.RemoteAddr = remoteAddr
+	if apiKey != "" {
+		req.Header.Set(APIKeyHeader, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_Middleware(t *testing.T) {
+	config := Config{
+		Read:  Limit{PerMinute: 60, Burst: 2},
+		Write: Limit{PerMinute: 6, Burst: 1},
+	}
+
+	t.Run("Limit Reads Case", func(t *testing.T) {
+		e := newTestServer(NewMemoryStore(), config)
+
+		first := send(e, http.MethodGet, "192.0.2.1:4000", "")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "2", first.Header().Get(HeaderLimit))
+		assert.Equal(t, "1", first.Header().Get(HeaderRemaining))
+		assert.Equal(t, "1", first.Header().Get(HeaderReset))
+
+		assert.Equal(t, http.StatusOK, send(e, http.MethodGet, "192.0.2.1:4000", "").Code)
+
+		limited := send(e, http.MethodGet, "192.0.2.1:4001", "")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+		assert.Equal(t, "0", limited.Header().Get(HeaderRemaining))
+		assert.Equal(t, "1", limited.Header().Get(echo.HeaderRetryAfter))
+		assert.JSONEq(t, `{"error":"Too many requests, retry later"}`, limited.Body.String())
+	})
+
+	t.Run("Limit Writes Apart From Reads Case", func(t *testing.T) {
+		e := newTestServer(NewMemoryStore(), config)
+
+		assert.Equal(t, http.StatusCreated, send(e, http.MethodPost, "192.0.2.1:4000", "").Code)
+		limited := send(e, http.MethodPost, "192.0.2.1:4000", "")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+		assert.Equal(t, "10", limited.Header().Get(echo.HeaderRetryAfter))
+
+		assert.Equal(t, http.StatusOK, send(e, http.MethodGet, "192.0.2.1:4000", "").Code)
+	})
+
+	t.Run("Limit By API Key Case", func(t *testing.T) {
+		e := newTestServer(NewMemoryStore(), Config{Write: config.Write, APIKeys: []string{"key-a", "key-b"}})
+
+		assert.Equal(t, http.StatusCreated, send(e, http.MethodPost, "192.0.2.1:4000", "key-a").Code)
+		assert.Equal(t, http.StatusCreated, send(e, http.MethodPost, "192.0.2.1:4000", "key-b").Code)
+		assert.Equal(t, http.StatusCreated, send(e, http.MethodPost, "192.0.2.1:4000", "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(e, http.MethodPost, "192.0.2.2:4000", "key-a").Code)
+	})
+
+	t.Run("Unknown API Keys Limited By IP Case", func(t *testing.T) {
+		e := newTestServer(NewMemoryStore(), Config{Write: config.Write, APIKeys: []string{"key-a"}})
+
+		assert.Equal(t, http.StatusCreated, send(e, http.MethodPost, "192.0.2.1:4000", "random-1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(e, http.MethodPost, "192.0.2.1:4000", "random-2").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(e, http.MethodPost, "192.0.2.1:4000", "").Code)
+		assert.Equal(t, http.StatusCreated, send(e, http.MethodPost, "192.0.2.1:4000", "key-a").Code)
+	})
+
+	t.Run("Unlimited Case", func(t *testing.T) {
+		e := newTestServer(NewMemoryStore(), Config{Write: config.Write})
+
+		for range 5 {
+			rec := send(e, http.MethodGet, "192.0.2.1:4000", "")
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Empty(t, rec.Header().Get(HeaderLimit))
+		}
+	})
+
+	t.Run("Skipped Case", func(t *testing.T) {
+		e := newTestServer(NewMemoryStore(), Config{
+			Skipper: func(echo.Context) bool { return true },
+			Write:   config.Write,
+		})
+
+		assert.Equal(t, http.StatusCreated, send(e, http.MethodPost, "192.0.2.1:4000", "").Code)
+		assert.Equal(t, http.StatusCreated, send(e, http.MethodPost, "192.0.2.1:4000", "").Code)
+	})
+
+	t.Run("Store Failing Case", func(t *testing.T) {
+		e := newTestServer(failingStore{}, config)
+
+		rec := send(e, http.MethodPost, "192.0.2.1:4000", "")
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get(HeaderLimit))
+	})
+}
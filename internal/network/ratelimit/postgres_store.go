@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tiagos4ntos/device-manager/internal/database"
+)
+
+type postgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore returns a store keeping the buckets in the database, the
+// limits being shared by every instance. The clock of the database is used so
+// the instances agree on the time elapsed.
+func NewPostgresStore(db *sql.DB) *postgresStore {
+	return &postgresStore{db: db}
+}
+
+func (s *postgresStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback()
+
+	// a bucket not taken from yet is full
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+	VALUES ($1, $2, now())
+	ON CONFLICT (key) DO NOTHING`, key, limit.Burst)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var b bucket
+	var now time.Time
+	err = tx.QueryRowContext(ctx, `
+	SELECT tokens, updated_at, now()
+	FROM rate_limit_buckets
+	WHERE key = $1
+	FOR UPDATE`, key).Scan(&b.tokens, &b.updatedAt, &now)
+	if err != nil {
+		return Result{}, err
+	}
+
+	b, result := take(b, limit, now)
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE rate_limit_buckets
+	SET tokens = $2, updated_at = $3
+	WHERE key = $1`, key, b.tokens, b.updatedAt)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return result, tx.Commit()
+}
+
+func (s *postgresStore) Sweep(ctx context.Context, idle time.Duration) error {
+	ctx, cancel := database.OperationContext(ctx)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, `
+	DELETE FROM rate_limit_buckets
+	WHERE updated_at < now() - make_interval(secs => $1)`, idle.Seconds())
+	return err
+}
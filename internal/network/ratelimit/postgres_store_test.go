@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/tiagos4ntos/device-manager/internal/database"
+)
+
+func Test_Postgres_Store_Take(t *testing.T) {
+	assert := assert.New(t)
+
+	insertQuery := regexp.QuoteMeta(`
+	INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+	VALUES ($1, $2, now())
+	ON CONFLICT (key) DO NOTHING`)
+
+	lockQuery := regexp.QuoteMeta(`
+	SELECT tokens, updated_at, now()
+	FROM rate_limit_buckets
+	WHERE key = $1
+	FOR UPDATE`)
+
+	updateQuery := regexp.QuoteMeta(`
+	UPDATE rate_limit_buckets
+	SET tokens = $2, updated_at = $3
+	WHERE key = $1`)
+
+	const key = "write:ip:192.0.2.1"
+	limit := Limit{PerMinute: 60, Burst: 5}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		sqlMock    func(mock sqlmock.Sqlmock)
+		wantResult Result
+		wantedErr  error
+	}{
+		{
+			name: "Take Allowed Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertQuery).
+					WithArgs(key, 5).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(lockQuery).
+					WithArgs(key).
+					WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at", "now"}).AddRow(1.0, now.Add(-2*time.Second), now))
+				mock.ExpectExec(updateQuery).
+					WithArgs(key, 2.0, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantResult: Result{Allowed: true, Remaining: 2, ResetAfter: 3 * time.Second},
+		},
+		{
+			name: "Take Denied Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertQuery).
+					WithArgs(key, 5).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(lockQuery).
+					WithArgs(key).
+					WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at", "now"}).AddRow(0.0, now.Add(-500*time.Millisecond), now))
+				mock.ExpectExec(updateQuery).
+					WithArgs(key, 0.5, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantResult: Result{Allowed: false, Remaining: 0, RetryAfter: 500 * time.Millisecond, ResetAfter: 4500 * time.Millisecond},
+		},
+		{
+			name: "Take Database Error Case",
+			sqlMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertQuery).
+					WithArgs(key, 5).
+					WillReturnError(errors.New("connection reset"))
+				mock.ExpectRollback()
+			},
+			wantedErr: errors.New("connection reset"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(err)
+			defer db.Close()
+
+			tc.sqlMock(mock)
+
+			result, err := NewPostgresStore(db).Take(context.Background(), key, limit)
+
+			assert.Equal(tc.wantedErr, err)
+			assert.Equal(tc.wantResult, result)
+			assert.NoError(mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_Postgres_Store_Take_Operation_Timeout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO rate_limit_buckets`)).
+		WillDelayFor(time.Second).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	ctx := database.WithOperationTimeout(context.Background(), 10*time.Millisecond)
+
+	began := time.Now()
+	_, err = NewPostgresStore(db).Take(ctx, "write:ip:192.0.2.1", Limit{PerMinute: 60, Burst: 5})
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(began), 500*time.Millisecond)
+}
+
+func Test_Postgres_Store_Sweep(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+	DELETE FROM rate_limit_buckets
+	WHERE updated_at < now() - make_interval(secs => $1)`)).
+		WithArgs(3600.0).
+		WillReturnResult(sqlmock.NewResult(0, 12))
+
+	assert.NoError(t, NewPostgresStore(db).Sweep(context.Background(), time.Hour))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
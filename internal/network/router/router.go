@@ -1,14 +1,83 @@
 package router
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4"
+	"github.com/tiagos4ntos/device-manager/internal/network/apidoc"
 	"github.com/tiagos4ntos/device-manager/internal/network/handler"
 )
 
-func RegisterRoutes(e *echo.Echo, dh handler.DeviceHandler) {
+func RegisterRoutes(e *echo.Echo, dh handler.DeviceHandler, adh handler.AttributeDefinitionHandler, bh handler.BrandHandler, mh handler.ModelHandler, lh handler.LocationHandler, rh handler.ReservationHandler, mth handler.MaintenanceHandler, rph handler.ReportHandler, dph handler.DisposalHandler, gh handler.GraphQLHandler, hh handler.HealthHandler) {
 	e.POST("/devices", dh.Create())
 	e.GET("/devices", dh.List())
+	e.GET("/devices/stats", dh.Stats())
+	e.GET("/devices/by-serial/:serial", dh.GetBySerialNumber())
 	e.GET("/devices/:id", dh.GetByID())
 	e.PUT("/devices/:id", dh.Update())
 	e.DELETE("/devices/:id", dh.Delete())
+	e.POST("/devices/:id/move", dh.Move())
+	e.GET("/devices/:id/history", dh.History())
+	e.POST("/devices/:id/reservations", rh.Create())
+	e.GET("/devices/:id/reservations", rh.ListByDevice())
+	e.POST("/devices/:id/maintenance", mth.Open())
+	e.POST("/devices/:id/retire", dph.Retire())
+	e.GET("/devices/:id/disposal", dph.GetByDevice())
+
+	e.GET("/attribute-definitions", adh.List())
+	e.PUT("/attribute-definitions/:name", adh.Put())
+	e.DELETE("/attribute-definitions/:name", adh.Delete())
+
+	e.POST("/brands", bh.Create())
+	e.GET("/brands", bh.List())
+	e.GET("/brands/:id", bh.GetByID())
+	e.PUT("/brands/:id", bh.Update())
+	e.DELETE("/brands/:id", bh.Delete())
+
+	e.POST("/models", mh.Create())
+	e.GET("/models", mh.List())
+	e.GET("/models/:id", mh.GetByID())
+	e.PUT("/models/:id", mh.Update())
+	e.DELETE("/models/:id", mh.Delete())
+
+	e.POST("/locations", lh.Create())
+	e.GET("/locations", lh.List())
+	e.GET("/locations/:id", lh.GetByID())
+	e.GET("/locations/:id/devices", lh.ListDevices())
+	e.PUT("/locations/:id", lh.Update())
+	e.DELETE("/locations/:id", lh.Delete())
+
+	e.GET("/reservations", rh.List())
+	e.GET("/reservations/:id", rh.GetByID())
+	e.POST("/reservations/:id/checkout", rh.CheckOut())
+	e.DELETE("/reservations/:id", rh.Cancel())
+
+	e.GET("/maintenance", mth.List())
+	e.GET("/maintenance/:id", mth.GetByID())
+	e.POST("/maintenance/:id/close", mth.Close())
+
+	e.GET("/reports/warranty", rph.Warranty())
+	e.GET("/reports/depreciation", rph.Depreciation())
+
+	e.POST("/graphql", gh.Query())
+
+	e.GET("/health/live", hh.Live())
+	e.GET("/health/ready", hh.Ready())
+}
+
+// QueryOperations lists the routes whose query string is declared by a schema,
+// their Swagger parameters are generated from it.
+func QueryOperations() []apidoc.Operation {
+	return []apidoc.Operation{
+		{Method: http.MethodGet, Path: "/devices", Query: handler.ListDevicesQuerySchema},
+		{Method: http.MethodGet, Path: "/devices/stats", Query: handler.DeviceStatsQuerySchema},
+		{Method: http.MethodGet, Path: "/models", Query: handler.ListModelsQuerySchema},
+		{Method: http.MethodGet, Path: "/locations", Query: handler.ListLocationsQuerySchema},
+		{Method: http.MethodGet, Path: "/locations/{id}/devices", Query: handler.ListDevicesQuerySchema},
+		{Method: http.MethodGet, Path: "/devices/{id}/reservations", Query: handler.ListDeviceReservationsQuerySchema},
+		{Method: http.MethodGet, Path: "/reservations", Query: handler.ListReservationsQuerySchema},
+		{Method: http.MethodGet, Path: "/maintenance", Query: handler.ListMaintenanceQuerySchema},
+		{Method: http.MethodGet, Path: "/reports/warranty", Query: handler.WarrantyReportQuerySchema},
+		{Method: http.MethodGet, Path: "/reports/depreciation", Query: handler.DepreciationReportQuerySchema},
+	}
 }
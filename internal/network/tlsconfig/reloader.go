@@ -0,0 +1,153 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval is how often, at most, the files are checked for renewed
+// certificates, on the handshakes.
+const checkInterval = 30 * time.Second
+
+// Reloader serves the certificate read from files, along with the CAs
+// verifying the client certificates when informed, reading them again once
+// renewed so they are picked up without a restart.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.Mutex
+	config    *tls.Config
+	files     map[string]time.Time
+	checkedAt time.Time
+	now       func() time.Time
+}
+
+// NewReloader reads the certificate and key, and the client CAs when
+// clientCAFile is informed, failing when they are not valid.
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		now:          time.Now,
+	}
+
+	files, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+	if r.config, err = r.load(); err != nil {
+		return nil, err
+	}
+	r.files = files
+	r.checkedAt = r.now()
+
+	return r, nil
+}
+
+// TLSConfig returns the server configuration, negotiating HTTP/2 and serving
+// the certificates in effect on each handshake.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current(), nil
+		},
+	}
+}
+
+// current returns the configuration of the certificates, reading them again
+// when the files changed since last checked. Certificates failing to load are
+// logged and the previous ones kept, as the files may be halfway written.
+func (r *Reloader) current() *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.now().Sub(r.checkedAt) < checkInterval {
+		return r.config
+	}
+	r.checkedAt = r.now()
+
+	files, err := r.modTimes()
+	if err != nil {
+		log.Printf("tls: failed to check the certificates, keeping the ones in effect: %v", err)
+		return r.config
+	}
+	if sameModTimes(files, r.files) {
+		return r.config
+	}
+
+	config, err := r.load()
+	if err != nil {
+		log.Printf("tls: failed to reload the certificates, keeping the ones in effect: %v", err)
+		return r.config
+	}
+	r.config, r.files = config, files
+	log.Printf("tls: certificates reloaded")
+
+	return r.config
+}
+
+func (r *Reloader) load() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tls cert or key: %w", err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2", "http/1.1"},
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls client ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("invalid tls client ca: no certificate found")
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+func (r *Reloader) modTimes() (map[string]time.Time, error) {
+	files := map[string]time.Time{}
+	for _, path := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		files[path] = info.ModTime()
+	}
+	return files, nil
+}
+
+func sameModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, modTime := range a {
+		if !modTime.Equal(b[path]) {
+			return false
+		}
+	}
+	return true
+}
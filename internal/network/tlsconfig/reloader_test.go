@@ -0,0 +1,174 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCertificate writes a self-signed certificate for name and its key,
+// returning their paths along with the certificate.
+func writeCertificate(t *testing.T, dir, name string) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+".key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile, cert
+}
+
+// servedCertificate returns the leaf certificate of the configuration in effect.
+func servedCertificate(t *testing.T, r *Reloader) *x509.Certificate {
+	config, err := r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	assert.NoError(t, err)
+	return cert
+}
+
+func Test_Reloader(t *testing.T) {
+	t.Run("Reload Renewed Certificate Case", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile, cert := writeCertificate(t, dir, "server")
+		r, err := NewReloader(certFile, keyFile, "")
+		assert.NoError(t, err)
+		now := time.Now()
+		r.now = func() time.Time { return now }
+
+		_, _, renewed := writeCertificate(t, dir, "server")
+		future := now.Add(time.Hour)
+		assert.NoError(t, os.Chtimes(certFile, future, future))
+
+		assert.Equal(t, cert.SerialNumber, servedCertificate(t, r).SerialNumber)
+
+		now = now.Add(checkInterval)
+		assert.Equal(t, renewed.SerialNumber, servedCertificate(t, r).SerialNumber)
+	})
+
+	t.Run("Reload Invalid Certificate Keeping Previous Case", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile, cert := writeCertificate(t, dir, "server")
+		r, err := NewReloader(certFile, keyFile, "")
+		assert.NoError(t, err)
+		now := time.Now()
+		r.now = func() time.Time { return now }
+
+		assert.NoError(t, os.WriteFile(certFile, []byte("halfway written"), 0o600))
+		future := now.Add(time.Hour)
+		assert.NoError(t, os.Chtimes(certFile, future, future))
+		now = now.Add(checkInterval)
+
+		assert.Equal(t, cert.SerialNumber, servedCertificate(t, r).SerialNumber)
+	})
+
+	t.Run("New Reloader Invalid Key Case", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, _, _ := writeCertificate(t, dir, "server")
+		_, otherKeyFile, _ := writeCertificate(t, dir, "other")
+
+		_, err := NewReloader(certFile, otherKeyFile, "")
+
+		assert.ErrorContains(t, err, "invalid tls cert or key")
+	})
+
+	t.Run("New Reloader Invalid Client CA Case", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile, _ := writeCertificate(t, dir, "server")
+
+		_, err := NewReloader(certFile, keyFile, keyFile)
+
+		assert.ErrorContains(t, err, "invalid tls client ca: no certificate found")
+	})
+}
+
+func Test_Reloader_Client_Verification(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, serverCert := writeCertificate(t, dir, "server")
+	clientCertFile, clientKeyFile, _ := writeCertificate(t, dir, "client")
+	otherCertFile, otherKeyFile, _ := writeCertificate(t, dir, "other")
+
+	r, err := NewReloader(certFile, keyFile, clientCertFile)
+	assert.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCert)
+
+	// handshake returns the protocol negotiated by the client along with the
+	// error of the server handshake, which verifies the client certificate.
+	handshake := func(clientCertFile, clientKeyFile string) (string, error) {
+		clientConn, serverConn := net.Pipe()
+		defer clientConn.Close()
+		defer serverConn.Close()
+
+		serverErr := make(chan error, 1)
+		go func() { serverErr <- tls.Server(serverConn, r.TLSConfig()).Handshake() }()
+
+		config := &tls.Config{RootCAs: roots, ServerName: "server", NextProtos: []string{"h2"}}
+		if clientCertFile != "" {
+			cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+			assert.NoError(t, err)
+			config.Certificates = []tls.Certificate{cert}
+		}
+		client := tls.Client(clientConn, config)
+		if err := client.Handshake(); err == nil {
+			// drains the alert the server sends when rejecting the certificate
+			go io.Copy(io.Discard, client)
+		}
+
+		return client.ConnectionState().NegotiatedProtocol, <-serverErr
+	}
+
+	t.Run("Handshake Verified Client Case", func(t *testing.T) {
+		protocol, err := handshake(clientCertFile, clientKeyFile)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "h2", protocol)
+	})
+
+	t.Run("Handshake Unknown Client Case", func(t *testing.T) {
+		_, err := handshake(otherCertFile, otherKeyFile)
+
+		assert.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("Handshake Without Client Certificate Case", func(t *testing.T) {
+		_, err := handshake("", "")
+
+		assert.ErrorContains(t, err, "certificate")
+	})
+}